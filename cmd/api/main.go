@@ -4,13 +4,20 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/GeorgiiMalishev/ideas-platform/config"
 	_ "github.com/GeorgiiMalishev/ideas-platform/docs"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
 	dbPkg "github.com/GeorgiiMalishev/ideas-platform/internal/db"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/handlers"
-	"github.com/GeorgiiMalishev/ideas-platform/internal/minio"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/jwtkeys"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/otpsender"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/router"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
@@ -32,83 +39,183 @@ func main() {
 		return
 	}
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{}))
+	if err := cfg.Validate(); err != nil {
+		logger.Error("Invalid config:", slog.String("error", err.Error()))
+		return
+	}
+	logger.Info("Loaded config", slog.Any("config", cfg.Redacted()))
+
+	// Cancelled on SIGINT or SIGTERM to stop background jobs and shut the server down.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	db, err := dbPkg.InitDB(cfg)
 	if err != nil {
 		logger.Error("Failed to connect to database:", slog.String("error", err.Error()))
 		return
 	}
-	minioClient, err := minio.NewMinioClient(&cfg.ImageDB)
+	store, err := blobstore.New(cfg)
 	if err != nil {
-		logger.Error("Failed to connect to minio:", slog.String("error", err.Error()))
+		logger.Error("Failed to create storage:", slog.String("error", err.Error()))
 		return
 	}
-	imageUsecase := usecase.NewImageUsecase(minioClient, cfg.ImageDB.BucketName)
-	err = imageUsecase.CreateBucket(context.Background())
-	if err != nil {
-		logger.Error("Failed to create minio bucket:", slog.String("error", err.Error()))
+	if err := store.Init(ctx); err != nil {
+		logger.Error("Failed to initialize storage:", slog.String("error", err.Error()), slog.String("driver", cfg.Storage.Driver))
 		return
 	}
 
-	adminRoleID, err := dbPkg.Setup(db, logger)
+	ownerRoleID, err := dbPkg.Setup(ctx, db, logger)
 	if err != nil {
 		logger.Error("Failed to setup database:", slog.String("error", err.Error()))
 		return
 	}
 
 	workerCsRepo := repository.NewWorkerCoffeeShopRepository(db)
+	roleRepo := repository.NewRoleRepository(db)
+	orgRepo := repository.NewOrganizationRepository(db)
+	accessControlUsecase := usecase.NewAccessControlUsecase(workerCsRepo, orgRepo, logger)
 
 	userRepo := repository.NewUserRepository(db)
-	userUsecase := usecase.NewUserUsecase(userRepo, workerCsRepo, logger)
+	userUsecase := usecase.NewUserUsecase(userRepo, workerCsRepo, accessControlUsecase, logger)
 	userHandler := handlers.NewUserHandler(userUsecase, logger)
 
 	coffeeShopRepo := repository.NewCoffeeShopRepository(db)
-	csUscase := usecase.NewCoffeeShopUsecase(coffeeShopRepo, workerCsRepo, adminRoleID, logger)
+	csUscase := usecase.NewCoffeeShopUsecase(coffeeShopRepo, workerCsRepo, accessControlUsecase, ownerRoleID, logger)
 	csHandler := handlers.NewCoffeeShopHandler(csUscase, logger)
 
+	otpSender, err := otpsender.NewOTPSender(&cfg.AuthConfig.OTPSenderConfig, logger)
+	if err != nil {
+		logger.Error("Failed to create OTP sender:", slog.String("error", err.Error()))
+		return
+	}
+
+	jwtKeys, err := jwtkeys.Load(&cfg.AuthConfig.JWTConfig)
+	if err != nil {
+		logger.Error("Failed to load JWT keys:", slog.String("error", err.Error()))
+		return
+	}
+	logger.Info("Loaded JWT keys", slog.String("signing_kid", jwtKeys.SigningKeyID()), slog.Int("verification_keys", len(jwtKeys.PublicKeys())))
+
 	authRepo := repository.NewAuthRepository(db)
-	authUsecase := usecase.NewAuthUsecase(authRepo, coffeeShopRepo, workerCsRepo, db, "1234567890", &cfg.AuthConfig, logger)
+	authUsecase := usecase.NewAuthUsecase(authRepo, coffeeShopRepo, workerCsRepo, db, jwtKeys, &cfg.AuthConfig, otpSender, logger)
 	authHandler := handlers.NewAuthHandler(authUsecase, logger)
 
 	ideaStatusRepo := repository.NewIdeaStatusRepository(db)
-	ideaStatusUsecase := usecase.NewIdeaStatusUsecase(ideaStatusRepo, logger)
+	ideaStatusUsecase := usecase.NewIdeaStatusUsecase(ideaStatusRepo, accessControlUsecase, logger)
 	ideaStatusHandler := handlers.NewIdeaStatusHandler(ideaStatusUsecase, logger)
 
+	bannedUserRepo := repository.NewBannedUserRepository(db)
+	banUsecase := usecase.NewBanUsecase(bannedUserRepo, workerCsRepo, userRepo, accessControlUsecase, logger)
+	banHandler := handlers.NewBanHandler(banUsecase, logger)
+
 	ideaRepo := repository.NewIdeaRepository(db)
 	likeRepo := repository.NewLikeRepository(db)
-	ideaUsecase := usecase.NewIdeaUsecase(ideaRepo, workerCsRepo, likeRepo, ideaStatusRepo, logger)
+
+	imageUploadRepo := repository.NewImageUploadRepository(db)
+	storedImageRepo := repository.NewStoredImageRepository(db)
+	imageUsecase := usecase.NewImageUsecase(store, imageUploadRepo, storedImageRepo, cfg.ImageDB.BucketName, &cfg.Image, logger)
+	ideaUsecase := usecase.NewIdeaUsecase(ideaRepo, accessControlUsecase, likeRepo, ideaStatusRepo, bannedUserRepo, imageUsecase, logger)
 	ideaHandler := handlers.NewIdeaHandler(ideaUsecase, imageUsecase, logger)
 
-	imageHandler := handlers.NewImageHandler(imageUsecase, cfg, logger)
+	imageHandler := handlers.NewImageHandler(imageUsecase, logger)
+	// The background jobs stop with ctx; the shutdown waits for them before closing the database.
+	var jobs sync.WaitGroup
+	jobs.Add(2)
+	go func() {
+		defer jobs.Done()
+		usecase.RunUploadCleaner(ctx, imageUsecase, cfg.Image.UploadCleanupInterval, logger)
+	}()
+	go func() {
+		defer jobs.Done()
+		usecase.RunImageGC(ctx, imageUsecase, cfg.Image.GCInterval, cfg.Image.GCDryRun, logger)
+	}()
+	// The local driver receives direct uploads itself when it is reachable from clients.
+	var storageHandler *handlers.StorageHandler
+	if local, ok := store.(*blobstore.Local); ok && cfg.Storage.LocalPublicURL != "" {
+		storageHandler = handlers.NewStorageHandler(local, logger)
+	}
 
-	likeUsecase := usecase.NewLikeUsecase(likeRepo, logger)
+	likeUsecase := usecase.NewLikeUsecase(likeRepo, ideaRepo, bannedUserRepo, logger)
 	likeHandler := handlers.NewLikeHandler(likeUsecase, logger)
 
 	rewardRepo := repository.NewRewardRepository(db)
-	rewardUsecase := usecase.NewRewardUsecase(rewardRepo, ideaRepo, logger)
+	rewardTypeRepo := repository.NewRewardTypeRepository(db)
+	rewardUsecase := usecase.NewRewardUsecase(rewardRepo, rewardTypeRepo, ideaRepo, accessControlUsecase, logger)
 	rewardHandler := handlers.NewRewardHandler(rewardUsecase, logger)
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		usecase.RunRewardExpirySweeper(ctx, rewardUsecase, cfg.Reward.ExpirySweepInterval, logger)
+	}()
 
-	rewardTypeRepo := repository.NewRewardTypeRepository(db)
-	rewardTypeUsecase := usecase.NewRewardTypeUsecase(rewardTypeRepo, coffeeShopRepo, workerCsRepo, logger)
+	rewardTypeUsecase := usecase.NewRewardTypeUsecase(rewardTypeRepo, coffeeShopRepo, accessControlUsecase, logger)
 	rewardTypeHandler := handlers.NewRewardTypeHandler(rewardTypeUsecase, logger)
 
-	workerCoffeeShopUsecase := usecase.NewWorkerCoffeeShopUsecase(workerCsRepo, coffeeShopRepo, userRepo, logger)
+	workerCoffeeShopUsecase := usecase.NewWorkerCoffeeShopUsecase(workerCsRepo, coffeeShopRepo, userRepo, roleRepo, accessControlUsecase, logger)
 	workerCoffeeShopHandler := handlers.NewWorkerCoffeeShopHandler(workerCoffeeShopUsecase, logger)
 
-	accessControlUsecase := usecase.NewAccessControlUsecase(workerCsRepo, logger)
+	invitationRepo := repository.NewWorkerInvitationRepository(db)
+	invitationUsecase := usecase.NewWorkerInvitationUsecase(invitationRepo, workerCsRepo, userRepo, roleRepo, accessControlUsecase, &cfg.Invitation, logger)
+	invitationHandler := handlers.NewWorkerInvitationHandler(invitationUsecase, logger)
+
+	ownershipRepo := repository.NewOwnershipTransferRepository(db)
+	ownershipUsecase := usecase.NewOwnershipTransferUsecase(ownershipRepo, workerCsRepo, accessControlUsecase, logger)
+	ownershipHandler := handlers.NewOwnershipTransferHandler(ownershipUsecase, logger)
+
+	organizationUsecase := usecase.NewOrganizationUsecase(orgRepo, userRepo, roleRepo, accessControlUsecase, ownerRoleID, logger)
+	organizationHandler := handlers.NewOrganizationHandler(organizationUsecase, logger)
+
 	categoryRepo := repository.NewCategoryRepository(db)
 	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, accessControlUsecase)
 	categoryHandler := handlers.NewCategoryHandler(categoryUsecase, logger)
 
 	commentRepo := repository.NewCommentRepository(db)
-	commentUsecase := usecase.NewCommentUsecase(commentRepo, ideaRepo, workerCsRepo, logger)
+	commentUsecase := usecase.NewCommentUsecase(commentRepo, ideaRepo, accessControlUsecase, bannedUserRepo, logger)
 	commentHandler := handlers.NewCommentHandler(commentUsecase, logger)
 
-	ar := router.NewRouter(cfg, userHandler, csHandler, authHandler, ideaHandler, rewardHandler, rewardTypeHandler, workerCoffeeShopHandler, likeHandler, categoryHandler, commentHandler, ideaStatusHandler, workerCsRepo, imageHandler, authUsecase, logger)
-	r := ar.SetupRouter()
-	err = r.Run(":8080")
-	if err != nil {
-		fmt.Println("Failed to start server:", err)
+	healthHandler := handlers.NewHealthHandler(logger,
+		handlers.ReadinessCheck{Name: "postgres", Check: func(ctx context.Context) error { return dbPkg.Ping(ctx, db) }},
+		handlers.ReadinessCheck{Name: "storage", Check: store.Ping},
+	)
+
+	ar := router.NewRouter(cfg, userHandler, csHandler, authHandler, ideaHandler, rewardHandler, rewardTypeHandler, workerCoffeeShopHandler, likeHandler, categoryHandler, commentHandler, ideaStatusHandler, workerCsRepo, imageHandler, storageHandler, banHandler, invitationHandler, ownershipHandler, organizationHandler, healthHandler, authUsecase, logger)
+	srv := &http.Server{
+		Addr:              cfg.Server.Address(),
+		Handler:           ar.SetupRouter(),
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Info("Starting server", slog.String("address", srv.Addr))
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		logger.Error("Failed to start server:", slog.String("error", err.Error()))
 		return
+	case <-ctx.Done():
+		// A second signal kills the process without waiting for the shutdown.
+		stop()
+	}
+
+	logger.Info("Shutting down", slog.Duration("drain_delay", cfg.Server.DrainDelay), slog.Duration("timeout", cfg.Server.ShutdownTimeout))
+	// Keep serving while /readyz reports 503, so the load balancer takes the
+	// instance out of rotation before the listener closes.
+	healthHandler.Drain()
+	time.Sleep(cfg.Server.DrainDelay)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to shut down gracefully:", slog.String("error", err.Error()))
+	}
+	jobs.Wait()
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
 	}
+	logger.Info("Server stopped")
 }
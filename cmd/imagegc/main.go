@@ -0,0 +1,105 @@
+// Command imagegc reports the stored images that no idea or upload refers to and that are
+// older than IMAGE_GCGRACEPERIOD, using the same environment variables as the API server.
+// The API deletes them periodically; this command shows what it would delete.
+//
+// Usage:
+//
+//	imagegc [-json]           list the images without deleting them
+//	imagegc -delete [-json]   delete the images and list what was deleted
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
+	dbPkg "github.com/GeorgiiMalishev/ideas-platform/internal/db"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
+)
+
+func main() {
+	deleteImages := flag.Bool("delete", false, "delete the images instead of only listing them")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	// Logs go to stderr, so that the report on stdout can be piped.
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{}))
+	if err := run(context.Background(), *deleteImages, *asJSON, logger); err != nil {
+		logger.Error("Image garbage collection failed:", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, deleteImages, asJSON bool, logger *slog.Logger) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	db, err := dbPkg.InitDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+	if _, err := dbPkg.Setup(ctx, db, logger); err != nil {
+		return err
+	}
+	store, err := blobstore.New(cfg)
+	if err != nil {
+		return err
+	}
+	if err := store.Ping(ctx); err != nil {
+		return fmt.Errorf("storage is not available: %w", err)
+	}
+
+	imageUsecase := usecase.NewImageUsecase(store, repository.NewImageUploadRepository(db), repository.NewStoredImageRepository(db),
+		cfg.ImageDB.BucketName, &cfg.Image, logger)
+	report, err := imageUsecase.CollectGarbage(ctx, !deleteImages)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	printReport(os.Stdout, report, cfg.Image.GCGracePeriod)
+	return nil
+}
+
+func printReport(out io.Writer, report *dto.ImageGCReport, gracePeriod time.Duration) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "IMAGE\tSIZE\tSTORED AT")
+	for _, image := range report.Images {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", image.ImageURL, image.Size, image.CreatedAt.UTC().Format(time.RFC3339))
+	}
+	w.Flush()
+
+	verb := "Deleted"
+	if report.DryRun {
+		verb = "Would delete"
+	}
+	fmt.Fprintf(out, "\n%s %d unreferenced images older than %s, %d bytes.\n", verb, len(report.Images), gracePeriod, report.Bytes)
+	if report.Failed > 0 {
+		fmt.Fprintf(out, "Failed to delete %d images; they are retried on the next run.\n", report.Failed)
+	}
+	if report.DryRun {
+		fmt.Fprintln(out, "Nothing was deleted. Run with -delete to delete them.")
+	}
+}
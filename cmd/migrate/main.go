@@ -0,0 +1,124 @@
+// Command migrate applies the versioned SQL migrations of the migrations package to the
+// database configured by the same environment variables as the API server.
+//
+// Usage:
+//
+//	migrate up [N]     apply all pending migrations, or only the next N
+//	migrate down [N]   roll back the last N migrations, one by default
+//	migrate version    print the current version of the schema
+//	migrate force V    set the version without running migrations, after repairing a dirty schema
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	dbPkg "github.com/GeorgiiMalishev/ideas-platform/internal/db"
+	"github.com/golang-migrate/migrate/v4"
+)
+
+const usage = "usage: migrate up [N] | down [N] | version | force V"
+
+// migrateLogger reports the migrations applied by golang-migrate through slog.
+type migrateLogger struct {
+	logger *slog.Logger
+}
+
+func (l migrateLogger) Printf(format string, v ...any) {
+	l.logger.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l migrateLogger) Verbose() bool {
+	return false
+}
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{}))
+	if err := run(os.Args[1:], logger); err != nil {
+		logger.Error("Migration failed:", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func run(args []string, logger *slog.Logger) error {
+	if len(args) == 0 || len(args) > 2 {
+		return errors.New(usage)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	m, err := dbPkg.NewMigrate(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	m.Log = migrateLogger{logger: logger}
+
+	switch args[0] {
+	case "up":
+		if len(args) == 1 {
+			err = m.Up()
+			break
+		}
+		n, parseErr := parseCount(args[1])
+		if parseErr != nil {
+			return parseErr
+		}
+		err = m.Steps(n)
+	case "down":
+		n := 1
+		if len(args) == 2 {
+			var parseErr error
+			if n, parseErr = parseCount(args[1]); parseErr != nil {
+				return parseErr
+			}
+		}
+		err = m.Steps(-n)
+	case "version":
+		if len(args) != 1 {
+			return errors.New(usage)
+		}
+	case "force":
+		if len(args) != 2 {
+			return errors.New(usage)
+		}
+		version, parseErr := strconv.Atoi(args[1])
+		if parseErr != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		err = m.Force(version)
+	default:
+		return errors.New(usage)
+	}
+	if errors.Is(err, migrate.ErrNoChange) {
+		logger.Info("No migrations to apply")
+	} else if err != nil {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		logger.Info("Schema has no migrations applied")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Schema version", slog.Uint64("version", uint64(version)), slog.Bool("dirty", dirty))
+	return nil
+}
+
+func parseCount(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid number of migrations %q", arg)
+	}
+	return n, nil
+}
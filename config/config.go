@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
 	"github.com/caarlos0/env/v11"
@@ -12,21 +14,82 @@ type Config struct {
 	Server     ServerConfig
 	DB         DBConfig
 	ImageDB    ImageDBConfig
+	Storage    StorageConfig
+	Image      ImageConfig
 	App        AppConfig
 	AuthConfig AuthConfig
+	Reward     RewardConfig
+	Invitation InvitationConfig
 }
 
 type ImageDBConfig struct {
 	Endpoint        string `env:"MINIO_ENDPOINT" envDefault:"localhost:9000"`
-	AccessKeyID     string `env:"MINIO_ACCESS_KEY_ID,required"`
-	SecretAccessKey string `env:"MINIO_SECRET_ACCESS_KEY,required"`
+	AccessKeyID     string `env:"MINIO_ACCESS_KEY_ID"`
+	SecretAccessKey string `env:"MINIO_SECRET_ACCESS_KEY"`
 	UseSSL          bool   `env:"MINIO_USE_SSL" envDefault:"false"`
 	BucketName      string `env:"MINIO_BUCKET_NAME" envDefault:"images"`
 }
 
+// Storage drivers.
+const (
+	StorageMinIO = "minio"
+	StorageLocal = "local"
+)
+
+// StorageConfig selects where uploaded files are kept: in MinIO or any S3-compatible storage
+// configured by ImageDBConfig, or in a local directory for small self-hosted setups.
+type StorageConfig struct {
+	Driver   string `env:"STORAGE_DRIVER" envDefault:"minio"`
+	LocalDir string `env:"STORAGE_LOCALDIR" envDefault:"data/uploads"`
+	// LocalPublicURL is the external URL of the upload endpoint of the local driver, which
+	// presigned upload URLs point to. Presigned uploads are unavailable when it is empty.
+	LocalPublicURL string `env:"STORAGE_LOCALPUBLICURL"`
+	// LocalSigningSecret signs the presigned upload URLs of the local driver.
+	LocalSigningSecret string `env:"STORAGE_LOCALSIGNINGSECRET"`
+}
+
+// ImageConfig limits uploaded images and sets the sizes of the variants generated for them.
+type ImageConfig struct {
+	// MaxBytes caps the size of an uploaded file.
+	MaxBytes int64 `env:"IMAGE_MAXBYTES" envDefault:"10485760"`
+	// MaxDimension caps the width and the height of an uploaded image in pixels.
+	MaxDimension int `env:"IMAGE_MAXDIMENSION" envDefault:"6000"`
+	// ThumbnailSize and MediumSize are the longest side of the variants; smaller images are not upscaled.
+	ThumbnailSize int `env:"IMAGE_THUMBNAILSIZE" envDefault:"320"`
+	MediumSize    int `env:"IMAGE_MEDIUMSIZE" envDefault:"1280"`
+	JPEGQuality   int `env:"IMAGE_JPEGQUALITY" envDefault:"85"`
+	// UploadURLExpiry is how long a presigned upload URL accepts the file.
+	UploadURLExpiry time.Duration `env:"IMAGE_UPLOADURLEXPIRY" envDefault:"15m"`
+	// UploadTTL is how long an upload can be finalized after it was requested; unfinalized
+	// uploads are deleted afterwards, every UploadCleanupInterval.
+	UploadTTL             time.Duration `env:"IMAGE_UPLOADTTL" envDefault:"1h"`
+	UploadCleanupInterval time.Duration `env:"IMAGE_UPLOADCLEANUPINTERVAL" envDefault:"10m"`
+	// GCInterval is how often images no idea refers to are looked for. Only images stored
+	// longer than GCGracePeriod ago are deleted, so that an image is not lost between its
+	// upload and the save of the idea. With GCDryRun they are only reported.
+	GCInterval    time.Duration `env:"IMAGE_GCINTERVAL" envDefault:"1h"`
+	GCGracePeriod time.Duration `env:"IMAGE_GCGRACEPERIOD" envDefault:"24h"`
+	GCDryRun      bool          `env:"IMAGE_GCDRYRUN" envDefault:"false"`
+}
+
 type ServerConfig struct {
-	Host string `env:"SERVER_HOST" envDefault:"localhost"`
-	Port int    `env:"SERVER_PORT" envDefault:"8080"`
+	// Host is the interface the server listens on, empty for all interfaces.
+	Host              string        `env:"SERVER_HOST"`
+	Port              int           `env:"SERVER_PORT" envDefault:"8080"`
+	ReadHeaderTimeout time.Duration `env:"SERVER_READHEADERTIMEOUT" envDefault:"5s"`
+	ReadTimeout       time.Duration `env:"SERVER_READTIMEOUT" envDefault:"30s"`
+	WriteTimeout      time.Duration `env:"SERVER_WRITETIMEOUT" envDefault:"60s"`
+	IdleTimeout       time.Duration `env:"SERVER_IDLETIMEOUT" envDefault:"120s"`
+	// ShutdownTimeout is how long in-flight requests may take to finish after SIGTERM.
+	ShutdownTimeout time.Duration `env:"SERVER_SHUTDOWNTIMEOUT" envDefault:"20s"`
+	// DrainDelay is how long /readyz reports 503 before the listener closes, so load
+	// balancers stop routing new requests to the instance first.
+	DrainDelay time.Duration `env:"SERVER_DRAINDELAY" envDefault:"5s"`
+}
+
+// Address returns the host:port the HTTP server listens on.
+func (c ServerConfig) Address() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
 }
 
 type DBConfig struct {
@@ -43,25 +106,65 @@ type AppConfig struct {
 	Version string `env:"APP_VERSION,required"`
 }
 
+type RewardConfig struct {
+	// ExpirySweepInterval is how often rewards past their expiry are marked as expired.
+	ExpirySweepInterval time.Duration `env:"REWARD_EXPIRYSWEEPINTERVAL" envDefault:"10m"`
+}
+
+type InvitationConfig struct {
+	// TTL is how long a worker invitation stays valid when the request does not set its lifetime.
+	TTL time.Duration `env:"INVITATION_TTL" envDefault:"72h"`
+	// MaxTTL caps the lifetime requested for an invitation.
+	MaxTTL time.Duration `env:"INVITATION_MAXTTL" envDefault:"720h"`
+}
+
 type AuthConfig struct {
-	OTPConfig OTPConfig `envPrefix:"AUTH_OTPCONFIG_"`
-	JWTConfig JWTConfig `envPrefix:"AUTH_JWTCONFIG_"`
+	OTPConfig       OTPConfig       `envPrefix:"AUTH_OTPCONFIG_"`
+	JWTConfig       JWTConfig       `envPrefix:"AUTH_JWTCONFIG_"`
+	OTPSenderConfig OTPSenderConfig `envPrefix:"AUTH_OTPSENDER_"`
 }
 
 type OTPConfig struct {
-	ExpiresAtTimer        time.Duration `env:"EXPIRESATTIMER"`
-	AttemptsLeft          int           `env:"ATTEMPTSLEFT"`
-	ResetResendCountTimer time.Duration `env:"RESETRESENDCOUNTTIMER"`
-	SoftAttemptsCount     int           `env:"SOFTATTEMPTSCOUNT"`
-	HardAttemptsCount     int           `env:"HARDATTEMPTSCOUNT"`
-	SubSoftAttemptsTimer  time.Duration `env:"SUBSOFTATTEMPTSTIMER"`
-	SubHardAttemptsTimer  time.Duration `env:"SUBHARDATTEMPTSTIMER"`
-	PostHardAttemptsCount time.Duration `env:"POSTHARDATTEMPTSCOUNT"`
+	ExpiresAtTimer        time.Duration `env:"EXPIRESATTIMER" envDefault:"5m"`
+	AttemptsLeft          int           `env:"ATTEMPTSLEFT" envDefault:"3"`
+	ResetResendCountTimer time.Duration `env:"RESETRESENDCOUNTTIMER" envDefault:"1m"`
+	SoftAttemptsCount     int           `env:"SOFTATTEMPTSCOUNT" envDefault:"5"`
+	HardAttemptsCount     int           `env:"HARDATTEMPTSCOUNT" envDefault:"10"`
+	SubSoftAttemptsTimer  time.Duration `env:"SUBSOFTATTEMPTSTIMER" envDefault:"10m"`
+	SubHardAttemptsTimer  time.Duration `env:"SUBHARDATTEMPTSTIMER" envDefault:"30m"`
+	PostHardAttemptsCount time.Duration `env:"POSTHARDATTEMPTSCOUNT" envDefault:"60m"`
+}
+
+// OTPSenderConfig selects and configures the provider used to deliver OTP codes.
+// Provider is one of "sms", "telegram", "file" or "memory".
+type OTPSenderConfig struct {
+	Provider    string        `env:"PROVIDER" envDefault:"file"`
+	MaxAttempts int           `env:"MAXATTEMPTS" envDefault:"3"`
+	RetryDelay  time.Duration `env:"RETRYDELAY" envDefault:"1s"`
+	Timeout     time.Duration `env:"TIMEOUT" envDefault:"10s"`
+
+	SMSGatewayURL    string `env:"SMSGATEWAYURL"`
+	SMSGatewayAPIKey string `env:"SMSGATEWAYAPIKEY"`
+	SMSSenderName    string `env:"SMSSENDERNAME"`
+
+	TelegramAPIURL string `env:"TELEGRAMAPIURL" envDefault:"https://gatewayapi.telegram.org"`
+	TelegramToken  string `env:"TELEGRAMTOKEN"`
+
+	FilePath string `env:"FILEPATH" envDefault:"otp_codes.log"`
 }
 
 type JWTConfig struct {
-	RefreshTokenTimer time.Duration `env:"REFRESHTOKENTIMER"`
-	JWTTokenTimer     time.Duration `env:"JWTTOKENTIMER"`
+	// SigningKeyFile is a PEM private key (RSA or Ed25519) that signs access tokens.
+	// When it is empty tokens are signed with HS256 and Secret.
+	SigningKeyFile string `env:"SIGNINGKEYFILE"`
+	// VerificationKeyFiles are PEM keys of previous signing keys that are still accepted,
+	// so that rotating the signing key does not invalidate issued tokens.
+	VerificationKeyFiles []string `env:"VERIFICATIONKEYFILES" envSeparator:","`
+	// Secret signs access tokens when no signing key file is set. Validate rejects an empty
+	// secret and, in production, a weak one.
+	Secret            string        `env:"SECRET"`
+	RefreshTokenTimer time.Duration `env:"REFRESHTOKENTIMER" envDefault:"168h"`
+	JWTTokenTimer     time.Duration `env:"JWTTOKENTIMER" envDefault:"15m"`
 }
 
 func Load() (*Config, error) {
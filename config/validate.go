@@ -0,0 +1,163 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	EnvProduction = "production"
+
+	minProductionSecretLength = 32
+	redacted                  = "[REDACTED]"
+)
+
+// weakSecrets are placeholder values from examples and local setups that must never reach production.
+var weakSecrets = []string{"1234567890", "secret", "changeme", "password", "postgres", "minioadmin", "test-secret", "change-me-local-development-secret"}
+
+// IsProduction reports whether the service runs with APP_ENV=production.
+func (c *Config) IsProduction() bool {
+	return strings.EqualFold(c.App.Env, EnvProduction)
+}
+
+// Validate checks that the loaded values make sense. Timers and counters are
+// checked in every environment; secret strength is enforced only in production.
+// All problems are reported at once.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	positive := func(name string, d time.Duration) {
+		check(d > 0, "%s must be positive, got %s", name, d)
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "SERVER_PORT must be between 1 and 65535, got %d", c.Server.Port)
+	positive("SERVER_READHEADERTIMEOUT", c.Server.ReadHeaderTimeout)
+	positive("SERVER_READTIMEOUT", c.Server.ReadTimeout)
+	positive("SERVER_WRITETIMEOUT", c.Server.WriteTimeout)
+	positive("SERVER_IDLETIMEOUT", c.Server.IdleTimeout)
+	positive("SERVER_SHUTDOWNTIMEOUT", c.Server.ShutdownTimeout)
+	check(c.Server.DrainDelay >= 0, "SERVER_DRAINDELAY must not be negative, got %s", c.Server.DrainDelay)
+	check(c.DB.Port > 0 && c.DB.Port <= 65535, "DB_PORT must be between 1 and 65535, got %d", c.DB.Port)
+
+	jwt := c.AuthConfig.JWTConfig
+	check(jwt.Secret != "" || jwt.SigningKeyFile != "", "AUTH_JWTCONFIG_SECRET or AUTH_JWTCONFIG_SIGNINGKEYFILE must be set")
+	check(jwt.SigningKeyFile != "" || len(jwt.VerificationKeyFiles) == 0,
+		"AUTH_JWTCONFIG_VERIFICATIONKEYFILES requires AUTH_JWTCONFIG_SIGNINGKEYFILE")
+	positive("AUTH_JWTCONFIG_JWTTOKENTIMER", jwt.JWTTokenTimer)
+	positive("AUTH_JWTCONFIG_REFRESHTOKENTIMER", jwt.RefreshTokenTimer)
+	check(jwt.RefreshTokenTimer > jwt.JWTTokenTimer,
+		"AUTH_JWTCONFIG_REFRESHTOKENTIMER (%s) must be longer than AUTH_JWTCONFIG_JWTTOKENTIMER (%s)", jwt.RefreshTokenTimer, jwt.JWTTokenTimer)
+
+	otp := c.AuthConfig.OTPConfig
+	positive("AUTH_OTPCONFIG_EXPIRESATTIMER", otp.ExpiresAtTimer)
+	positive("AUTH_OTPCONFIG_RESETRESENDCOUNTTIMER", otp.ResetResendCountTimer)
+	positive("AUTH_OTPCONFIG_SUBSOFTATTEMPTSTIMER", otp.SubSoftAttemptsTimer)
+	positive("AUTH_OTPCONFIG_SUBHARDATTEMPTSTIMER", otp.SubHardAttemptsTimer)
+	positive("AUTH_OTPCONFIG_POSTHARDATTEMPTSCOUNT", otp.PostHardAttemptsCount)
+	check(otp.AttemptsLeft > 0, "AUTH_OTPCONFIG_ATTEMPTSLEFT must be positive, got %d", otp.AttemptsLeft)
+	check(otp.SoftAttemptsCount > 0, "AUTH_OTPCONFIG_SOFTATTEMPTSCOUNT must be positive, got %d", otp.SoftAttemptsCount)
+	check(otp.HardAttemptsCount >= otp.SoftAttemptsCount,
+		"AUTH_OTPCONFIG_HARDATTEMPTSCOUNT (%d) must not be less than AUTH_OTPCONFIG_SOFTATTEMPTSCOUNT (%d)", otp.HardAttemptsCount, otp.SoftAttemptsCount)
+	check(otp.SubSoftAttemptsTimer <= otp.SubHardAttemptsTimer && otp.SubHardAttemptsTimer <= otp.PostHardAttemptsCount,
+		"OTP resend delays must not decrease: soft %s, hard %s, post hard %s", otp.SubSoftAttemptsTimer, otp.SubHardAttemptsTimer, otp.PostHardAttemptsCount)
+
+	sender := c.AuthConfig.OTPSenderConfig
+	check(sender.MaxAttempts > 0, "AUTH_OTPSENDER_MAXATTEMPTS must be positive, got %d", sender.MaxAttempts)
+	check(sender.RetryDelay >= 0, "AUTH_OTPSENDER_RETRYDELAY must not be negative, got %s", sender.RetryDelay)
+	positive("AUTH_OTPSENDER_TIMEOUT", sender.Timeout)
+
+	switch c.Storage.Driver {
+	case StorageMinIO:
+		check(c.ImageDB.AccessKeyID != "" && c.ImageDB.SecretAccessKey != "",
+			"MINIO_ACCESS_KEY_ID and MINIO_SECRET_ACCESS_KEY must be set for the minio storage driver")
+	case StorageLocal:
+		check(c.Storage.LocalDir != "", "STORAGE_LOCALDIR must be set for the local storage driver")
+		check(c.Storage.LocalPublicURL == "" || c.Storage.LocalSigningSecret != "",
+			"STORAGE_LOCALPUBLICURL requires STORAGE_LOCALSIGNINGSECRET")
+	default:
+		check(false, "STORAGE_DRIVER must be %q or %q, got %q", StorageMinIO, StorageLocal, c.Storage.Driver)
+	}
+
+	image := c.Image
+	check(image.MaxBytes > 0, "IMAGE_MAXBYTES must be positive, got %d", image.MaxBytes)
+	check(image.MaxDimension > 0, "IMAGE_MAXDIMENSION must be positive, got %d", image.MaxDimension)
+	check(image.ThumbnailSize > 0 && image.ThumbnailSize <= image.MediumSize,
+		"IMAGE_THUMBNAILSIZE (%d) must be positive and not larger than IMAGE_MEDIUMSIZE (%d)", image.ThumbnailSize, image.MediumSize)
+	check(image.JPEGQuality >= 1 && image.JPEGQuality <= 100, "IMAGE_JPEGQUALITY must be between 1 and 100, got %d", image.JPEGQuality)
+	positive("IMAGE_UPLOADURLEXPIRY", image.UploadURLExpiry)
+	check(image.UploadTTL >= image.UploadURLExpiry,
+		"IMAGE_UPLOADTTL (%s) must not be less than IMAGE_UPLOADURLEXPIRY (%s)", image.UploadTTL, image.UploadURLExpiry)
+	positive("IMAGE_UPLOADCLEANUPINTERVAL", image.UploadCleanupInterval)
+	positive("IMAGE_GCINTERVAL", image.GCInterval)
+	positive("IMAGE_GCGRACEPERIOD", image.GCGracePeriod)
+
+	positive("REWARD_EXPIRYSWEEPINTERVAL", c.Reward.ExpirySweepInterval)
+	positive("INVITATION_TTL", c.Invitation.TTL)
+	check(c.Invitation.MaxTTL >= c.Invitation.TTL,
+		"INVITATION_MAXTTL (%s) must not be less than INVITATION_TTL (%s)", c.Invitation.MaxTTL, c.Invitation.TTL)
+
+	if c.IsProduction() {
+		if jwt.SigningKeyFile == "" {
+			check(len(jwt.Secret) >= minProductionSecretLength && !isWeakSecret(jwt.Secret),
+				"AUTH_JWTCONFIG_SECRET is too weak for production: use at least %d random characters", minProductionSecretLength)
+		}
+		check(!isWeakSecret(c.DB.Password), "DB_PASSWORD is too weak for production")
+		// The file and memory senders only keep the codes on the server, users never get them.
+		switch sender.Provider {
+		case "sms":
+			check(sender.SMSGatewayURL != "" && sender.SMSGatewayAPIKey != "",
+				"AUTH_OTPSENDER_SMSGATEWAYURL and AUTH_OTPSENDER_SMSGATEWAYAPIKEY must be set for the sms provider")
+		case "telegram":
+			check(sender.TelegramToken != "", "AUTH_OTPSENDER_TELEGRAMTOKEN must be set for the telegram provider")
+		default:
+			check(false, `AUTH_OTPSENDER_PROVIDER must be "sms" or "telegram" in production, got %q`, sender.Provider)
+		}
+		if c.Storage.Driver == StorageMinIO {
+			check(!isWeakSecret(c.ImageDB.SecretAccessKey), "MINIO_SECRET_ACCESS_KEY is too weak for production")
+		}
+		if c.Storage.LocalPublicURL != "" {
+			check(len(c.Storage.LocalSigningSecret) >= minProductionSecretLength && !isWeakSecret(c.Storage.LocalSigningSecret),
+				"STORAGE_LOCALSIGNINGSECRET is too weak for production: use at least %d random characters", minProductionSecretLength)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func isWeakSecret(secret string) bool {
+	if len(secret) < 8 {
+		return true
+	}
+	for _, weak := range weakSecrets {
+		if strings.EqualFold(secret, weak) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redacted returns a copy of the config that is safe to log: passwords, keys and tokens
+// are replaced with a placeholder, and left empty when they are not set.
+func (c *Config) Redacted() Config {
+	out := *c
+	out.DB.Password = redact(out.DB.Password)
+	out.ImageDB.SecretAccessKey = redact(out.ImageDB.SecretAccessKey)
+	out.Storage.LocalSigningSecret = redact(out.Storage.LocalSigningSecret)
+	out.AuthConfig.JWTConfig.Secret = redact(out.AuthConfig.JWTConfig.Secret)
+	out.AuthConfig.OTPSenderConfig.SMSGatewayAPIKey = redact(out.AuthConfig.OTPSenderConfig.SMSGatewayAPIKey)
+	out.AuthConfig.OTPSenderConfig.TelegramToken = redact(out.AuthConfig.OTPSenderConfig.TelegramToken)
+	return out
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
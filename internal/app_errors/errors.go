@@ -76,3 +76,15 @@ func NewErrConflict(message string) error {
 func (err *ErrConflict) Error() string {
 	return err.message
 }
+
+type ErrDeliveryFailed struct {
+	message string
+}
+
+func NewErrDeliveryFailed(message string) error {
+	return &ErrDeliveryFailed{message: message}
+}
+
+func (err *ErrDeliveryFailed) Error() string {
+	return err.message
+}
@@ -0,0 +1,96 @@
+// Package blobstore stores uploaded files behind a driver chosen by config: MinIO or any
+// S3-compatible storage, or a local directory for setups without an object storage.
+//
+// Keys are slash-separated paths such as "<uuid>/original.jpg". They never start with a
+// slash and never contain "." or ".." segments.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/minio"
+)
+
+var (
+	// ErrNotFound is returned when no object is stored under the key.
+	ErrNotFound = errors.New("object not found")
+	// ErrInvalidKey is returned for keys that could escape the store, such as "../secret".
+	ErrInvalidKey = errors.New("invalid object key")
+	// ErrPresignNotSupported is returned by stores that are not configured to accept direct uploads.
+	ErrPresignNotSupported = errors.New("presigned uploads are not configured")
+)
+
+// ObjectInfo describes a stored object.
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	// ETag changes whenever the content of the object changes.
+	ETag         string
+	LastModified time.Time
+}
+
+// Range selects Length bytes starting at Offset. It must lie within the object.
+type Range struct {
+	Offset int64
+	Length int64
+}
+
+// PresignedRequest is an upload the client sends directly to the store, without passing
+// the file through the API. The request must carry Headers exactly as given.
+type PresignedRequest struct {
+	Method    string
+	URL       string
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
+
+// BlobStore keeps files under keys.
+type BlobStore interface {
+	// Init prepares the store for use, creating the bucket or the directory when missing.
+	Init(ctx context.Context) error
+	// Ping checks that the store is reachable.
+	Ping(ctx context.Context) error
+	// Put stores size bytes read from r under key, replacing any previous object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object, or only the part selected by rng when it is not nil.
+	// The returned info always describes the whole object.
+	Get(ctx context.Context, key string, rng *Range) (io.ReadCloser, ObjectInfo, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// PresignPut returns an upload of exactly size bytes of contentType to key that is valid until expiry passes.
+	PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (*PresignedRequest, error)
+}
+
+// New returns the store selected by cfg.Storage.Driver.
+func New(cfg *config.Config) (BlobStore, error) {
+	switch cfg.Storage.Driver {
+	case config.StorageMinIO:
+		client, err := minio.NewMinioClient(&cfg.ImageDB)
+		if err != nil {
+			return nil, err
+		}
+		return NewMinIO(client, cfg.ImageDB.BucketName), nil
+	case config.StorageLocal:
+		return NewLocal(cfg.Storage.LocalDir, cfg.Storage.LocalPublicURL, []byte(cfg.Storage.LocalSigningSecret)), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
+
+// validateKey rejects keys that are not clean relative paths.
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key || key == "." ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tempPrefix marks files that are still being written; they are never served.
+const tempPrefix = ".upload-"
+
+// Local stores objects as files under a directory. The content type is derived from the
+// file extension, so keys must carry one.
+type Local struct {
+	root      string
+	publicURL string
+	secret    []byte
+}
+
+// NewLocal returns a store in the directory root. Presigned uploads point to publicURL
+// followed by the key and are signed with secret; they are unavailable when publicURL is empty.
+func NewLocal(root, publicURL string, secret []byte) *Local {
+	return &Local{root: root, publicURL: strings.TrimRight(publicURL, "/"), secret: secret}
+}
+
+func (s *Local) Init(ctx context.Context) error {
+	return os.MkdirAll(s.root, 0o750)
+}
+
+func (s *Local) Ping(ctx context.Context) error {
+	info, err := os.Stat(s.root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", s.root)
+	}
+	return nil
+}
+
+// Put writes to a temporary file first, so readers never see a partly written object.
+func (s *Local) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(name)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, tempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if err == nil && size >= 0 && written != size {
+		err = fmt.Errorf("expected %d bytes, got %d", size, written)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (s *Local) Get(ctx context.Context, key string, rng *Range) (io.ReadCloser, ObjectInfo, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, ObjectInfo{}, s.mapError(key, err)
+	}
+	stat, err := f.Stat()
+	if err == nil && stat.IsDir() {
+		err = fs.ErrNotExist
+	}
+	if err != nil {
+		f.Close()
+		return nil, ObjectInfo{}, s.mapError(key, err)
+	}
+	info := s.info(key, stat)
+	if rng == nil {
+		return f, info, nil
+	}
+
+	if _, err := f.Seek(rng.Offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, ObjectInfo{}, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(f, rng.Length), f}, info, nil
+}
+
+func (s *Local) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	stat, err := os.Stat(name)
+	if err == nil && stat.IsDir() {
+		err = fs.ErrNotExist
+	}
+	if err != nil {
+		return ObjectInfo{}, s.mapError(key, err)
+	}
+	return s.info(key, stat), nil
+}
+
+// Delete removes the file and the directories the removal left empty.
+func (s *Local) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	root := filepath.Clean(s.root)
+	for dir := filepath.Dir(name); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// PresignPut returns a URL of the upload endpoint carrying an HMAC of the key, the content
+// type, the size and the expiry. The endpoint checks it with VerifyPut.
+func (s *Local) PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (*PresignedRequest, error) {
+	if s.publicURL == "" || len(s.secret) == 0 {
+		return nil, ErrPresignNotSupported
+	}
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(expiry).Truncate(time.Second)
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expiresAt.Unix(), 10)},
+		"signature": {s.sign(key, contentType, size, expiresAt.Unix())},
+	}
+	return &PresignedRequest{
+		Method:    http.MethodPut,
+		URL:       s.publicURL + "/" + key + "?" + query.Encode(),
+		Headers:   map[string]string{"Content-Type": contentType, "Content-Length": strconv.FormatInt(size, 10)},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// VerifyPut checks that an upload of size bytes of contentType to key carries the query of
+// a URL returned by PresignPut that has not expired.
+func (s *Local) VerifyPut(key, contentType string, size int64, query url.Values, now time.Time) error {
+	if len(s.secret) == 0 {
+		return ErrPresignNotSupported
+	}
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return errors.New("invalid upload signature")
+	}
+	expected := s.sign(key, contentType, size, expires)
+	if !hmac.Equal([]byte(expected), []byte(query.Get("signature"))) {
+		return errors.New("invalid upload signature")
+	}
+	if now.Unix() > expires {
+		return errors.New("upload URL has expired")
+	}
+	return nil
+}
+
+func (s *Local) sign(key, contentType string, size, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "PUT\n%s\n%s\n%d\n%d", key, contentType, size, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Local) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(path.Base(key), tempPrefix) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// info describes a file. Its ETag changes with the modification time and the size, which
+// is enough because objects are only ever replaced as a whole by Put.
+func (s *Local) info(key string, stat fs.FileInfo) ObjectInfo {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  contentType,
+		ETag:         fmt.Sprintf("%x-%x", stat.ModTime().UnixNano(), stat.Size()),
+		LastModified: stat.ModTime(),
+	}
+}
+
+func (s *Local) mapError(key string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return err
+}
@@ -0,0 +1,124 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// MinIO stores objects in a bucket of MinIO or another S3-compatible storage.
+type MinIO struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewMinIO(client *minio.Client, bucket string) *MinIO {
+	return &MinIO{client: client, bucket: bucket}
+}
+
+func (s *MinIO) Init(ctx context.Context) error {
+	found, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{})
+	}
+	return nil
+}
+
+func (s *MinIO) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %q does not exist", s.bucket)
+	}
+	return nil
+}
+
+func (s *MinIO) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (s *MinIO) Get(ctx context.Context, key string, rng *Range) (io.ReadCloser, ObjectInfo, error) {
+	// A ranged response only describes the range, so the whole object is looked up first.
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	opts := minio.GetObjectOptions{}
+	if rng != nil {
+		if err := opts.SetRange(rng.Offset, rng.Offset+rng.Length-1); err != nil {
+			return nil, ObjectInfo{}, err
+		}
+	}
+	object, err := s.client.GetObject(ctx, s.bucket, key, opts)
+	if err != nil {
+		return nil, ObjectInfo{}, s.mapError(key, err)
+	}
+	return object, info, nil
+}
+
+func (s *MinIO) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := s.client.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, s.mapError(key, err)
+	}
+	return ObjectInfo{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+func (s *MinIO) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// PresignPut signs the Content-Type and Content-Length headers into the URL, so the
+// storage rejects uploads of another type or size.
+func (s *MinIO) PresignPut(ctx context.Context, key, contentType string, size int64, expiry time.Duration) (*PresignedRequest, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	headers.Set("Content-Length", strconv.FormatInt(size, 10))
+	u, err := s.client.PresignHeader(ctx, http.MethodPut, s.bucket, key, expiry, nil, headers)
+	if err != nil {
+		return nil, err
+	}
+	return &PresignedRequest{
+		Method:    http.MethodPut,
+		URL:       u.String(),
+		Headers:   map[string]string{"Content-Type": contentType, "Content-Length": strconv.FormatInt(size, 10)},
+		ExpiresAt: time.Now().Add(expiry),
+	}, nil
+}
+
+func (s *MinIO) mapError(key string, err error) error {
+	if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return err
+}
@@ -1,14 +1,10 @@
 package db
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"log"
 
 	"github.com/GeorgiiMalishev/ideas-platform/config"
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -25,25 +21,11 @@ func InitDB(cfg *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
-func RunMigrations(migrationsPath string, cfg *config.Config) error {
-	m, err := migrate.New(
-		migrationsPath,
-		fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-			cfg.DB.User, cfg.DB.Password, cfg.DB.Host, cfg.DB.Port, cfg.DB.Name, cfg.DB.SSLMode),
-	)
+// Ping checks that the database behind db accepts connections.
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
 	if err != nil {
-		return fmt.Errorf("failed to create migrate instance: %w", err)
+		return err
 	}
-	defer m.Close()
-
-	if err := m.Up(); err != nil {
-		if !errors.Is(err, migrate.ErrNoChange) {
-			return fmt.Errorf("failed to run migrations: %w", err)
-		}
-		log.Println("No new migrations to apply")
-		return nil
-	}
-
-	log.Println("Migrations applied successfully")
-	return nil
+	return sqlDB.PingContext(ctx)
 }
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/GeorgiiMalishev/ideas-platform/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"gorm.io/gorm"
+)
+
+// schemaMigrationsTable is where golang-migrate records the applied version.
+const schemaMigrationsTable = "schema_migrations"
+
+var (
+	// ErrSchemaNotInitialized is returned when no migration was ever applied to the database.
+	ErrSchemaNotInitialized = errors.New("database schema is not initialized, run the migrate command")
+	// ErrSchemaDirty is returned when a migration failed halfway and the schema has to be repaired by hand.
+	ErrSchemaDirty = errors.New("database schema is dirty after a failed migration, fix it and run the migrate command with force")
+	// ErrSchemaOutdated is returned when the database misses migrations the application needs.
+	ErrSchemaOutdated = errors.New("database schema is outdated, run the migrate command")
+)
+
+// NewMigrate returns a migrator for the database of cfg with the embedded migrations.
+// The caller has to close it.
+func NewMigrate(cfg *config.Config) (*migrate.Migrate, error) {
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.DB.User, cfg.DB.Password),
+		Host:     fmt.Sprintf("%s:%d", cfg.DB.Host, cfg.DB.Port),
+		Path:     cfg.DB.Name,
+		RawQuery: url.Values{"sslmode": {cfg.DB.SSLMode}}.Encode(),
+	}
+	m, err := migrate.NewWithSourceInstance("iofs", source, dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+	return m, nil
+}
+
+// RunMigrations applies all migrations the database of cfg does not have yet.
+func RunMigrations(cfg *config.Config) error {
+	m, err := NewMigrate(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return nil
+}
+
+// LatestSchemaVersion returns the version of the newest embedded migration, which is the
+// version the application is built against.
+func LatestSchemaVersion() (uint, error) {
+	files, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, name := range files {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name %q", name)
+		}
+		latest = max(latest, uint(version))
+	}
+	if latest == 0 {
+		return 0, errors.New("no migrations embedded")
+	}
+	return latest, nil
+}
+
+// SchemaVersion returns the migration version the database is at and whether the last
+// migration failed. It only reads the version table and never changes the database.
+func SchemaVersion(ctx context.Context, db *gorm.DB) (uint, bool, error) {
+	if !db.Migrator().HasTable(schemaMigrationsTable) {
+		return 0, false, ErrSchemaNotInitialized
+	}
+
+	var state struct {
+		Version int64
+		Dirty   bool
+	}
+	result := db.WithContext(ctx).Raw("SELECT version, dirty FROM " + schemaMigrationsTable + " LIMIT 1").Scan(&state)
+	if result.Error != nil {
+		return 0, false, result.Error
+	}
+	if result.RowsAffected == 0 || state.Version < 0 {
+		return 0, false, ErrSchemaNotInitialized
+	}
+	return uint(state.Version), state.Dirty, nil
+}
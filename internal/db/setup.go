@@ -1,6 +1,8 @@
 package db
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
@@ -8,41 +10,33 @@ import (
 	"gorm.io/gorm"
 )
 
-func Setup(db *gorm.DB, logger *slog.Logger) (uuid.UUID, error) {
-	err := db.AutoMigrate(
-		&models.User{},
-		&models.BannedUser{},
-		&models.Role{},
-		&models.CoffeeShop{},
-		&models.WorkerCoffeeShop{},
-		&models.Category{},
-		&models.Idea{},
-		&models.IdeaLike{},
-		&models.IdeaComment{},
-		&models.IdeaStatus{},
-		&models.Reward{},
-		&models.RewardType{},
-		&models.OTP{},
-		&models.UserRefreshToken{},
-	)
+// Setup checks that the schema is at the version the application is built against and
+// returns the ID of the owner role. The schema itself is changed only by cmd/migrate.
+func Setup(ctx context.Context, db *gorm.DB, logger *slog.Logger) (uuid.UUID, error) {
+	latest, err := LatestSchemaVersion()
 	if err != nil {
 		return uuid.Nil, err
 	}
-
-	statuses := []string{"Создана", "В работе", "Реализована", "Отклонена"}
-	for _, title := range statuses {
-		err := db.FirstOrCreate(&models.IdeaStatus{Title: title}, "title = ?", title).Error
-		if err != nil {
-			logger.Error("Failed to seed status:", slog.String("status", title), slog.String("error", err.Error()))
-		}
+	version, dirty, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return uuid.Nil, err
 	}
-
-	adminRole := models.Role{
-		Name: "admin",
+	if dirty {
+		return uuid.Nil, fmt.Errorf("%w (version %d)", ErrSchemaDirty, version)
 	}
-	if err := db.FirstOrCreate(&adminRole, "name = ?", "admin").Error; err != nil {
-		return uuid.Nil, err
+	if version < latest {
+		return uuid.Nil, fmt.Errorf("%w (version %d, expected %d)", ErrSchemaOutdated, version, latest)
+	}
+	if version > latest {
+		// Migrations are applied before a rollout, so instances of the previous release
+		// keep running against the newer schema until they are replaced.
+		logger.Warn("Database schema is newer than the application",
+			slog.Uint64("version", uint64(version)), slog.Uint64("expected", uint64(latest)))
 	}
 
-	return adminRole.ID, nil
+	var ownerRole models.Role
+	if err := db.WithContext(ctx).Where("name = ?", models.RoleOwner).First(&ownerRole).Error; err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find the owner role: %w", err)
+	}
+	return ownerRole.ID, nil
 }
@@ -5,9 +5,11 @@ type RegisterAdminRequest struct {
 	Password       string `json:"password" binding:"required"`
 	CoffeeShopName string `json:"coffee_shop_name" binding:"required"`
 	Address        string `json:"address" binding:"required"`
+	DeviceName     string `json:"device_name,omitempty"`
 }
 
 type AdminLoginRequest struct {
-	Login    string `json:"login" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Login      string `json:"login" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	DeviceName string `json:"device_name,omitempty"`
 }
@@ -1,14 +1,17 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
 type VerifyOTPRequest struct {
-	Phone string `json:"phone"`
-	OTP   string `json:"otp"`
-	Name  string `json:"name,omitempty"`
+	Phone      string `json:"phone"`
+	OTP        string `json:"otp"`
+	Name       string `json:"name,omitempty"`
+	DeviceName string `json:"device_name,omitempty"`
 }
 
 type AuthResponse struct {
@@ -25,6 +28,7 @@ type AdminAuthResponse struct {
 type JWTClaims struct {
 	jwt.RegisteredClaims
 	UserID       uuid.UUID `json:"user_id"`
+	SessionID    uuid.UUID `json:"sid"`
 	RefreshToken string    `json:"refresh_token"`
 }
 
@@ -35,3 +39,39 @@ type RefreshRequest struct {
 type LogoutRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
+
+// ClientInfo describes the device a session is started or refreshed from.
+type ClientInfo struct {
+	DeviceName string
+	IP         string
+	UserAgent  string
+}
+
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	DeviceName string    `json:"device_name,omitempty"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Current marks the session of the access token used for the request.
+	Current bool `json:"current"`
+}
+
+// JWK is a public key that verifies platform access tokens.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSResponse is the JSON Web Key Set published at /.well-known/jwks.json.
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
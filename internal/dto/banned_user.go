@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BanUserRequest struct {
+	UserID    uuid.UUID  `json:"user_id" binding:"required"`
+	Reason    *string    `json:"reason" binding:"omitempty,max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	// HideIdeas hides the user's existing ideas from the coffee shop feed while the ban is active.
+	HideIdeas bool `json:"hide_ideas"`
+}
+
+type BannedUserResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       *uuid.UUID `json:"user_id"`
+	UserName     string     `json:"user_name"`
+	CoffeeShopID *uuid.UUID `json:"coffee_shop_id"`
+	BannedByID   *uuid.UUID `json:"banned_by_id"`
+	Reason       *string    `json:"reason"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	HideIdeas    bool       `json:"hide_ideas"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
@@ -12,9 +12,11 @@ type UpdateCategory struct {
 	Description *string `json:"description"`
 }
 
+// CategoryResponse has either CoffeeShopID or, for categories shared by an organization, OrganizationID set.
 type CategoryResponse struct {
-	ID           uuid.UUID  `json:"id"`
-	CoffeeShopID *uuid.UUID `json:"coffee_shop_id"`
-	Title        string     `json:"title"`
-	Description  *string    `json:"description"`
+	ID             uuid.UUID  `json:"id"`
+	CoffeeShopID   *uuid.UUID `json:"coffee_shop_id"`
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	Title          string     `json:"title"`
+	Description    *string    `json:"description"`
 }
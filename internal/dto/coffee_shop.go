@@ -1,29 +1,78 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+// Location is a point given in degrees.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// OpeningHours is an interval of a weekday in which the coffee shop is open, in its local time.
+// Weekday is 0 for Sunday through 6 for Saturday; Opens and Closes are formatted as HH:MM.
+// An interval that closes at or before its opening time ends on the next day.
+type OpeningHours struct {
+	Weekday int    `json:"weekday"`
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"`
+}
 
 type CreateCoffeeShopRequest struct {
-	Name           string  `json:"name"`
-	Address        string  `json:"address"`
-	Contacts       *string `json:"contacts"`
-	WelcomeMessage *string `json:"welcome_message"`
-	Rules          *string `json:"rules"`
+	Name           string         `json:"name"`
+	Address        string         `json:"address"`
+	Country        *string        `json:"country"`
+	City           *string        `json:"city"`
+	Street         *string        `json:"street"`
+	PostalCode     *string        `json:"postal_code"`
+	Location       *Location      `json:"location"`
+	OpeningHours   []OpeningHours `json:"opening_hours"`
+	Contacts       *string        `json:"contacts"`
+	WelcomeMessage *string        `json:"welcome_message"`
+	Rules          *string        `json:"rules"`
 }
 
+// UpdateCoffeeShopRequest keeps empty fields unchanged. An empty opening_hours list clears the opening hours.
 type UpdateCoffeeShopRequest struct {
-	Name           string  `json:"name"`
-	Address        string  `json:"address"`
-	Contacts       *string `json:"contacts"`
-	WelcomeMessage *string `json:"welcome_message"`
-	Rules          *string `json:"rules"`
+	Name           string         `json:"name"`
+	Address        string         `json:"address"`
+	Country        *string        `json:"country"`
+	City           *string        `json:"city"`
+	Street         *string        `json:"street"`
+	PostalCode     *string        `json:"postal_code"`
+	Location       *Location      `json:"location"`
+	OpeningHours   []OpeningHours `json:"opening_hours"`
+	Contacts       *string        `json:"contacts"`
+	WelcomeMessage *string        `json:"welcome_message"`
+	Rules          *string        `json:"rules"`
 }
 
-type CoffeeShopResponse struct {
-	ID             uuid.UUID `json:"id"`
-	Name           string    `json:"name"`
-	Address        string    `json:"address"`
-	Contacts       *string   `json:"contacts"`
-	WelcomeMessage *string   `json:"welcome_message"`
-	Rules          *string   `json:"rules"`
+// GetCoffeeShopsRequest filters the list of coffee shops. With Near only located shops within
+// RadiusMeters are listed, nearest first.
+type GetCoffeeShopsRequest struct {
+	pagination.Request
+	Query        string
+	Near         *Location
+	RadiusMeters int
 }
 
+type CoffeeShopResponse struct {
+	ID             uuid.UUID      `json:"id"`
+	Name           string         `json:"name"`
+	Address        string         `json:"address"`
+	Country        *string        `json:"country,omitempty"`
+	City           *string        `json:"city,omitempty"`
+	Street         *string        `json:"street,omitempty"`
+	PostalCode     *string        `json:"postal_code,omitempty"`
+	Location       *Location      `json:"location,omitempty"`
+	OpeningHours   []OpeningHours `json:"opening_hours,omitempty"`
+	Contacts       *string        `json:"contacts"`
+	WelcomeMessage *string        `json:"welcome_message"`
+	Rules          *string        `json:"rules"`
+	// OrganizationID is set when the coffee shop belongs to an organization.
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	// DistanceMeters is set in nearby searches.
+	DistanceMeters *float64 `json:"distance_meters,omitempty"`
+}
@@ -17,9 +17,3 @@ type CommentResponse struct {
 	AuthorName string    `json:"name"`
 	CreatedAt  time.Time `json:"created_at"`
 }
-
-type GetCommentsRequest struct {
-	Page  int
-	Limit int
-	Sort  string
-}
@@ -0,0 +1,7 @@
+package dto
+
+// ProbeResponse is returned by the liveness and readiness probes.
+type ProbeResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -3,6 +3,7 @@ package dto
 import (
 	"time"
 
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
@@ -16,9 +17,13 @@ type CreateIdeaRequest struct {
 type UpdateIdeaRequest struct {
 	CategoryID  *uuid.UUID `json:"category_id"`
 	StatusID    *uuid.UUID `json:"status_id"`
+	StatusNote  *string    `json:"status_note" binding:"omitempty,max=500"`
 	Title       *string    `json:"title"`
 	Description *string    `json:"description"`
-	ImageURL    *string    `json:"image_url"`
+	// ImageURL attaches an image already stored by the API, such as the image of another idea.
+	ImageURL *string `json:"image_url"`
+	// UploadID attaches an image uploaded directly to the storage, replacing ImageURL.
+	UploadID *uuid.UUID `json:"upload_id"`
 }
 
 type IdeaResponse struct {
@@ -31,12 +36,41 @@ type IdeaResponse struct {
 	Title        string     `json:"title"`
 	Description  string     `json:"description"`
 	ImageURL     *string    `json:"image_url"`
-	Likes        int        `json:"likes"`
-	CreatedAt    time.Time  `json:"created_at"`
+	// ImageMediumURL and ImageThumbnailURL are smaller copies of the image for lists; they are empty for
+	// images uploaded before the copies were generated, in which case ImageURL is used.
+	ImageMediumURL    *string   `json:"image_medium_url"`
+	ImageThumbnailURL *string   `json:"image_thumbnail_url"`
+	Likes             int       `json:"likes"`
+	LikedByMe         bool      `json:"liked_by_me"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type IdeaStatusHistoryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	FromStatusID   *uuid.UUID `json:"from_status_id"`
+	FromStatusName string     `json:"from_status_name"`
+	ToStatusID     *uuid.UUID `json:"to_status_id"`
+	ToStatusName   string     `json:"to_status_name"`
+	ActorID        *uuid.UUID `json:"actor_id"`
+	ActorName      string     `json:"actor_name"`
+	Note           *string    `json:"note"`
+	CreatedAt      time.Time  `json:"created_at"`
 }
 
 type GetIdeasRequest struct {
-	Page  int
-	Limit int
-	Sort  string
+	pagination.Request
+	Sort string
+	// ViewerID is the authenticated caller, if any; it is used to fill liked_by_me.
+	ViewerID *uuid.UUID
+}
+
+type SearchIdeasRequest struct {
+	GetIdeasRequest
+	Query       string
+	StatusIDs   []uuid.UUID
+	CategoryIDs []uuid.UUID
+	CreatorID   *uuid.UUID
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	HasImage    *bool
 }
@@ -3,14 +3,34 @@ package dto
 import "github.com/google/uuid"
 
 type CreateIdeaStatusRequest struct {
-	Title string `json:"title" binding:"required"`
+	Title string `json:"title" binding:"required,max=50"`
+	// IsInitial makes the status the one new ideas start in. The first status of a shop is always initial.
+	IsInitial bool `json:"is_initial"`
+	// IsTerminal forbids moving ideas out of the status.
+	IsTerminal bool `json:"is_terminal"`
 }
 
 type UpdateIdeaStatusRequest struct {
-	Title string `json:"title" binding:"required"`
+	Title      string `json:"title" binding:"required,max=50"`
+	IsInitial  bool   `json:"is_initial"`
+	IsTerminal bool   `json:"is_terminal"`
 }
 
 type IdeaStatusResponse struct {
-	ID    uuid.UUID `json:"id"`
-	Title string    `json:"title"`
+	ID           uuid.UUID  `json:"id"`
+	CoffeeShopID *uuid.UUID `json:"coffee_shop_id"`
+	Title        string     `json:"title"`
+	IsInitial    bool       `json:"is_initial"`
+	IsTerminal   bool       `json:"is_terminal"`
+}
+
+type CreateIdeaStatusTransitionRequest struct {
+	FromStatusID uuid.UUID `json:"from_status_id" binding:"required"`
+	ToStatusID   uuid.UUID `json:"to_status_id" binding:"required"`
+}
+
+type IdeaStatusTransitionResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	FromStatusID *uuid.UUID `json:"from_status_id"`
+	ToStatusID   *uuid.UUID `json:"to_status_id"`
 }
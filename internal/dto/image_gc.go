@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// ImageGCReport lists the images the garbage collection deleted, or would delete in a dry run.
+type ImageGCReport struct {
+	DryRun bool            `json:"dry_run"`
+	Images []OrphanedImage `json:"images"`
+	// Bytes is the total size of the files of Images.
+	Bytes int64 `json:"bytes"`
+	// Failed counts images whose files could not be deleted; the next run retries them.
+	Failed int `json:"failed"`
+}
+
+// OrphanedImage is an image that no idea or upload refers to.
+type OrphanedImage struct {
+	ImageURL string `json:"image_url"`
+	// Size is the size of the original and its variants.
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
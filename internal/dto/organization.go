@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateOrganizationRequest defines the request body for creating an organization.
+type CreateOrganizationRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Description *string `json:"description"`
+}
+
+// UpdateOrganizationRequest defines the request body for updating an organization.
+type UpdateOrganizationRequest struct {
+	Name        string  `json:"name" binding:"required,max=100"`
+	Description *string `json:"description"`
+}
+
+// OrganizationResponse defines the response for an organization.
+type OrganizationResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description"`
+	CreatorID   *uuid.UUID `json:"creator_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// AddOrganizationMemberRequest defines the request body for adding a member to an organization.
+type AddOrganizationMemberRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+	// Role is one of admin, moderator or barista. Defaults to admin.
+	Role string `json:"role"`
+}
+
+// OrganizationMemberResponse defines the response for a member of an organization.
+type OrganizationMemberResponse struct {
+	ID        uuid.UUID    `json:"id"`
+	User      UserResponse `json:"user"`
+	Role      string       `json:"role"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// AttachCoffeeShopRequest defines the request body for adding a coffee shop to an organization.
+type AttachCoffeeShopRequest struct {
+	CoffeeShopID uuid.UUID `json:"coffee_shop_id" binding:"required"`
+}
+
+// CoffeeShopStatsResponse defines activity counters of a coffee shop.
+type CoffeeShopStatsResponse struct {
+	CoffeeShopID    uuid.UUID `json:"coffee_shop_id,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Ideas           int64     `json:"ideas"`
+	Likes           int64     `json:"likes"`
+	Comments        int64     `json:"comments"`
+	RewardsGiven    int64     `json:"rewards_given"`
+	RewardsRedeemed int64     `json:"rewards_redeemed"`
+}
+
+// OrganizationStatsResponse defines activity counters of an organization, in total and per coffee shop.
+type OrganizationStatsResponse struct {
+	OrganizationID uuid.UUID                 `json:"organization_id"`
+	Total          CoffeeShopStatsResponse   `json:"total"`
+	CoffeeShops    []CoffeeShopStatsResponse `json:"coffee_shops"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateOwnershipTransferRequest defines the request body for offering ownership of a coffee shop to an admin.
+type CreateOwnershipTransferRequest struct {
+	ToUserID uuid.UUID `json:"to_user_id" binding:"required"`
+	// KeepOwnership makes the recipient a co-owner; otherwise the initiator becomes an admin on acceptance.
+	KeepOwnership bool `json:"keep_ownership"`
+}
+
+// OwnershipTransferResponse defines the response for an ownership transfer.
+type OwnershipTransferResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	CoffeeShopID   uuid.UUID  `json:"coffee_shop_id"`
+	CoffeeShopName string     `json:"coffee_shop_name"`
+	FromUserID     *uuid.UUID `json:"from_user_id"`
+	ToUserID       uuid.UUID  `json:"to_user_id"`
+	KeepOwnership  bool       `json:"keep_ownership"`
+	Status         string     `json:"status"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
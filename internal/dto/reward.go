@@ -18,6 +18,18 @@ type RewardResponse struct {
 	IdeaID       *uuid.UUID `json:"idea_id,omitempty"`
 	RewardTypeID *uuid.UUID `json:"reward_type_id,omitempty"`
 	IsActivated  bool       `json:"is_activated"`
+	ActivatedAt  *time.Time `json:"activated_at,omitempty"`
 	GivenAt      *time.Time `json:"given_at,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	IsExpired    bool       `json:"is_expired"`
 	CreatedAt    time.Time  `json:"created_at"`
+
+	// Redemption code and its expiry are only returned to the receiver of a reward that is not activated yet.
+	RedemptionCode          *string    `json:"redemption_code,omitempty"`
+	RedemptionCodeExpiresAt *time.Time `json:"redemption_code_expires_at,omitempty"`
+}
+
+type RedeemRewardRequest struct {
+	Code         string    `json:"code" binding:"required"`
+	CoffeeShopID uuid.UUID `json:"coffee_shop_id" binding:"required"`
 }
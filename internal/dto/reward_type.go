@@ -2,17 +2,35 @@ package dto
 
 import "github.com/google/uuid"
 
+// RewardTypeResponse has CoffeeShopID set, or OrganizationID for reward types shared by an organization.
 type RewardTypeResponse struct {
-	ID           uuid.UUID
-	CoffeeShopID uuid.UUID
-	Description  string
+	ID             uuid.UUID
+	CoffeeShopID   uuid.UUID
+	OrganizationID *uuid.UUID `json:",omitempty"`
+	Title          string
+	Description    string
+	ValidityDays   *int
+	TotalStock     *int
+	MonthlyBudget  *int
 }
 
+// CreateRewardTypeRequest leaves a limit out when it is nil; set limits must be positive.
+// With OrganizationID the reward type is shared by all coffee shops of the organization and CoffeeShopID is ignored.
 type CreateRewardTypeRequest struct {
-	CoffeeShopID uuid.UUID
-	Description  string
+	CoffeeShopID   uuid.UUID
+	OrganizationID *uuid.UUID
+	Title          string
+	Description    string
+	ValidityDays   *int
+	TotalStock     *int
+	MonthlyBudget  *int
 }
 
+// UpdateRewardTypeRequest keeps nil fields unchanged; a limit set to 0 is removed.
 type UpdateRewardTypeRequest struct {
-	Description *string
+	Title         *string
+	Description   *string
+	ValidityDays  *int
+	TotalStock    *int
+	MonthlyBudget *int
 }
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateUploadRequest defines the request body for uploading an image directly to the storage.
+type CreateUploadRequest struct {
+	// ContentType is image/jpeg, image/png or image/webp.
+	ContentType string `json:"content_type" binding:"required"`
+	// Size is the exact size of the file in bytes.
+	Size int64 `json:"size" binding:"required,gt=0"`
+}
+
+// UploadResponse tells the client where to send the file. The request must use Method and
+// carry Headers exactly as given. Once it succeeds, the upload is attached to an idea by
+// passing UploadID as upload_id when updating the idea.
+type UploadResponse struct {
+	UploadID  uuid.UUID         `json:"upload_id"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
@@ -6,11 +6,19 @@ import "github.com/google/uuid"
 type AddWorkerToShopRequest struct {
 	WorkerID     uuid.UUID `json:"worker_id" binding:"required"`
 	CoffeeShopID uuid.UUID `json:"coffee_shop_id" binding:"required"`
+	// Role is one of admin, moderator or barista. Defaults to barista.
+	Role string `json:"role"`
+}
+
+// ChangeWorkerRoleRequest defines the request body for changing the role of a worker in a coffee shop.
+type ChangeWorkerRoleRequest struct {
+	Role string `json:"role" binding:"required"`
 }
 
 // WorkerCoffeeShopResponse defines the response for a worker-coffeeshop relationship.
 type WorkerCoffeeShopResponse struct {
 	ID         uuid.UUID          `json:"id"`
+	Role       string             `json:"role"`
 	Worker     UserResponse       `json:"worker"`
 	CoffeeShop CoffeeShopResponse `json:"coffee_shop"`
 }
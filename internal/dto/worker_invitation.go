@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateInvitationRequest defines the request body for inviting a worker to a coffee shop.
+// Invitations without a phone are shared as a link and can be accepted by any user holding the token.
+type CreateInvitationRequest struct {
+	// Role is one of admin, moderator or barista. Defaults to barista.
+	Role string `json:"role"`
+	// Phone of the invitee as +7XXXXXXXXXX or 8XXXXXXXXXX.
+	Phone *string `json:"phone" binding:"omitempty,min=1,max=15"`
+	// ExpiresInHours overrides the default lifetime of the invitation.
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1"`
+}
+
+// AcceptInvitationByTokenRequest defines the request body for accepting a link invitation.
+type AcceptInvitationByTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// InvitationResponse defines the response for a worker invitation.
+type InvitationResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	CoffeeShopID   uuid.UUID  `json:"coffee_shop_id"`
+	CoffeeShopName string     `json:"coffee_shop_name"`
+	Role           string     `json:"role"`
+	Phone          *string    `json:"phone,omitempty"`
+	Status         string     `json:"status"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	InvitedByID    *uuid.UUID `json:"invited_by_id"`
+	RespondedByID  *uuid.UUID `json:"responded_by_id,omitempty"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+	RevokedByID    *uuid.UUID `json:"revoked_by_id,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	// Token is returned only once, when a link invitation is created.
+	Token string `json:"token,omitempty"`
+}
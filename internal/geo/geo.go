@@ -0,0 +1,73 @@
+// Package geo provides the distance math behind the nearby search of coffee shops.
+//
+// Distances are great-circle distances on a spherical Earth, which is accurate enough
+// for finding a coffee shop within a city.
+package geo
+
+import "math"
+
+// EarthRadiusMeters is the mean radius of the Earth.
+const EarthRadiusMeters = 6371000.0
+
+// Point is a location given in degrees.
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Valid reports whether the coordinates of p are within their ranges.
+func (p Point) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// Distance returns the great-circle distance between a and b in meters using the haversine formula.
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude - a.Longitude)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * EarthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Box is a latitude and longitude range in degrees.
+type Box struct {
+	MinLatitude, MaxLatitude   float64
+	MinLongitude, MaxLongitude float64
+}
+
+// BoundingBox returns a box containing every point within radius meters of p.
+// It is used to narrow a search down with an index before the exact distance is checked.
+// Near the poles, or when the box crosses the antimeridian, the longitude range covers the whole globe.
+func BoundingBox(p Point, radius float64) Box {
+	angle := radius / EarthRadiusMeters
+	dLat := degrees(angle)
+	box := Box{
+		MinLatitude:  math.Max(-90, p.Latitude-dLat),
+		MaxLatitude:  math.Min(90, p.Latitude+dLat),
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+	if box.MinLatitude == -90 || box.MaxLatitude == 90 {
+		return box
+	}
+	// The widest point of the circle is not on the latitude of p but closer to the pole.
+	sinLon := math.Sin(angle) / math.Cos(radians(p.Latitude))
+	if sinLon >= 1 {
+		return box
+	}
+	dLon := degrees(math.Asin(sinLon))
+	if p.Longitude-dLon < -180 || p.Longitude+dLon > 180 {
+		return box
+	}
+	box.MinLongitude = p.Longitude - dLon
+	box.MaxLongitude = p.Longitude + dLon
+	return box
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -28,7 +28,9 @@ func NewAuthHandler(uc usecase.AuthUsecase, logger *slog.Logger) *AuthHandler {
 // @Param phone path string true "Phone number"
 // @Success 204 "No Content"
 // @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 429 {object} dto.ErrorResponse "Too Many Requests"
 // @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Failure 502 {object} dto.ErrorResponse "OTP delivery failed"
 // @Router /auth/{phone} [get]
 func (h *AuthHandler) GetOTP(c *gin.Context) {
 	phone := c.Param("phone")
@@ -60,7 +62,7 @@ func (h *AuthHandler) VerifyOTP(c *gin.Context) {
 		return
 	}
 
-	authResp, err := h.uc.VerifyOTP(c.Request.Context(), &req)
+	authResp, err := h.uc.VerifyOTP(c.Request.Context(), &req, clientInfo(c, req.DeviceName))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -88,7 +90,7 @@ func (h *AuthHandler) RegisterAdminAndCoffeeShop(c *gin.Context) {
 		return
 	}
 
-	authResp, err := h.uc.RegisterAdminAndCoffeeShop(c.Request.Context(), &req)
+	authResp, err := h.uc.RegisterAdminAndCoffeeShop(c.Request.Context(), &req, clientInfo(c, req.DeviceName))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -116,7 +118,7 @@ func (h *AuthHandler) LoginAdmin(c *gin.Context) {
 		return
 	}
 
-	authResp, err := h.uc.LoginAdmin(c.Request.Context(), &req)
+	authResp, err := h.uc.LoginAdmin(c.Request.Context(), &req, clientInfo(c, req.DeviceName))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -126,7 +128,7 @@ func (h *AuthHandler) LoginAdmin(c *gin.Context) {
 }
 
 // @Summary Refresh Access Token
-// @Description Refresh access token using a refresh token
+// @Description Refresh access token using a refresh token. The refresh token is rotated: reusing an already exchanged token revokes its session.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -144,7 +146,7 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	authResp, err := h.uc.Refresh(c.Request.Context(), refreshReq.RefreshToken)
+	authResp, err := h.uc.Refresh(c.Request.Context(), refreshReq.RefreshToken, clientInfo(c, ""))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -204,3 +206,66 @@ func (h *AuthHandler) LogoutEverywhere(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// @Summary List my sessions
+// @Description Lists the devices the current user is logged in on, most recently used first. The session of the access token used for the request is marked as current.
+// @Tags auth
+// @Produce json
+// @Success 200 {array} dto.SessionResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /users/me/sessions [get]
+// @Security ApiKeyAuth
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.uc.ListSessions(c.Request.Context(), userID, parseSessionIDFromContext(c))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// @Summary Revoke a session
+// @Description Logs the current user out on one device by revoking the session and its refresh tokens. Access tokens already issued for it stay valid until they expire.
+// @Tags auth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /users/me/sessions/{id} [delete]
+// @Security ApiKeyAuth
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+	sessionID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	err := h.uc.RevokeSession(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// JWKS serves the public keys that verify access tokens, so that other services
+// can check platform tokens without sharing a secret. It is mounted at
+// /.well-known/jwks.json outside of the API base path.
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.uc.JWKS())
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type BanHandler struct {
+	uc     usecase.BanUsecase
+	logger *slog.Logger
+}
+
+func NewBanHandler(uc usecase.BanUsecase, logger *slog.Logger) *BanHandler {
+	return &BanHandler{
+		uc:     uc,
+		logger: logger,
+	}
+}
+
+// @Summary Ban a user in a coffee shop
+// @Description Bans a user in a coffee shop. Banned users cannot create ideas, comment or like ideas of the shop. Requires the user.ban permission in the coffee shop.
+// @Tags bans
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param request body dto.BanUserRequest true "Ban details"
+// @Success 201 {object} dto.BannedUserResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/bans [post]
+// @Security ApiKeyAuth
+func (h *BanHandler) BanUser(c *gin.Context) {
+	shopID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	var req dto.BanUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind ban user request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.BanUser(c.Request.Context(), actorID, shopID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("user banned in coffee shop", slog.String("shop_id", shopID.String()), slog.String("user_id", req.UserID.String()))
+	c.JSON(http.StatusCreated, resp)
+}
+
+// @Summary Unban a user in a coffee shop
+// @Description Lifts the ban of a user in a coffee shop. Requires the user.ban permission in the coffee shop.
+// @Tags bans
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param user_id path string true "User ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/bans/{user_id} [delete]
+// @Security ApiKeyAuth
+func (h *BanHandler) UnbanUser(c *gin.Context) {
+	shopID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	userID, ok := parseUUIDFromParam(h.logger, c, "user_id")
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.UnbanUser(c.Request.Context(), actorID, shopID, userID); err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("user unbanned in coffee shop", slog.String("shop_id", shopID.String()), slog.String("user_id", userID.String()))
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List bans in a coffee shop
+// @Description Retrieves a paginated list of active bans in a coffee shop. Requires the user.ban permission in the coffee shop.
+// @Tags bans
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.BannedUserResponse]
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/bans [get]
+// @Security ApiKeyAuth
+func (h *BanHandler) ListBans(c *gin.Context) {
+	shopID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ListBans(c.Request.Context(), actorID, shopID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("listed bans for coffee shop", slog.String("shop_id", shopID.String()), slog.Int("count", len(resp.Items)))
+	c.JSON(http.StatusOK, resp)
+}
@@ -3,11 +3,11 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
-	"strconv"
 
-	"github.com/gin-gonic/gin"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
+	"github.com/gin-gonic/gin"
 )
 
 type CategoryHandler struct {
@@ -171,13 +171,14 @@ func (h *CategoryHandler) GetByID(c *gin.Context) {
 }
 
 // @Summary Get categories by coffee shop
-// @Description Get a list of all categories for a given coffee shop with optional pagination
+// @Description Get a list of all categories for a given coffee shop, including those shared by its organization, with optional pagination
 // @Tags categories
 // @Produce json
 // @Param id path string true "Coffee Shop ID"
-// @Param page query int false "Page number"
-// @Param page_size query int false "Number of items per page"
-// @Success 200 {object} map[string]interface{}
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.CategoryResponse]
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /coffee-shops/{id}/categories [get]
 func (h *CategoryHandler) GetByCoffeeShop(c *gin.Context) {
@@ -186,14 +187,158 @@ func (h *CategoryHandler) GetByCoffeeShop(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	categories, err := h.categoryUsecase.GetByCoffeeShop(c.Request.Context(), coffeeShopID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// @Summary Create an organization category
+// @Description Create a category shared by all coffee shops of an organization. Requires the shop.manage permission in the organization.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param category body dto.CreateCategory true "Category information"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organizations/{id}/categories [post]
+// @Security ApiKeyAuth
+func (h *CategoryHandler) CreateInOrganization(c *gin.Context) {
+	var req dto.CreateCategory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind category create request: ", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	organizationID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := h.categoryUsecase.CreateInOrganization(c.Request.Context(), userID, organizationID, req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"id": id})
+}
+
+// @Summary Update an organization category
+// @Description Update a category shared by all coffee shops of an organization. Requires the shop.manage permission in the organization.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param category_id path string true "Category ID"
+// @Param category body dto.UpdateCategory true "Category update information"
+// @Success 200 "OK"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organizations/{id}/categories/{category_id} [put]
+// @Security ApiKeyAuth
+func (h *CategoryHandler) UpdateInOrganization(c *gin.Context) {
+	var req dto.UpdateCategory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind category update request: ", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	organizationID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	categoryID, ok := parseUUIDFromParam(h.logger, c, "category_id")
+	if !ok {
+		return
+	}
+
+	if err := h.categoryUsecase.UpdateInOrganization(c.Request.Context(), userID, organizationID, categoryID, req); err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// @Summary Delete an organization category
+// @Description Delete a category shared by all coffee shops of an organization. Requires the shop.manage permission in the organization.
+// @Tags categories
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param category_id path string true "Category ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organizations/{id}/categories/{category_id} [delete]
+// @Security ApiKeyAuth
+func (h *CategoryHandler) DeleteInOrganization(c *gin.Context) {
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	organizationID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	categoryID, ok := parseUUIDFromParam(h.logger, c, "category_id")
+	if !ok {
+		return
+	}
+
+	if err := h.categoryUsecase.DeleteInOrganization(c.Request.Context(), userID, organizationID, categoryID); err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Get categories by organization
+// @Description Get a list of categories shared by all coffee shops of an organization with optional pagination
+// @Tags categories
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.CategoryResponse]
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organizations/{id}/categories [get]
+func (h *CategoryHandler) GetByOrganization(c *gin.Context) {
+	organizationID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
 
-	categories, total, err := h.categoryUsecase.GetByCoffeeShop(c.Request.Context(), coffeeShopID, page, pageSize)
+	categories, err := h.categoryUsecase.GetByOrganization(c.Request.Context(), organizationID, pagination.ParseQuery(c.Query))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": categories, "total": total})
+	c.JSON(http.StatusOK, categories)
 }
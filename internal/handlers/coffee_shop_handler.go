@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -52,22 +56,28 @@ func (h *CoffeeShopHandler) CreateCoffeeShop(c *gin.Context) {
 }
 
 // @Summary Get all coffee shops
-// @Description Get a list of all coffee shops with optional pagination
+// @Description Get a list of coffee shops, newest first. With near only shops with a location within the radius are listed, nearest first, with their distance.
 // @Tags coffee-shops
 // @Produce json
-// @Param page query int false "Page number"
-// @Param limit query int false "Number of items per page"
-// @Success 200 {array} dto.CoffeeShopResponse
+// @Param q query string false "Part of the coffee shop name"
+// @Param near query string false "Point to search around as latitude,longitude"
+// @Param radius query int false "Search radius in meters, at most 100000" default(5000)
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.CoffeeShopResponse]
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /coffee-shops [get]
 func (h *CoffeeShopHandler) GetAllCoffeeShops(c *gin.Context) {
-	pageRaw := c.Query("page")
-	limitRaw := c.Query("limit")
-
-	page, _ := strconv.Atoi(pageRaw)
-	limit, _ := strconv.Atoi(limitRaw)
+	params, err := parseGetCoffeeShopsRequest(c)
+	if err != nil {
+		h.logger.Info("invalid get coffee shops request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
 
-	resp, err := h.coffeeShopUsecase.GetAllCoffeeShops(c.Request.Context(), page, limit)
+	resp, err := h.coffeeShopUsecase.GetAllCoffeeShops(c.Request.Context(), params)
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -99,7 +109,7 @@ func (h *CoffeeShopHandler) GetCoffeeShop(c *gin.Context) {
 }
 
 // @Summary Update coffee shop by ID
-// @Description Update coffee shop details for the given ID
+// @Description Update coffee shop details for the given ID. Requires the shop.manage permission in the coffee shop.
 // @Tags coffee-shops
 // @Accept json
 // @Produce json
@@ -107,6 +117,7 @@ func (h *CoffeeShopHandler) GetCoffeeShop(c *gin.Context) {
 // @Param coffee_shop body dto.UpdateCoffeeShopRequest true "Coffee shop update information"
 // @Success 204 "No Content"
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /coffee-shops/{id} [put]
@@ -136,12 +147,13 @@ func (h *CoffeeShopHandler) UpdateCoffeeShop(c *gin.Context) {
 }
 
 // @Summary Delete coffee shop by ID
-// @Description Delete a coffee shop by ID
+// @Description Delete a coffee shop by ID. Requires the shop.delete permission in the coffee shop, which only owners have.
 // @Tags coffee-shops
 // @Produce json
 // @Param id path string true "Coffee Shop ID"
 // @Success 204 "No Content"
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /coffee-shops/{id} [delete]
@@ -163,3 +175,36 @@ func (h *CoffeeShopHandler) DeleteCoffeeShop(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+func parseGetCoffeeShopsRequest(c *gin.Context) (dto.GetCoffeeShopsRequest, error) {
+	params := dto.GetCoffeeShopsRequest{
+		Request: pagination.ParseQuery(c.Query),
+		Query:   c.Query("q"),
+	}
+	if raw := c.Query("near"); raw != "" {
+		lat, lon, ok := strings.Cut(raw, ",")
+		if !ok {
+			return params, errors.New("invalid near: expected latitude,longitude")
+		}
+		latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+		if err != nil {
+			return params, fmt.Errorf("invalid near latitude: %w", err)
+		}
+		longitude, err := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+		if err != nil {
+			return params, fmt.Errorf("invalid near longitude: %w", err)
+		}
+		params.Near = &dto.Location{Latitude: latitude, Longitude: longitude}
+	}
+	if raw := c.Query("radius"); raw != "" {
+		if params.Near == nil {
+			return params, errors.New("radius requires near")
+		}
+		radius, err := strconv.Atoi(raw)
+		if err != nil {
+			return params, fmt.Errorf("invalid radius: %w", err)
+		}
+		params.RadiusMeters = radius
+	}
+	return params, nil
+}
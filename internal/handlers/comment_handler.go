@@ -3,9 +3,9 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -70,9 +70,10 @@ func (h *CommentHandler) CreateComment(c *gin.Context) {
 // @Tags ideas
 // @Produce json
 // @Param id path string true "Idea ID"
-// @Param page query int false "Page number"
-// @Param limit query int false "Number of items per page"
-// @Success 200 {array} dto.CommentResponse
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.CommentResponse]
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
@@ -86,26 +87,12 @@ func (h *CommentHandler) GetComments(c *gin.Context) {
 		return
 	}
 
-	pageRaw := c.Query("page")
-	limitRaw := c.Query("limit")
-	// Sort is not used in the current usecase implementation but can be added later
-	// sort := c.Query("sort")
-
-	page, _ := strconv.Atoi(pageRaw)
-	limit, _ := strconv.Atoi(limitRaw)
-
-	params := dto.GetCommentsRequest{
-		Page:  page,
-		Limit: limit,
-		// Sort:  sort,
-	}
-
 	actorID, ok := parseActorIDFromContext(h.logger, c)
 	if !ok {
 		return
 	}
 
-	resp, err := h.uc.GetCommentsByIdeaID(c.Request.Context(), actorID, ideaID, params)
+	resp, err := h.uc.GetCommentsByIdeaID(c.Request.Context(), actorID, ideaID, pagination.ParseQuery(c.Query))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
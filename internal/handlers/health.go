@@ -1,12 +1,78 @@
 package handlers
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/gin-gonic/gin"
 )
 
+const readinessCheckTimeout = 2 * time.Second
+
+// ReadinessCheck verifies one dependency the service needs to serve requests.
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	checks   []ReadinessCheck
+	draining atomic.Bool
+	logger   *slog.Logger
+}
+
+func NewHealthHandler(logger *slog.Logger, checks ...ReadinessCheck) *HealthHandler {
+	return &HealthHandler{
+		checks: checks,
+		logger: logger,
+	}
+}
+
+// Drain makes the readiness probe fail, so that load balancers stop routing
+// new requests while the server finishes the in-flight ones.
+func (h *HealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// Live is the liveness probe, mounted at /livez. It only reports that the process
+// is up and does not check dependencies, so a database outage does not restart the service.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.ProbeResponse{Status: "ok"})
+}
+
+// Ready is the readiness probe, mounted at /readyz. It answers 503 while any check
+// fails or the server is shutting down.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, dto.ProbeResponse{Status: "shutting down"})
+		return
+	}
+
+	resp := dto.ProbeResponse{Status: "ok", Checks: make(map[string]string, len(h.checks))}
+	status := http.StatusOK
+	for _, check := range h.checks {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessCheckTimeout)
+		err := check.Check(ctx)
+		cancel()
+		if err != nil {
+			// Error details stay in the logs, the probe is public.
+			h.logger.Warn("readiness check failed", slog.String("check", check.Name), slog.String("error", err.Error()))
+			resp.Checks[check.Name] = "failed"
+			resp.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Checks[check.Name] = "ok"
+	}
+
+	c.JSON(status, resp)
+}
+
 // @Summary Health Check
 // @Description Check the health of the service
 // @Tags health
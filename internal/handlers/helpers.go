@@ -19,6 +19,7 @@ func HandleAppErrors(err error, logger *slog.Logger, c *gin.Context) {
 	var errRateLimit *apperrors.ErrRateLimit
 	var errAccessDenied *apperrors.ErrAccessDenied
 	var errConflict *apperrors.ErrConflict
+	var errDeliveryFailed *apperrors.ErrDeliveryFailed
 	if errors.As(err, &errNotFound) {
 		c.JSON(http.StatusNotFound, &dto.ErrorResponse{Message: err.Error()})
 		return
@@ -43,6 +44,10 @@ func HandleAppErrors(err error, logger *slog.Logger, c *gin.Context) {
 		c.JSON(http.StatusConflict, &dto.ErrorResponse{Message: err.Error()})
 		return
 	}
+	if errors.As(err, &errDeliveryFailed) {
+		c.JSON(http.StatusBadGateway, &dto.ErrorResponse{Message: err.Error()})
+		return
+	}
 
 	logger.Error("internal server error: ", slog.String("error", err.Error()))
 	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
@@ -90,6 +95,35 @@ func parseActorIDFromContext(logger *slog.Logger, c *gin.Context) (uuid.UUID, bo
 	return actorID, true
 }
 
+// parseOptionalActorIDFromContext returns the caller's ID on routes where authentication is optional.
+func parseOptionalActorIDFromContext(c *gin.Context) *uuid.UUID {
+	actorID, ok := c.Get("user_id")
+	if !ok {
+		return nil
+	}
+	id, ok := actorID.(uuid.UUID)
+	if !ok {
+		return nil
+	}
+	return &id
+}
+
+// parseSessionIDFromContext returns the session of the access token, or uuid.Nil for tokens issued before sessions.
+func parseSessionIDFromContext(c *gin.Context) uuid.UUID {
+	sessionID, _ := c.Get("session_id")
+	id, _ := sessionID.(uuid.UUID)
+	return id
+}
+
+// clientInfo describes the device the request comes from.
+func clientInfo(c *gin.Context, deviceName string) dto.ClientInfo {
+	return dto.ClientInfo{
+		DeviceName: deviceName,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+}
+
 //func parseRoleFromContext(logger *slog.Logger, c *gin.Context) (string, bool) {
 // 	roleAny, exist := c.Get("role")
 // 	if !exist {
@@ -1,12 +1,15 @@
 package handlers
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 	"github.com/google/uuid"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -31,7 +34,7 @@ func NewIdeaHandler(uc usecase.IdeaUsecase, imageUsecase usecase.ImageUsecase, l
 // @Accept mpfd
 // @Produce json
 // @Param idea formData dto.CreateIdeaRequest true "Idea information"
-// @Param image formData file false "Image file"
+// @Param image formData file false "JPEG, PNG or WebP image; metadata is removed and smaller copies are generated"
 // @Success 201 {object} dto.IdeaResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
@@ -79,8 +82,7 @@ func (h *IdeaHandler) CreateIdea(c *gin.Context) {
 	if file != nil {
 		uploadedURL, err := h.imageUsecase.UploadImage(c.Request.Context(), file)
 		if err != nil {
-			h.logger.Error("failed to upload image: ", slog.String("error", err.Error()))
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload image"})
+			HandleAppErrors(err, h.logger, c)
 			return
 		}
 		imageURL = &uploadedURL
@@ -101,10 +103,11 @@ func (h *IdeaHandler) CreateIdea(c *gin.Context) {
 // @Tags ideas
 // @Produce json
 // @Param id path string true "Coffee Shop ID"
-// @Param page query int false "Page number"
-// @Param limit query int false "Number of items per page"
-// @Param sort query string false "Sort order"
-// @Success 200 {array} dto.IdeaResponse
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Param sort query string false "Comma-separated sort fields (created_at, status, likes); prefix with - for descending"
+// @Success 200 {object} pagination.Page[dto.IdeaResponse]
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /coffee-shops/{id}/ideas [get]
@@ -113,17 +116,10 @@ func (h *IdeaHandler) GetIdeasFromShop(c *gin.Context) {
 	if !ok {
 		return
 	}
-	pageRaw := c.Query("page")
-	limitRaw := c.Query("limit")
-	sort := c.Query("sort")
-
-	page, _ := strconv.Atoi(pageRaw)
-	limit, _ := strconv.Atoi(limitRaw)
-
 	params := dto.GetIdeasRequest{
-		Page:  page,
-		Limit: limit,
-		Sort:  sort,
+		Request:  pagination.ParseQuery(c.Query),
+		Sort:     c.Query("sort"),
+		ViewerID: parseOptionalActorIDFromContext(c),
 	}
 
 	resp, err := h.uc.GetAllIdeasByShop(c.Request.Context(), shopID, params)
@@ -134,14 +130,157 @@ func (h *IdeaHandler) GetIdeasFromShop(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// @Summary Search ideas in a shop
+// @Description Full-text search over idea titles and descriptions (Russian and English) combined with filters. Without a sort, text searches are ordered by relevance.
+// @Tags ideas
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param q query string false "Search text, supports websearch syntax"
+// @Param status_id query []string false "Status IDs" collectionFormat(multi)
+// @Param category_id query []string false "Category IDs" collectionFormat(multi)
+// @Param creator_id query string false "Creator user ID"
+// @Param created_from query string false "Created at or after, RFC3339"
+// @Param created_to query string false "Created at or before, RFC3339"
+// @Param has_image query bool false "Only ideas with (true) or without (false) an image"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Param sort query string false "Comma-separated sort fields (created_at, status, likes); prefix with - for descending"
+// @Success 200 {object} pagination.Page[dto.IdeaResponse]
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /coffee-shops/{id}/ideas/search [get]
+func (h *IdeaHandler) SearchIdeasInShop(c *gin.Context) {
+	shopID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	params, err := parseSearchIdeasRequest(c)
+	if err != nil {
+		h.logger.Info("invalid search ideas request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp, err := h.uc.SearchIdeas(c.Request.Context(), shopID, params)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Search ideas in an organization
+// @Description Full-text search and filters over ideas of all coffee shops of an organization. Without a sort, text searches are ordered by relevance.
+// @Tags ideas
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param q query string false "Search text, supports websearch syntax"
+// @Param status_id query []string false "Status IDs" collectionFormat(multi)
+// @Param category_id query []string false "Category IDs" collectionFormat(multi)
+// @Param creator_id query string false "Creator user ID"
+// @Param created_from query string false "Created at or after, RFC3339"
+// @Param created_to query string false "Created at or before, RFC3339"
+// @Param has_image query bool false "Only ideas with (true) or without (false) an image"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Param sort query string false "Comma-separated sort fields (created_at, status, likes); prefix with - for descending"
+// @Success 200 {object} pagination.Page[dto.IdeaResponse]
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organizations/{id}/ideas [get]
+func (h *IdeaHandler) SearchIdeasInOrganization(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	params, err := parseSearchIdeasRequest(c)
+	if err != nil {
+		h.logger.Info("invalid search ideas request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	resp, err := h.uc.SearchIdeasInOrganization(c.Request.Context(), organizationID, params)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func parseSearchIdeasRequest(c *gin.Context) (dto.SearchIdeasRequest, error) {
+	params := dto.SearchIdeasRequest{
+		GetIdeasRequest: dto.GetIdeasRequest{
+			Request:  pagination.ParseQuery(c.Query),
+			Sort:     c.Query("sort"),
+			ViewerID: parseOptionalActorIDFromContext(c),
+		},
+		Query: c.Query("q"),
+	}
+
+	var err error
+	if params.StatusIDs, err = parseUUIDList(c.QueryArray("status_id")); err != nil {
+		return params, fmt.Errorf("invalid status_id: %w", err)
+	}
+	if params.CategoryIDs, err = parseUUIDList(c.QueryArray("category_id")); err != nil {
+		return params, fmt.Errorf("invalid category_id: %w", err)
+	}
+	if raw := c.Query("creator_id"); raw != "" {
+		creatorID, err := uuid.Parse(raw)
+		if err != nil {
+			return params, fmt.Errorf("invalid creator_id: %w", err)
+		}
+		params.CreatorID = &creatorID
+	}
+	if raw := c.Query("created_from"); raw != "" {
+		from, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return params, fmt.Errorf("invalid created_from: %w", err)
+		}
+		params.CreatedFrom = &from
+	}
+	if raw := c.Query("created_to"); raw != "" {
+		to, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return params, fmt.Errorf("invalid created_to: %w", err)
+		}
+		params.CreatedTo = &to
+	}
+	if raw := c.Query("has_image"); raw != "" {
+		hasImage, err := strconv.ParseBool(raw)
+		if err != nil {
+			return params, fmt.Errorf("invalid has_image: %w", err)
+		}
+		params.HasImage = &hasImage
+	}
+	return params, nil
+}
+
+func parseUUIDList(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 // @Summary Get all ideas by user
 // @Description Get a list of all ideas for a given user with optional pagination
 // @Tags ideas
 // @Produce json
-// @Param page query int false "Page number"
-// @Param limit query int false "Number of items per page"
-// @Param sort query string false "Sort order"
-// @Success 200 {array} dto.IdeaResponse
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Param sort query string false "Comma-separated sort fields (created_at, status, likes); prefix with - for descending"
+// @Success 200 {object} pagination.Page[dto.IdeaResponse]
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /users/me/ideas [get]
@@ -151,17 +290,10 @@ func (h *IdeaHandler) GetIdeasFromUser(c *gin.Context) {
 	if !ok {
 		return
 	}
-	pageRaw := c.Query("page")
-	limitRaw := c.Query("limit")
-	sort := c.Query("sort")
-
-	page, _ := strconv.Atoi(pageRaw)
-	limit, _ := strconv.Atoi(limitRaw)
-
 	req := dto.GetIdeasRequest{
-		Page:  page,
-		Limit: limit,
-		Sort:  sort,
+		Request:  pagination.ParseQuery(c.Query),
+		Sort:     c.Query("sort"),
+		ViewerID: &userID,
 	}
 
 	resp, err := h.uc.GetAllIdeasByUser(c.Request.Context(), userID, req)
@@ -188,7 +320,7 @@ func (h *IdeaHandler) GetIdea(c *gin.Context) {
 	if !ok {
 		return
 	}
-	idea, err := h.uc.GetIdea(c.Request.Context(), uuid)
+	idea, err := h.uc.GetIdea(c.Request.Context(), uuid, parseOptionalActorIDFromContext(c))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -196,8 +328,31 @@ func (h *IdeaHandler) GetIdea(c *gin.Context) {
 	c.JSON(http.StatusOK, idea)
 }
 
+// @Summary Get idea status history
+// @Description Get the timeline of status changes of an idea, oldest first
+// @Tags ideas
+// @Produce json
+// @Param id path string true "Idea ID"
+// @Success 200 {array} dto.IdeaStatusHistoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /ideas/{id}/history [get]
+func (h *IdeaHandler) GetIdeaStatusHistory(c *gin.Context) {
+	uuid, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+	history, err := h.uc.GetStatusHistory(c.Request.Context(), uuid)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
 // @Summary Update idea by ID
-// @Description Update idea details for the given ID
+// @Description Update idea details for the given ID. An image uploaded with POST /uploads is attached by passing its upload_id.
 // @Tags ideas
 // @Accept json
 // @Produce json
@@ -205,6 +360,7 @@ func (h *IdeaHandler) GetIdea(c *gin.Context) {
 // @Param idea body dto.UpdateIdeaRequest true "Idea update information"
 // @Success 204 "No Content"
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /ideas/{id} [put]
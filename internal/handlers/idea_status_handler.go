@@ -22,6 +22,20 @@ func NewIdeaStatusHandler(statusUsecase usecase.IdeaStatusUsecase, logger *slog.
 	}
 }
 
+// @Summary Create an idea status
+// @Description Adds a status to the coffee shop workflow. The first status of a shop becomes initial. Requires the shop.manage permission in the coffee shop.
+// @Tags statuses
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param status body dto.CreateIdeaStatusRequest true "Status information"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /coffee-shops/{id}/statuses [post]
+// @Security ApiKeyAuth
 func (h *IdeaStatusHandler) Create(c *gin.Context) {
 	var req dto.CreateIdeaStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -30,7 +44,17 @@ func (h *IdeaStatusHandler) Create(c *gin.Context) {
 		return
 	}
 
-	id, err := h.statusUsecase.Create(c.Request.Context(), req)
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	coffeeShopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := h.statusUsecase.Create(c.Request.Context(), userID, coffeeShopID, req)
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -40,8 +64,8 @@ func (h *IdeaStatusHandler) Create(c *gin.Context) {
 }
 
 // GetAllStatuses godoc
-// @Summary Get all idea statuses
-// @Description Get all idea statuses
+// @Summary Get legacy idea statuses
+// @Description Get idea statuses that do not belong to any coffee shop. Use /coffee-shops/{id}/statuses for shop workflows.
 // @Tags statuses
 // @Produce json
 // @Success 200 {array} dto.IdeaStatusResponse
@@ -57,6 +81,30 @@ func (h *IdeaStatusHandler) GetAll(c *gin.Context) {
 	c.JSON(http.StatusOK, statuses)
 }
 
+// @Summary Get idea statuses of a coffee shop
+// @Description Get the statuses of the coffee shop workflow
+// @Tags statuses
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Success 200 {array} dto.IdeaStatusResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /coffee-shops/{id}/statuses [get]
+func (h *IdeaStatusHandler) GetByCoffeeShop(c *gin.Context) {
+	coffeeShopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	statuses, err := h.statusUsecase.GetByCoffeeShop(c.Request.Context(), coffeeShopID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, statuses)
+}
+
 // GetStatusByID godoc
 // @Summary Get idea status by ID
 // @Description Get idea status by ID
@@ -84,14 +132,23 @@ func (h *IdeaStatusHandler) GetByID(c *gin.Context) {
 	c.JSON(http.StatusOK, status)
 }
 
+// @Summary Update an idea status
+// @Description Updates a status of the coffee shop workflow. Requires the shop.manage permission in the coffee shop.
+// @Tags statuses
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param status_id path string true "Status ID"
+// @Param status body dto.UpdateIdeaStatusRequest true "Status update information"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /coffee-shops/{id}/statuses/{status_id} [put]
+// @Security ApiKeyAuth
 func (h *IdeaStatusHandler) Update(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
-		return
-	}
-
 	var req dto.UpdateIdeaStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("failed to bind json", "error", err.Error())
@@ -99,7 +156,22 @@ func (h *IdeaStatusHandler) Update(c *gin.Context) {
 		return
 	}
 
-	if err := h.statusUsecase.Update(c.Request.Context(), id, req); err != nil {
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	coffeeShopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	statusID, ok := parseUUIDFromParam(h.logger, c, "status_id")
+	if !ok {
+		return
+	}
+
+	if err := h.statusUsecase.Update(c.Request.Context(), userID, coffeeShopID, statusID, req); err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
 	}
@@ -107,18 +179,142 @@ func (h *IdeaStatusHandler) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "status updated successfully"})
 }
 
+// @Summary Delete an idea status
+// @Description Deletes a status of the coffee shop workflow together with its transitions. The initial status cannot be deleted. Requires the shop.manage permission in the coffee shop.
+// @Tags statuses
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param status_id path string true "Status ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /coffee-shops/{id}/statuses/{status_id} [delete]
+// @Security ApiKeyAuth
 func (h *IdeaStatusHandler) Delete(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id format"})
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	coffeeShopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	statusID, ok := parseUUIDFromParam(h.logger, c, "status_id")
+	if !ok {
 		return
 	}
 
-	if err := h.statusUsecase.Delete(c.Request.Context(), id); err != nil {
+	if err := h.statusUsecase.Delete(c.Request.Context(), userID, coffeeShopID, statusID); err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "status deleted successfully"})
 }
+
+// @Summary Get status transitions of a coffee shop
+// @Description Get the allowed transitions between statuses of the coffee shop workflow
+// @Tags statuses
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Success 200 {array} dto.IdeaStatusTransitionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /coffee-shops/{id}/status-transitions [get]
+func (h *IdeaStatusHandler) GetTransitions(c *gin.Context) {
+	coffeeShopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	transitions, err := h.statusUsecase.GetTransitions(c.Request.Context(), coffeeShopID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, transitions)
+}
+
+// @Summary Allow a status transition
+// @Description Allows ideas of the coffee shop to move from one status to another. Terminal statuses cannot have outgoing transitions. Requires the shop.manage permission in the coffee shop.
+// @Tags statuses
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param transition body dto.CreateIdeaStatusTransitionRequest true "Transition information"
+// @Success 201 {object} dto.IdeaStatusTransitionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /coffee-shops/{id}/status-transitions [post]
+// @Security ApiKeyAuth
+func (h *IdeaStatusHandler) CreateTransition(c *gin.Context) {
+	var req dto.CreateIdeaStatusTransitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind json", "error", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	coffeeShopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	transition, err := h.statusUsecase.CreateTransition(c.Request.Context(), userID, coffeeShopID, req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transition)
+}
+
+// @Summary Forbid a status transition
+// @Description Removes an allowed transition from the coffee shop workflow. Requires the shop.manage permission in the coffee shop.
+// @Tags statuses
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param transition_id path string true "Transition ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /coffee-shops/{id}/status-transitions/{transition_id} [delete]
+// @Security ApiKeyAuth
+func (h *IdeaStatusHandler) DeleteTransition(c *gin.Context) {
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	coffeeShopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	transitionID, ok := parseUUIDFromParam(h.logger, c, "transition_id")
+	if !ok {
+		return
+	}
+
+	if err := h.statusUsecase.DeleteTransition(c.Request.Context(), userID, coffeeShopID, transitionID); err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,84 +1,228 @@
 package handlers
 
 import (
-	"fmt" // Add fmt import
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
-	"github.com/minio/minio-go/v7"
 )
 
 type ImageHandler struct {
 	imageUsecase usecase.ImageUsecase
-	cfg          *config.Config
 	logger       *slog.Logger
 }
 
-func NewImageHandler(imageUsecase usecase.ImageUsecase, cfg *config.Config, logger *slog.Logger) *ImageHandler {
+func NewImageHandler(imageUsecase usecase.ImageUsecase, logger *slog.Logger) *ImageHandler {
 	return &ImageHandler{
 		imageUsecase: imageUsecase,
-		cfg:          cfg,
 		logger:       logger,
 	}
 }
 
+// imageCacheControl lets clients and CDNs keep images for a year without revalidating:
+// images are never changed in place, a new image always gets a new key.
+const imageCacheControl = "public, max-age=31536000, immutable"
+
 // @Summary Get image
-// @Description Get an image by its path stored in MinIO.
+// @Description Get an image by the URL returned for it, such as images/<uuid>/original.jpg.
+// @Description Responses carry a strong ETag and Last-Modified for conditional requests and may be cached forever.
+// @Description A single byte range can be requested with the Range header.
 // @Tags images
 // @Produce octet-stream
-// @Param imagePath path string true "Full path of the image (e.g., images/uuid.jpg)"
+// @Param imagePath path string true "Image URL (e.g., images/uuid/original.jpg)"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Param If-Modified-Since header string false "Last-Modified of a cached copy"
+// @Param Range header string false "Single byte range, e.g. bytes=0-1023"
+// @Param If-Range header string false "ETag or Last-Modified the range is valid for"
 // @Success 200 {file} byte
+// @Success 206 {file} byte
+// @Success 304 "Not Modified"
+// @Header 200,206 {string} ETag "Strong entity tag of the image"
+// @Header 200,206 {string} Cache-Control "public, max-age=31536000, immutable"
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 416 "Range Not Satisfiable"
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /images/{imagePath} [get]
 func (h *ImageHandler) GetImage(c *gin.Context) {
-	imagePath := c.Param("imagePath")
+	// The wildcard parameter keeps the slash in front of the path.
+	imagePath := strings.TrimPrefix(c.Param("imagePath"), "/")
 	logger := h.logger.With("method", "GetImage", "imagePath", imagePath)
 	logger.Debug("attempting to retrieve image")
 
-	// Get the configured bucket name
-	configuredBucketName := h.cfg.ImageDB.BucketName
-
-	// Check if the imagePath starts with the configured bucket name and a slash
-	// to extract the actual object name within the bucket.
-	objectName := imagePath
-	if len(imagePath) > len(configuredBucketName) && imagePath[:len(configuredBucketName)] == configuredBucketName && imagePath[len(configuredBucketName)] == '/' {
-		objectName = imagePath[len(configuredBucketName)+1:]
-	} else if imagePath == configuredBucketName {
-		// This case means the imagePath *is* just the bucket name, which is not a valid object name
-		logger.Error("invalid image path: image path is just bucket name", slog.String("path", imagePath))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid image path"})
+	info, err := h.imageUsecase.StatImage(c.Request.Context(), imagePath)
+	if err != nil {
+		HandleAppErrors(err, logger, c)
 		return
 	}
-	// If it doesn't start with the bucket name, it might be an object in a different bucket
-	// or an incorrectly formed path. For this proxy, we assume it's in the configured bucket.
 
+	etag := strongETag(info.ETag)
+	if notModified(c.Request, etag, info.LastModified) {
+		setImageCacheHeaders(c, etag, info)
+		c.Status(http.StatusNotModified)
+		return
+	}
 
-	object, objectInfo, err := h.imageUsecase.GetImage(c.Request.Context(), objectName)
-	if err != nil {
-		logger.Error("failed to get image from usecase", slog.String("error", err.Error()))
-		// Check for specific MinIO errors, e.g., NoSuchKey
-		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Image not found"})
+	var rng *blobstore.Range
+	if header := c.GetHeader("Range"); header != "" && rangeApplies(c.Request, etag, info.LastModified) {
+		rng, err = parseRange(header, info.Size)
+		if err != nil {
+			logger.Debug("unsatisfiable range", slog.String("range", header))
+			c.Header("Content-Range", fmt.Sprintf("bytes */%d", info.Size))
+			c.Status(http.StatusRequestedRangeNotSatisfiable)
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve image"})
-		return
 	}
-	defer object.Close() // Ensure the object stream is closed
 
-	c.Header("Content-Type", objectInfo.ContentType)
-	c.Header("Content-Length", fmt.Sprintf("%d", objectInfo.Size))
-	// You might want to add Cache-Control headers here
+	var object io.ReadCloser
+	if c.Request.Method != http.MethodHead {
+		object, _, err = h.imageUsecase.GetImage(c.Request.Context(), imagePath, rng)
+		if err != nil {
+			HandleAppErrors(err, logger, c)
+			return
+		}
+		defer object.Close()
+	}
 
+	setImageCacheHeaders(c, etag, info)
+	status, length := http.StatusOK, info.Size
+	if rng != nil {
+		status, length = http.StatusPartialContent, rng.Length
+		c.Header("Content-Range", fmt.Sprintf("bytes %d-%d/%d", rng.Offset, rng.Offset+rng.Length-1, info.Size))
+	}
+	c.Header("Content-Type", info.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(length, 10))
+	c.Status(status)
+	if object == nil {
+		return
+	}
 	if _, err := io.Copy(c.Writer, object); err != nil {
+		// The status is already sent, the client notices the short body.
 		logger.Error("failed to stream image to client", slog.String("error", err.Error()))
-		// Note: Cannot send HTTP status code here as headers have already been written.
-		// The client might receive an incomplete file or a connection reset.
-		// Logging the error is sufficient.
 	}
 }
+
+// setImageCacheHeaders sets the validators and the caching policy of a stored image.
+func setImageCacheHeaders(c *gin.Context, etag string, info blobstore.ObjectInfo) {
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", info.LastModified.UTC().Format(http.TimeFormat))
+	c.Header("Cache-Control", imageCacheControl)
+	c.Header("Accept-Ranges", "bytes")
+}
+
+// strongETag quotes the ETag of a stored object. Stores return it with or without quotes.
+func strongETag(etag string) string {
+	return `"` + strings.Trim(etag, `"`) + `"`
+}
+
+// notModified reports whether the cached copy the request was made for is still current.
+// If-Modified-Since is only used when the request has no If-None-Match.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			// Weak comparison, as required for If-None-Match.
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
+
+// rangeApplies reports whether the Range of the request is to be served: without If-Range
+// it always is, otherwise only when If-Range still describes the image.
+func rangeApplies(r *http.Request, etag string, modified time.Time) bool {
+	ir := r.Header.Get("If-Range")
+	if ir == "" {
+		return true
+	}
+	if strings.HasPrefix(ir, `"`) || strings.HasPrefix(ir, "W/") {
+		// Strong comparison: a weak tag never matches.
+		return ir == etag
+	}
+	date, err := http.ParseTime(ir)
+	return err == nil && modified.Truncate(time.Second).Equal(date)
+}
+
+// parseRange parses a Range header selecting a single byte range of an object of size bytes.
+// Requests for several ranges return a nil range and are answered with the whole object.
+func parseRange(header string, size int64) (*blobstore.Range, error) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return nil, nil
+	}
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, errors.New("invalid range")
+	}
+
+	if first == "" {
+		// The last n bytes.
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 || size == 0 {
+			return nil, errors.New("invalid range")
+		}
+		n = min(n, size)
+		return &blobstore.Range{Offset: size - n, Length: n}, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return nil, errors.New("invalid range")
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return nil, errors.New("invalid range")
+		}
+		end = min(end, size-1)
+	}
+	return &blobstore.Range{Offset: start, Length: end - start + 1}, nil
+}
+
+// @Summary Request a direct upload
+// @Description Returns a presigned request for uploading an image directly to the storage, without sending it through the API.
+// @Description Once the file is uploaded, attach it to an idea by passing upload_id to PUT /ideas/{id}. Uploads that are not attached expire.
+// @Tags images
+// @Accept json
+// @Produce json
+// @Param upload body dto.CreateUploadRequest true "Content type and exact size of the file"
+// @Success 201 {object} dto.UploadResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /uploads [post]
+// @Security ApiKeyAuth
+func (h *ImageHandler) CreateUpload(c *gin.Context) {
+	var req dto.CreateUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind upload request: ", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
+		return
+	}
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+	resp, err := h.imageUsecase.CreateUpload(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+	c.JSON(http.StatusCreated, resp)
+}
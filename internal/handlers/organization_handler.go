@@ -0,0 +1,440 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	uc     usecase.OrganizationUsecase
+	logger *slog.Logger
+}
+
+func NewOrganizationHandler(uc usecase.OrganizationUsecase, logger *slog.Logger) *OrganizationHandler {
+	return &OrganizationHandler{
+		uc:     uc,
+		logger: logger,
+	}
+}
+
+// @Summary Create an organization
+// @Description Creates an organization grouping coffee shops of a chain. The current user becomes its owner.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateOrganizationRequest true "Organization details"
+// @Success 201 {object} dto.OrganizationResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations [post]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req dto.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind create organization request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.CreateOrganization(c.Request.Context(), actorID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("organization created", slog.String("organization_id", resp.ID.String()))
+	c.JSON(http.StatusCreated, resp)
+}
+
+// @Summary Get an organization
+// @Description Retrieves an organization by its ID.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} dto.OrganizationResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.GetOrganization(c.Request.Context(), organizationID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Update an organization
+// @Description Updates the name and description of an organization. Requires the shop.manage permission in the organization.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param request body dto.UpdateOrganizationRequest true "Organization details"
+// @Success 200 {object} dto.OrganizationResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id} [put]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind update organization request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.UpdateOrganization(c.Request.Context(), actorID, organizationID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("organization updated", slog.String("organization_id", organizationID.String()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Delete an organization
+// @Description Deletes an organization. Its coffee shops stay and become standalone. Requires the shop.delete permission in the organization.
+// @Tags organizations
+// @Param id path string true "Organization ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id} [delete]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.DeleteOrganization(c.Request.Context(), actorID, organizationID); err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("organization deleted", slog.String("organization_id", organizationID.String()))
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List my organizations
+// @Description Retrieves a paginated list of organizations in which the current user is a member.
+// @Tags organizations
+// @Produce json
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.OrganizationResponse]
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /users/me/organizations [get]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) ListMyOrganizations(c *gin.Context) {
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ListMyOrganizations(c.Request.Context(), userID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("listed organizations for user", slog.String("user_id", userID.String()), slog.Int("count", len(resp.Items)))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Add a member to an organization
+// @Description Gives a user a role (admin, moderator or barista; admin by default) in every coffee shop of the organization. Requires the worker.manage permission in the organization.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param request body dto.AddOrganizationMemberRequest true "Member details"
+// @Success 201 {object} dto.OrganizationMemberResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id}/members [post]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	var req dto.AddOrganizationMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind add organization member request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.AddMember(c.Request.Context(), actorID, organizationID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("organization member added", slog.String("organization_id", organizationID.String()), slog.String("user_id", req.UserID.String()))
+	c.JSON(http.StatusCreated, resp)
+}
+
+// @Summary List members of an organization
+// @Description Retrieves a paginated list of members of an organization with their roles. Requires the worker.manage permission in the organization.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.OrganizationMemberResponse]
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id}/members [get]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ListMembers(c.Request.Context(), actorID, organizationID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("listed organization members", slog.String("organization_id", organizationID.String()), slog.Int("count", len(resp.Items)))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Remove a member from an organization
+// @Description Removes a member from an organization. Owners cannot be removed. Requires the worker.manage permission in the organization.
+// @Tags organizations
+// @Param id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id}/members/{user_id} [delete]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	organizationID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	userID, ok := parseUUIDFromParam(h.logger, c, "user_id")
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.RemoveMember(c.Request.Context(), actorID, organizationID, userID); err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("organization member removed", slog.String("organization_id", organizationID.String()), slog.String("user_id", userID.String()))
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Add a coffee shop to an organization
+// @Description Adds a standalone coffee shop to an organization. Requires the shop.manage permission in the organization and ownership of the coffee shop.
+// @Tags organizations
+// @Accept json
+// @Param id path string true "Organization ID"
+// @Param request body dto.AttachCoffeeShopRequest true "Coffee shop"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id}/coffee-shops [post]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) AttachCoffeeShop(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	var req dto.AttachCoffeeShopRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind attach coffee shop request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.AttachCoffeeShop(c.Request.Context(), actorID, organizationID, &req); err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("coffee shop attached to organization", slog.String("organization_id", organizationID.String()), slog.String("shop_id", req.CoffeeShopID.String()))
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary Remove a coffee shop from an organization
+// @Description Makes a coffee shop of an organization standalone again. Requires the shop.manage permission in the organization.
+// @Tags organizations
+// @Param id path string true "Organization ID"
+// @Param shop_id path string true "Coffee Shop ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id}/coffee-shops/{shop_id} [delete]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) DetachCoffeeShop(c *gin.Context) {
+	organizationID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	shopID, ok := parseUUIDFromParam(h.logger, c, "shop_id")
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	if err := h.uc.DetachCoffeeShop(c.Request.Context(), actorID, organizationID, shopID); err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("coffee shop detached from organization", slog.String("organization_id", organizationID.String()), slog.String("shop_id", shopID.String()))
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary List coffee shops of an organization
+// @Description Retrieves a paginated list of coffee shops of an organization.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.CoffeeShopResponse]
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id}/coffee-shops [get]
+func (h *OrganizationHandler) ListCoffeeShops(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ListCoffeeShops(c.Request.Context(), organizationID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Get statistics of an organization
+// @Description Retrieves counters of ideas, likes, comments and rewards of an organization in total and per coffee shop. Available to members of the organization.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} dto.OrganizationStatsResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /organizations/{id}/stats [get]
+// @Security ApiKeyAuth
+func (h *OrganizationHandler) GetStats(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.GetStats(c.Request.Context(), actorID, organizationID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
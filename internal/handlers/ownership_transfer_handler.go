@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type OwnershipTransferHandler struct {
+	uc     usecase.OwnershipTransferUsecase
+	logger *slog.Logger
+}
+
+func NewOwnershipTransferHandler(uc usecase.OwnershipTransferUsecase, logger *slog.Logger) *OwnershipTransferHandler {
+	return &OwnershipTransferHandler{
+		uc:     uc,
+		logger: logger,
+	}
+}
+
+// @Summary Offer ownership of a coffee shop
+// @Description Offers ownership of a coffee shop to one of its admins. The transfer takes effect when the admin accepts it. With keep_ownership the initiator stays a co-owner, otherwise they become an admin. Requires the ownership.manage permission in the coffee shop.
+// @Tags ownership
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param request body dto.CreateOwnershipTransferRequest true "Transfer details"
+// @Success 201 {object} dto.OwnershipTransferResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/ownership-transfers [post]
+// @Security ApiKeyAuth
+func (h *OwnershipTransferHandler) CreateTransfer(c *gin.Context) {
+	shopID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateOwnershipTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind create ownership transfer request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.CreateTransfer(c.Request.Context(), actorID, shopID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("ownership transfer created", slog.String("shop_id", shopID.String()), slog.String("transfer_id", resp.ID.String()))
+	c.JSON(http.StatusCreated, resp)
+}
+
+// @Summary List ownership transfers of a coffee shop
+// @Description Retrieves a paginated list of ownership transfers of a coffee shop in any status. Requires the ownership.manage permission in the coffee shop.
+// @Tags ownership
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.OwnershipTransferResponse]
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/ownership-transfers [get]
+// @Security ApiKeyAuth
+func (h *OwnershipTransferHandler) ListTransfers(c *gin.Context) {
+	shopID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ListTransfers(c.Request.Context(), actorID, shopID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("listed ownership transfers for coffee shop", slog.String("shop_id", shopID.String()), slog.Int("count", len(resp.Items)))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Cancel an ownership transfer
+// @Description Cancels a pending ownership transfer. Requires the ownership.manage permission in the coffee shop.
+// @Tags ownership
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param transfer_id path string true "Transfer ID"
+// @Success 200 {object} dto.OwnershipTransferResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/ownership-transfers/{transfer_id} [delete]
+// @Security ApiKeyAuth
+func (h *OwnershipTransferHandler) CancelTransfer(c *gin.Context) {
+	shopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	transferID, ok := parseUUIDFromParam(h.logger, c, "transfer_id")
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.CancelTransfer(c.Request.Context(), actorID, shopID, transferID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("ownership transfer cancelled", slog.String("shop_id", shopID.String()), slog.String("transfer_id", transferID.String()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary List my ownership transfers
+// @Description Retrieves a paginated list of pending ownership transfers offered to the current user.
+// @Tags ownership
+// @Produce json
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.OwnershipTransferResponse]
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /users/me/ownership-transfers [get]
+// @Security ApiKeyAuth
+func (h *OwnershipTransferHandler) ListMyTransfers(c *gin.Context) {
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ListMyTransfers(c.Request.Context(), userID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("listed ownership transfers for user", slog.String("user_id", userID.String()), slog.Int("count", len(resp.Items)))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Accept an ownership transfer
+// @Description Accepts a pending ownership transfer offered to the current user and makes them an owner of the coffee shop. Fails if the user is no longer an admin or the initiator is no longer an owner.
+// @Tags ownership
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 200 {object} dto.OwnershipTransferResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /ownership-transfers/{id}/accept [post]
+// @Security ApiKeyAuth
+func (h *OwnershipTransferHandler) AcceptTransfer(c *gin.Context) {
+	transferID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.AcceptTransfer(c.Request.Context(), userID, transferID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("ownership transfer accepted", slog.String("transfer_id", transferID.String()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Decline an ownership transfer
+// @Description Declines a pending ownership transfer offered to the current user.
+// @Tags ownership
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Success 200 {object} dto.OwnershipTransferResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /ownership-transfers/{id}/decline [post]
+// @Security ApiKeyAuth
+func (h *OwnershipTransferHandler) DeclineTransfer(c *gin.Context) {
+	transferID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.DeclineTransfer(c.Request.Context(), userID, transferID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("ownership transfer declined", slog.String("transfer_id", transferID.String()))
+	c.JSON(http.StatusOK, resp)
+}
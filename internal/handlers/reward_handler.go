@@ -3,9 +3,9 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -23,7 +23,7 @@ func NewRewardHandler(uc usecase.RewardUsecase, logger *slog.Logger) *RewardHand
 }
 
 // @Summary Give a reward
-// @Description Creates a reward for the author of a specific idea. The caller must be the owner of the coffee shop.
+// @Description Creates a reward for the author of a specific idea. The caller needs the reward.give permission in the idea's coffee shop, and the reward type must belong to that shop.
 // @Tags rewards
 // @Accept json
 // @Produce json
@@ -33,6 +33,7 @@ func NewRewardHandler(uc usecase.RewardUsecase, logger *slog.Logger) *RewardHand
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
 // @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Reward type is out of stock or its monthly budget is used up"
 // @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
 // @Router /admin/rewards [post]
 // @Security ApiKeyAuth
@@ -60,7 +61,7 @@ func (h *RewardHandler) GiveReward(c *gin.Context) {
 }
 
 // @Summary Revoke a reward
-// @Description Deletes a reward by its ID. The caller must be the owner of the coffee shop.
+// @Description Deletes a reward by its ID. The caller needs the reward.give permission in the coffee shop that issued the reward.
 // @Tags rewards
 // @Produce json
 // @Param id path string true "Reward ID"
@@ -118,13 +119,14 @@ func (h *RewardHandler) GetReward(c *gin.Context) {
 }
 
 // @Summary Get rewards for a coffee shop
-// @Description Retrieves a paginated list of rewards associated with a specific coffee shop. The caller must be the owner.
+// @Description Retrieves a paginated list of rewards associated with a specific coffee shop. The caller must be a worker of the shop.
 // @Tags rewards
 // @Produce json
 // @Param id path string true "Coffee Shop ID"
-// @Param page query int false "Page number" default(0)
-// @Param limit query int false "Items per page" default(25)
-// @Success 200 {array} dto.RewardResponse
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.RewardResponse]
 // @Failure 400 {object} dto.ErrorResponse "Bad Request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
@@ -142,10 +144,7 @@ func (h *RewardHandler) GetRewardsForCoffeeShop(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.Query("page"))
-	limit, _ := strconv.Atoi(c.Query("limit"))
-
-	resp, err := h.uc.GetRewardsForCoffeeShop(c.Request.Context(), actorID, coffeeShopID, page, limit)
+	resp, err := h.uc.GetRewardsForCoffeeShop(c.Request.Context(), actorID, coffeeShopID, pagination.ParseQuery(c.Query))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -155,12 +154,13 @@ func (h *RewardHandler) GetRewardsForCoffeeShop(c *gin.Context) {
 }
 
 // @Summary Get my rewards
-// @Description Retrieves a paginated list of rewards the currently authenticated user has received.
+// @Description Retrieves a paginated list of rewards the currently authenticated user has received. Rewards that are not activated yet carry a short-lived redemption code to show to a barista, as text or as a QR code.
 // @Tags rewards
 // @Produce json
-// @Param page query int false "Page number" default(0)
-// @Param limit query int false "Items per page" default(25)
-// @Success 200 {array} dto.RewardResponse
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.RewardResponse]
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
 // @Router /users/me/rewards [get]
@@ -171,14 +171,49 @@ func (h *RewardHandler) GetMyRewards(c *gin.Context) {
 		return
 	}
 
-	page, _ := strconv.Atoi(c.Query("page"))
-	limit, _ := strconv.Atoi(c.Query("limit"))
+	resp, err := h.uc.GetMyRewards(c.Request.Context(), userID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Redeem a reward
+// @Description Activates a reward by the redemption code its receiver shows. The caller needs the reward.redeem permission in the coffee shop that issued the reward.
+// @Tags rewards
+// @Accept json
+// @Produce json
+// @Param redeem_info body dto.RedeemRewardRequest true "Redemption code and the coffee shop where it is redeemed"
+// @Success 200 {object} dto.RewardResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Already redeemed or code expired"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /rewards/redeem [post]
+// @Security ApiKeyAuth
+func (h *RewardHandler) RedeemReward(c *gin.Context) {
+	var req dto.RedeemRewardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind redeem reward request", "error", err)
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request: " + err.Error()})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
 
-	resp, err := h.uc.GetMyRewards(c.Request.Context(), userID, page, limit)
+	resp, err := h.uc.RedeemReward(c.Request.Context(), actorID, &req)
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
 	}
 
+	h.logger.Info("reward redeemed successfully", "reward_id", resp.ID.String())
 	c.JSON(http.StatusOK, resp)
 }
@@ -3,9 +3,9 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -48,13 +48,14 @@ func (h *RewardTypeHandler) GetRewardType(c *gin.Context) {
 }
 
 // @Summary Get reward types by coffee shop
-// @Description Get a list of all reward types for a given coffee shop with optional pagination
+// @Description Get a list of all reward types for a given coffee shop, including those shared by its organization, with optional pagination
 // @Tags rewards
 // @Produce json
 // @Param id path string true "Coffee Shop ID"
-// @Param page query int false "Page number"
-// @Param limit query int false "Number of items per page"
-// @Success 200 {array} dto.RewardTypeResponse
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.RewardTypeResponse]
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /coffee-shops/{id}/rewards/type [get]
 // @Security ApiKeyAuth
@@ -63,13 +64,33 @@ func (h *RewardTypeHandler) GetRewardTypesByCoffeeShop(c *gin.Context) {
 	if !ok {
 		return
 	}
-	pageRaw := c.Query("page")
-	limitRaw := c.Query("limit")
+	rewardTypes, err := h.uc.GetRewardsTypesFromCoffeeShop(c.Request.Context(), coffeeShopID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
 
-	page, _ := strconv.Atoi(pageRaw)
-	limit, _ := strconv.Atoi(limitRaw)
+	c.JSON(http.StatusOK, rewardTypes)
+}
 
-	rewardTypes, err := h.uc.GetRewardsTypesFromCoffeeShop(c.Request.Context(), coffeeShopID, page, limit)
+// @Summary Get reward types by organization
+// @Description Get a list of reward types shared by all coffee shops of an organization with optional pagination
+// @Tags rewards
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.RewardTypeResponse]
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /organizations/{id}/rewards/type [get]
+// @Security ApiKeyAuth
+func (h *RewardTypeHandler) GetRewardTypesByOrganization(c *gin.Context) {
+	organizationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+	rewardTypes, err := h.uc.GetRewardsTypesFromOrganization(c.Request.Context(), organizationID, pagination.ParseQuery(c.Query))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
@@ -79,7 +100,7 @@ func (h *RewardTypeHandler) GetRewardTypesByCoffeeShop(c *gin.Context) {
 }
 
 // @Summary Create a new reward type
-// @Description Create a new reward type
+// @Description Create a new reward type for a coffee shop, or with OrganizationID a reward type shared by all coffee shops of the organization. Requires the reward_type.manage permission in the coffee shop or the organization.
 // @Tags rewards
 // @Accept json
 // @Produce json
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
+	"github.com/gin-gonic/gin"
+)
+
+// StorageHandler receives the files sent to the presigned URLs of the local storage
+// driver, standing in for the upload endpoint of an object storage.
+type StorageHandler struct {
+	store  *blobstore.Local
+	logger *slog.Logger
+}
+
+func NewStorageHandler(store *blobstore.Local, logger *slog.Logger) *StorageHandler {
+	return &StorageHandler{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// @Summary Upload a file to the local storage
+// @Description Target of the presigned URLs returned by POST /uploads when images are stored in a local directory.
+// @Description The Content-Type and Content-Length headers must be the ones the URL was issued for.
+// @Tags images
+// @Accept octet-stream
+// @Param key path string true "Object key"
+// @Param expires query int true "Expiry of the URL as a Unix timestamp"
+// @Param signature query string true "Signature of the URL"
+// @Success 200 "OK"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 411 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /storage/{key} [put]
+func (h *StorageHandler) PutObject(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	logger := h.logger.With("method", "PutObject", "key", key)
+
+	size := c.Request.ContentLength
+	if size < 0 {
+		c.JSON(http.StatusLengthRequired, gin.H{"error": "Content-Length is required"})
+		return
+	}
+	contentType := c.GetHeader("Content-Type")
+	if err := h.store.VerifyPut(key, contentType, size, c.Request.URL.Query(), time.Now()); err != nil {
+		logger.Info("rejected upload", slog.String("error", err.Error()))
+		HandleAppErrors(apperrors.NewErrAccessDenied(err.Error()), logger, c)
+		return
+	}
+
+	err := h.store.Put(c.Request.Context(), key, c.Request.Body, size, contentType)
+	if errors.Is(err, blobstore.ErrInvalidKey) {
+		HandleAppErrors(apperrors.NewErrNotValid(err.Error()), logger, c)
+		return
+	}
+	if err != nil {
+		HandleAppErrors(err, logger, c)
+		return
+	}
+	logger.Info("file uploaded", slog.Int64("size", size))
+	c.Status(http.StatusOK)
+}
@@ -3,11 +3,12 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type UserHandler struct {
@@ -22,13 +23,17 @@ func NewUserHandler(uc usecase.UserUsecase, logger *slog.Logger) *UserHandler {
 	}
 }
 
-// @Summary Get all users
-// @Description Get a list of all users with optional pagination
+// @Summary Get users of a coffee shop
+// @Description Get the users who work in the coffee shop or posted ideas in it. Requires the user.view permission in the coffee shop.
 // @Tags users
 // @Produce json
-// @Param page query int false "Page number"
-// @Param limit query int false "Number of items per page"
-// @Success 200 {array} dto.UserResponse
+// @Param coffee_shop_id query string true "Coffee shop ID"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.UserResponse]
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /users [get]
 // @Security ApiKeyAuth
@@ -37,25 +42,28 @@ func (h UserHandler) GetAllUsers(c *gin.Context) {
 	if !ok {
 		return
 	}
-	pageRaw := c.Query("page")
-	limitRaw := c.Query("limit")
-
-	page, _ := strconv.Atoi(pageRaw)
-	limit, _ := strconv.Atoi(limitRaw)
-	resp, err := h.uc.GetAllUsers(c.Request.Context(), actorID, page, limit)
+	coffeeShopID, err := uuid.Parse(c.Query("coffee_shop_id"))
+	if err != nil {
+		h.logger.Info("invalid coffee_shop_id", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "coffee_shop_id is required and must be a valid uuid"})
+		return
+	}
+	resp, err := h.uc.GetAllUsers(c.Request.Context(), actorID, coffeeShopID, pagination.ParseQuery(c.Query))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
+		return
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
 // @Summary Get user by ID
-// @Description Get user details by user ID
+// @Description Get user details by user ID. Other users can be viewed with the user.view permission in a coffee shop they work in or posted ideas in.
 // @Tags users
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 200 {object} dto.UserResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /users/{id} [get]
@@ -117,13 +125,14 @@ func (h UserHandler) UpdateUser(c *gin.Context) {
 }
 
 // @Summary Delete user by ID
-// @Description Delete a user by user ID
+// @Description Delete a user by user ID. The only owner of a coffee shop has to transfer ownership first.
 // @Tags users
 // @Produce json
 // @Param id path string true "User ID"
 // @Success 204 "No Content"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /users/{id} [delete]
 // @Security ApiKeyAuth
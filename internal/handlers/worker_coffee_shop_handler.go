@@ -3,9 +3,9 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -23,7 +23,7 @@ func NewWorkerCoffeeShopHandler(uc usecase.WorkerCoffeeShopUsecase, logger *slog
 }
 
 // @Summary Add a worker to a coffee shop
-// @Description Adds a user as a worker to a specific coffee shop. Requires creator or admin access to the coffee shop.
+// @Description Adds a user as a worker to a specific coffee shop with the given role (admin, moderator or barista; barista by default). Requires the worker.manage permission in the coffee shop.
 // @Tags worker-coffee-shops
 // @Accept json
 // @Produce json
@@ -61,7 +61,7 @@ func (h *WorkerCoffeeShopHandler) AddWorker(c *gin.Context) {
 }
 
 // @Summary Remove a worker from a coffee shop
-// @Description Removes a worker-coffee shop relationship by its ID. Requires creator or admin access to the coffee shop.
+// @Description Removes a worker-coffee shop relationship by its ID. An owner can only remove themselves, and only while another owner remains. Requires the worker.manage permission in the coffee shop.
 // @Tags worker-coffee-shops
 // @Produce json
 // @Param id path string true "Worker Coffee Shop Relationship ID"
@@ -70,6 +70,7 @@ func (h *WorkerCoffeeShopHandler) AddWorker(c *gin.Context) {
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
 // @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
 // @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
 // @Router /admin/worker-coffee-shops/{id} [delete]
 // @Security ApiKeyAuth
@@ -94,14 +95,59 @@ func (h *WorkerCoffeeShopHandler) RemoveWorker(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// @Summary Change the role of a worker
+// @Description Gives a worker another role in the coffee shop: admin, moderator or barista. An owner can only step down by themselves, and only while another owner remains. Requires the worker.manage permission in the coffee shop.
+// @Tags worker-coffee-shops
+// @Accept json
+// @Produce json
+// @Param id path string true "Worker Coffee Shop Relationship ID"
+// @Param request body dto.ChangeWorkerRoleRequest true "New role"
+// @Success 200 {object} dto.WorkerCoffeeShopResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /admin/worker-coffee-shops/{id}/role [put]
+// @Security ApiKeyAuth
+func (h *WorkerCoffeeShopHandler) ChangeWorkerRole(c *gin.Context) {
+	workerShopRelationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	var req dto.ChangeWorkerRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind change worker role request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ChangeWorkerRole(c.Request.Context(), actorID, workerShopRelationID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("worker role changed successfully", slog.String("relation_id", workerShopRelationID.String()), slog.String("role", resp.Role))
+	c.JSON(http.StatusOK, resp)
+}
+
 // @Summary List workers in a coffee shop
-// @Description Retrieves a paginated list of users working in a specific coffee shop. Requires admin access to the coffee shop.
+// @Description Retrieves a paginated list of users working in a specific coffee shop. Requires the worker.manage permission in the coffee shop.
 // @Tags worker-coffee-shops
 // @Produce json
 // @Param id path string true "Coffee Shop ID"
-// @Param page query int false "Page number" default(0)
-// @Param limit query int false "Items per page" default(25)
-// @Success 200 {array} dto.UserResponse
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.UserResponse]
 // @Failure 400 {object} dto.ErrorResponse "Bad Request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
@@ -120,19 +166,13 @@ func (h *WorkerCoffeeShopHandler) ListWorkersInShop(c *gin.Context) {
 		return
 	}
 
-	pageRaw := c.Query("page")
-	limitRaw := c.Query("limit")
-
-	page, _ := strconv.Atoi(pageRaw)
-	limit, _ := strconv.Atoi(limitRaw)
-
-	resp, err := h.uc.ListWorkers(c.Request.Context(), actorID, shopID, page, limit)
+	resp, err := h.uc.ListWorkers(c.Request.Context(), actorID, shopID, pagination.ParseQuery(c.Query))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
 	}
 
-	h.logger.Info("listed workers for coffee shop", slog.String("shop_id", shopID.String()), slog.Int("count", len(resp)))
+	h.logger.Info("listed workers for coffee shop", slog.String("shop_id", shopID.String()), slog.Int("count", len(resp.Items)))
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -141,9 +181,10 @@ func (h *WorkerCoffeeShopHandler) ListWorkersInShop(c *gin.Context) {
 // @Tags worker-coffee-shops
 // @Produce json
 // @Param id path string true "Worker User ID"
-// @Param page query int false "Page number" default(0)
-// @Param limit query int false "Items per page" default(25)
-// @Success 200 {array} dto.CoffeeShopResponse
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.CoffeeShopResponse]
 // @Failure 400 {object} dto.ErrorResponse "Bad Request"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden"
@@ -162,18 +203,12 @@ func (h *WorkerCoffeeShopHandler) ListCoffeeShopsForWorker(c *gin.Context) {
 		return
 	}
 
-	pageRaw := c.Query("page")
-	limitRaw := c.Query("limit")
-
-	page, _ := strconv.Atoi(pageRaw)
-	limit, _ := strconv.Atoi(limitRaw)
-
-	resp, err := h.uc.ListShopsForWorker(c.Request.Context(), actorID, workerID, page, limit)
+	resp, err := h.uc.ListShopsForWorker(c.Request.Context(), actorID, workerID, pagination.ParseQuery(c.Query))
 	if err != nil {
 		HandleAppErrors(err, h.logger, c)
 		return
 	}
 
-	h.logger.Info("listed coffee shops for worker", slog.String("worker_id", workerID.String()), slog.Int("count", len(resp)))
+	h.logger.Info("listed coffee shops for worker", slog.String("worker_id", workerID.String()), slog.Int("count", len(resp.Items)))
 	c.JSON(http.StatusOK, resp)
 }
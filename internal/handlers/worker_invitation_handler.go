@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type WorkerInvitationHandler struct {
+	uc     usecase.WorkerInvitationUsecase
+	logger *slog.Logger
+}
+
+func NewWorkerInvitationHandler(uc usecase.WorkerInvitationUsecase, logger *slog.Logger) *WorkerInvitationHandler {
+	return &WorkerInvitationHandler{
+		uc:     uc,
+		logger: logger,
+	}
+}
+
+// @Summary Invite a worker to a coffee shop
+// @Description Creates an invitation with a role (admin, moderator or barista; barista by default). With a phone the invitation is shown to the user with that phone; without it a link token is returned once. Requires the worker.manage permission in the coffee shop.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param request body dto.CreateInvitationRequest true "Invitation details"
+// @Success 201 {object} dto.InvitationResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/invitations [post]
+// @Security ApiKeyAuth
+func (h *WorkerInvitationHandler) CreateInvitation(c *gin.Context) {
+	shopID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind create invitation request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.CreateInvitation(c.Request.Context(), actorID, shopID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("invitation created", slog.String("shop_id", shopID.String()), slog.String("invitation_id", resp.ID.String()))
+	c.JSON(http.StatusCreated, resp)
+}
+
+// @Summary List invitations of a coffee shop
+// @Description Retrieves a paginated list of invitations of a coffee shop in any status, with who created, answered or revoked them. Requires the worker.manage permission in the coffee shop.
+// @Tags invitations
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.InvitationResponse]
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/invitations [get]
+// @Security ApiKeyAuth
+func (h *WorkerInvitationHandler) ListInvitations(c *gin.Context) {
+	shopID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ListInvitations(c.Request.Context(), actorID, shopID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("listed invitations for coffee shop", slog.String("shop_id", shopID.String()), slog.Int("count", len(resp.Items)))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Revoke an invitation
+// @Description Revokes a pending invitation. The invitation is kept with the revoked status. Requires the worker.manage permission in the coffee shop.
+// @Tags invitations
+// @Produce json
+// @Param id path string true "Coffee Shop ID"
+// @Param invitation_id path string true "Invitation ID"
+// @Success 200 {object} dto.InvitationResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /coffee-shops/{id}/invitations/{invitation_id} [delete]
+// @Security ApiKeyAuth
+func (h *WorkerInvitationHandler) RevokeInvitation(c *gin.Context) {
+	shopID, ok := parseUUIDFromParam(h.logger, c, "id")
+	if !ok {
+		return
+	}
+
+	invitationID, ok := parseUUIDFromParam(h.logger, c, "invitation_id")
+	if !ok {
+		return
+	}
+
+	actorID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.RevokeInvitation(c.Request.Context(), actorID, shopID, invitationID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("invitation revoked", slog.String("shop_id", shopID.String()), slog.String("invitation_id", invitationID.String()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary List my invitations
+// @Description Retrieves a paginated list of pending invitations addressed to the phone of the current user.
+// @Tags invitations
+// @Produce json
+// @Param limit query int false "Items per page, at most 50" default(25)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param include_total query bool false "Include the total number of items"
+// @Success 200 {object} pagination.Page[dto.InvitationResponse]
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /users/me/invitations [get]
+// @Security ApiKeyAuth
+func (h *WorkerInvitationHandler) ListMyInvitations(c *gin.Context) {
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.ListMyInvitations(c.Request.Context(), userID, pagination.ParseQuery(c.Query))
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("listed invitations for user", slog.String("user_id", userID.String()), slog.Int("count", len(resp.Items)))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Accept an invitation
+// @Description Accepts a pending invitation addressed to the phone of the current user and adds them to the coffee shop with the invited role.
+// @Tags invitations
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} dto.WorkerCoffeeShopResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /invitations/{id}/accept [post]
+// @Security ApiKeyAuth
+func (h *WorkerInvitationHandler) AcceptInvitation(c *gin.Context) {
+	invitationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.AcceptInvitation(c.Request.Context(), userID, invitationID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("invitation accepted", slog.String("invitation_id", invitationID.String()), slog.String("relation_id", resp.ID.String()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Accept a link invitation
+// @Description Accepts a pending link invitation by its token and adds the current user to the coffee shop with the invited role.
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param request body dto.AcceptInvitationByTokenRequest true "Invitation token"
+// @Success 200 {object} dto.WorkerCoffeeShopResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /invitations/accept [post]
+// @Security ApiKeyAuth
+func (h *WorkerInvitationHandler) AcceptInvitationByToken(c *gin.Context) {
+	var req dto.AcceptInvitationByTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("failed to bind accept invitation request", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{Message: "bad request"})
+		return
+	}
+
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.AcceptInvitationByToken(c.Request.Context(), userID, &req)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("link invitation accepted", slog.String("relation_id", resp.ID.String()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary Decline an invitation
+// @Description Declines a pending invitation addressed to the phone of the current user.
+// @Tags invitations
+// @Produce json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} dto.InvitationResponse
+// @Failure 400 {object} dto.ErrorResponse "Bad Request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Not Found"
+// @Failure 409 {object} dto.ErrorResponse "Conflict"
+// @Failure 500 {object} dto.ErrorResponse "Internal Server Error"
+// @Router /invitations/{id}/decline [post]
+// @Security ApiKeyAuth
+func (h *WorkerInvitationHandler) DeclineInvitation(c *gin.Context) {
+	invitationID, ok := parseUUID(h.logger, c)
+	if !ok {
+		return
+	}
+
+	userID, ok := parseActorIDFromContext(h.logger, c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.uc.DeclineInvitation(c.Request.Context(), userID, invitationID)
+	if err != nil {
+		HandleAppErrors(err, h.logger, c)
+		return
+	}
+
+	h.logger.Info("invitation declined", slog.String("invitation_id", invitationID.String()))
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,171 @@
+// Package imageproc validates uploaded images and prepares them for storage.
+//
+// The format is sniffed from the content instead of trusting the file name or the
+// Content-Type of the upload. Images are decoded and encoded again, which drops EXIF,
+// GPS and any other metadata; the EXIF orientation of JPEG photos is applied to the
+// pixels first, so phone photos keep showing the right way up.
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Names of the variants stored for every uploaded image.
+const (
+	Original  = "original"
+	Medium    = "medium"
+	Thumbnail = "thumbnail"
+)
+
+var (
+	// ErrInvalidImage is wrapped by every error caused by the uploaded file rather than by the server.
+	ErrInvalidImage = errors.New("invalid image")
+
+	ErrUnsupportedFormat = fmt.Errorf("%w: only JPEG, PNG and WebP images are accepted", ErrInvalidImage)
+	ErrTooLarge          = fmt.Errorf("%w: file is too large", ErrInvalidImage)
+	ErrTooManyPixels     = fmt.Errorf("%w: width or height is too large", ErrInvalidImage)
+	ErrCorrupt           = fmt.Errorf("%w: file can not be decoded", ErrInvalidImage)
+)
+
+// formats maps the sniffed content types to the names of the registered image decoders.
+var formats = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+// Variant is an encoded image ready to be stored.
+type Variant struct {
+	Name   string
+	Data   []byte
+	Width  int
+	Height int
+}
+
+// Result holds the processed original followed by its variants. All of them share
+// the content type and the file extension.
+type Result struct {
+	ContentType string
+	Ext         string
+	Variants    []Variant
+}
+
+// Process reads an uploaded image, checks it against the limits of cfg and returns the
+// re-encoded original together with the medium and thumbnail variants.
+// Problems with the file itself are reported as errors wrapping ErrInvalidImage.
+func Process(r io.Reader, cfg *config.ImageConfig) (*Result, error) {
+	data, err := io.ReadAll(io.LimitReader(r, cfg.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > cfg.MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	format, ok := formats[http.DetectContentType(data)]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+	// The header is checked before decoding, so a small file claiming huge dimensions
+	// is rejected without allocating its pixels.
+	header, decoded, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decoded != format {
+		return nil, ErrCorrupt
+	}
+	if header.Width > cfg.MaxDimension || header.Height > cfg.MaxDimension {
+		return nil, ErrTooManyPixels
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	if format == "jpeg" {
+		img = orient(img, jpegOrientation(data))
+	}
+
+	// Photos become JPEG; PNG sources and images with transparency stay lossless.
+	enc := encoder{contentType: "image/jpeg", ext: ".jpg", quality: cfg.JPEGQuality}
+	if format == "png" || !isOpaque(img) {
+		enc = encoder{contentType: "image/png", ext: ".png"}
+	}
+
+	original, err := enc.encode(Original, img)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{ContentType: enc.contentType, Ext: enc.ext, Variants: []Variant{original}}
+	for _, v := range []struct {
+		name string
+		size int
+	}{{Medium, cfg.MediumSize}, {Thumbnail, cfg.ThumbnailSize}} {
+		variant := original
+		variant.Name = v.name
+		// Images already within the size are stored as they are instead of being upscaled.
+		if resized, ok := fit(img, v.size); ok {
+			if variant, err = enc.encode(v.name, resized); err != nil {
+				return nil, err
+			}
+		}
+		result.Variants = append(result.Variants, variant)
+	}
+	return result, nil
+}
+
+type encoder struct {
+	contentType string
+	ext         string
+	quality     int
+}
+
+func (e encoder) encode(name string, img image.Image) (Variant, error) {
+	var buf bytes.Buffer
+	var err error
+	if e.contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: e.quality})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return Variant{}, fmt.Errorf("failed to encode %s image: %w", name, err)
+	}
+	b := img.Bounds()
+	return Variant{Name: name, Data: buf.Bytes(), Width: b.Dx(), Height: b.Dy()}, nil
+}
+
+// fit scales img down so that its longest side is size. It reports false when the image
+// is already small enough.
+func fit(img image.Image, size int) (image.Image, bool) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return img, false
+	}
+	if w >= h {
+		h = max(1, h*size/w)
+		w = size
+	} else {
+		w = max(1, w*size/h)
+		h = size
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+	return dst, true
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
@@ -0,0 +1,260 @@
+// Package jwtkeys holds the keys used to sign and verify platform access tokens.
+//
+// Tokens are signed with a single active key and carry its key ID in the "kid"
+// header. Any number of additional public keys can be kept for verification, so
+// a new signing key can be rolled out while tokens issued with the previous one
+// stay valid until they expire. Key IDs are RFC 7638 thumbprints of the public
+// keys, so they never have to be configured by hand.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const minRSAKeyBits = 2048
+
+// JWK is the public part of a verification key as published in a JWK Set.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	public crypto.PublicKey
+	jwk    JWK
+}
+
+// KeySet signs tokens with its active key and verifies tokens signed with any of its keys.
+type KeySet struct {
+	signingKey any
+	signingKid string
+	method     jwt.SigningMethod
+	keys       map[string]verificationKey
+	order      []string
+}
+
+// New builds a key set that signs with signingKey and additionally accepts tokens
+// signed with the private counterparts of verificationKeys.
+// Supported keys are RSA (RS256, at least 2048 bits) and Ed25519 (EdDSA).
+func New(signingKey crypto.Signer, verificationKeys ...crypto.PublicKey) (*KeySet, error) {
+	s := &KeySet{keys: make(map[string]verificationKey)}
+	kid, err := s.add(signingKey.Public())
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+	s.signingKey = signingKey
+	s.signingKid = kid
+	s.method = s.keys[kid].method
+
+	for i, key := range verificationKeys {
+		if _, err := s.add(key); err != nil {
+			return nil, fmt.Errorf("verification key %d: %w", i+1, err)
+		}
+	}
+	return s, nil
+}
+
+// NewHMAC builds a key set that signs and verifies HS256 tokens with a shared secret.
+// It is meant for local development and tests; it publishes no keys.
+func NewHMAC(secret string) *KeySet {
+	return &KeySet{
+		signingKey: []byte(secret),
+		method:     jwt.SigningMethodHS256,
+	}
+}
+
+// Load builds the key set described by cfg. Without a signing key file it falls
+// back to HS256 with cfg.Secret.
+func Load(cfg *config.JWTConfig) (*KeySet, error) {
+	if cfg.SigningKeyFile == "" {
+		if cfg.Secret == "" {
+			return nil, errors.New("neither a jwt signing key file nor a jwt secret is configured")
+		}
+		return NewHMAC(cfg.Secret), nil
+	}
+
+	signingKey, err := readPrivateKey(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	var verificationKeys []crypto.PublicKey
+	for _, path := range cfg.VerificationKeyFiles {
+		key, err := readPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+	return New(signingKey, verificationKeys...)
+}
+
+// Sign returns the signed token for claims, with the active key ID in the "kid" header.
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.signingKid != "" {
+		token.Header["kid"] = s.signingKid
+	}
+	return token.SignedString(s.signingKey)
+}
+
+// Parse verifies tokenString and decodes it into claims. The key is chosen by the
+// "kid" header and the token algorithm has to match that key.
+func (s *KeySet) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	if s.keys == nil {
+		return jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (any, error) {
+			return s.signingKey, nil
+		}, jwt.WithValidMethods([]string{s.method.Alg()}))
+	}
+
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %q for key %q", token.Method.Alg(), kid)
+		}
+		return key.public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
+}
+
+// SigningKeyID returns the key ID put into new tokens, or an empty string for HS256.
+func (s *KeySet) SigningKeyID() string {
+	return s.signingKid
+}
+
+// PublicKeys returns every verification key, the active signing key first.
+func (s *KeySet) PublicKeys() []JWK {
+	keys := make([]JWK, 0, len(s.order))
+	for _, kid := range s.order {
+		keys = append(keys, s.keys[kid].jwk)
+	}
+	return keys
+}
+
+func (s *KeySet) add(public crypto.PublicKey) (string, error) {
+	key, err := newVerificationKey(public)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := s.keys[key.jwk.Kid]; !ok {
+		s.keys[key.jwk.Kid] = key
+		s.order = append(s.order, key.jwk.Kid)
+	}
+	return key.jwk.Kid, nil
+}
+
+func newVerificationKey(public crypto.PublicKey) (verificationKey, error) {
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return verificationKey{}, fmt.Errorf("rsa key has %d bits, at least %d are required", key.N.BitLen(), minRSAKeyBits)
+		}
+		jwk := JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			N:   encode(key.N.Bytes()),
+			E:   encode(big.NewInt(int64(key.E)).Bytes()),
+		}
+		jwk.Kid = thumbprint(map[string]string{"e": jwk.E, "kty": jwk.Kty, "n": jwk.N})
+		return verificationKey{method: jwt.SigningMethodRS256, public: key, jwk: jwk}, nil
+	case ed25519.PublicKey:
+		jwk := JWK{
+			Kty: "OKP",
+			Use: "sig",
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Crv: "Ed25519",
+			X:   encode(key),
+		}
+		jwk.Kid = thumbprint(map[string]string{"crv": jwk.Crv, "kty": jwk.Kty, "x": jwk.X})
+		return verificationKey{method: jwt.SigningMethodEdDSA, public: key, jwk: jwk}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %T", public)
+	}
+}
+
+// thumbprint computes the RFC 7638 thumbprint of the required JWK members.
+// encoding/json sorts map keys, which gives the lexicographic order the RFC requires.
+func thumbprint(members map[string]string) string {
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return encode(sum[:])
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+	return block, nil
+}
+
+func readPrivateKey(path string) (crypto.Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key %s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T in %s", key, path)
+	}
+	return signer, nil
+}
+
+// readPublicKey accepts a public key or, for convenience during rotation, a private key
+// whose public part is used.
+func readPublicKey(path string) (crypto.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	signer, err := readPrivateKey(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key %s", path)
+	}
+	return signer.Public(), nil
+}
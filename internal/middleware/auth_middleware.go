@@ -34,10 +34,37 @@ func AuthMiddleware(uc usecase.AuthUsecase, logger *slog.Logger) gin.HandlerFunc
 		}
 
 		c.Set("user_id", claims.UserID)
+		c.Set("session_id", claims.SessionID)
 		c.Next()
 	}
 }
 
+// OptionalAuthMiddleware identifies the caller on public routes. Requests without a valid token proceed anonymously.
+func OptionalAuthMiddleware(uc usecase.AuthUsecase, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.Next()
+			return
+		}
+
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		claims, err := uc.ValidateJWTToken(c.Request.Context(), tokenString)
+		if err != nil {
+			logger.Info("ignoring invalid authorization token", "path", c.Request.URL.Path, "error", err.Error())
+			c.Next()
+			return
+		}
+
+		c.Set("user_id", claims.UserID)
+		c.Set("session_id", claims.SessionID)
+		c.Next()
+	}
+}
+
+// AdminFilter keeps the admin routes away from users who manage no coffee shop at all. It is not
+// the access check: the usecases behind the routes check the permission in the affected shop
+// with AccessControlUsecase.Can.
 func AdminFilter(workerShopRepo repository.WorkerCoffeeShopRepository, logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userIDAny, exist := c.Get("user_id")
@@ -6,15 +6,18 @@ import (
 	"github.com/google/uuid"
 )
 
+// Category belongs either to a coffee shop or to an organization, whose categories are shared by all its coffee shops.
 type Category struct {
-	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	CoffeeShopID *uuid.UUID `gorm:"type:uuid"`
-	CoffeeShop   CoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
-	Title        string     `gorm:"not null;size:50"`
-	Description  *string
-	IsDeleted    bool      `gorm:"default:false"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	ID             uuid.UUID     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID   *uuid.UUID    `gorm:"type:uuid"`
+	CoffeeShop     CoffeeShop    `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	OrganizationID *uuid.UUID    `gorm:"type:uuid;index"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID;references:ID;constraint:OnDelete:CASCADE"`
+	Title          string        `gorm:"not null;size:50"`
+	Description    *string
+	IsDeleted      bool      `gorm:"default:false"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
 }
 
 func (Category) TableName() string {
@@ -6,19 +6,48 @@ import (
 	"github.com/google/uuid"
 )
 
+// CoffeeShop is managed by its workers with the owner role. CreatorID only records who founded the shop
+// and is cleared when the founder's account is removed.
 type CoffeeShop struct {
-	ID             uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	CreatorID      uuid.UUID `gorm:"type:uuid;not null"`
-	Creator        User      `gorm:"foreignKey:CreatorID;references:ID;constraint:OnDelete:CASCADE"`
-	Name           string    `gorm:"not null;size:100"`
-	Address        string    `gorm:"not null;size:255"`
-	Contacts       *string   `gorm:"size:100"`
+	ID             uuid.UUID     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CreatorID      *uuid.UUID    `gorm:"type:uuid"`
+	Creator        *User         `gorm:"foreignKey:CreatorID;references:ID;constraint:OnDelete:SET NULL"`
+	OrganizationID *uuid.UUID    `gorm:"type:uuid;index"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID;references:ID;constraint:OnDelete:SET NULL"`
+	Name           string        `gorm:"not null;size:100"`
+	// Address is the address as shown to customers; the structured parts below are optional.
+	Address    string  `gorm:"not null;size:255"`
+	Country    *string `gorm:"size:100"`
+	City       *string `gorm:"size:100"`
+	Street     *string `gorm:"size:255"`
+	PostalCode *string `gorm:"size:20"`
+	// Latitude and Longitude are either both set or both empty. Only located shops are found by nearby search.
+	Latitude       *float64       `gorm:"index:idx_coffee_shop_location"`
+	Longitude      *float64       `gorm:"index:idx_coffee_shop_location"`
+	OpeningHours   []OpeningHours `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	Contacts       *string        `gorm:"size:100"`
 	WelcomeMessage *string
 	Rules          *string
 	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
 	CreatedAt      time.Time `gorm:"autoCreateTime"`
 }
 
+// OpeningHours is an interval of a weekday in which the coffee shop is open, in the local time of the shop.
+// An interval that closes at or before its opening time ends on the next day.
+type OpeningHours struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID uuid.UUID `gorm:"type:uuid;not null;index"`
+	// Weekday is 0 for Sunday through 6 for Saturday, as in time.Weekday.
+	Weekday int `gorm:"not null"`
+	// Opens and Closes are formatted as HH:MM.
+	Opens  string `gorm:"size:5;not null"`
+	Closes string `gorm:"size:5;not null"`
+}
+
+func (OpeningHours) TableName() string {
+	return "coffee_shop_opening_hours"
+}
+
 type WorkerCoffeeShop struct {
 	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
 	WorkerID     *uuid.UUID `gorm:"type:uuid"`
@@ -58,13 +58,54 @@ func (IdeaComment) TableName() string {
 	return "idea_comment"
 }
 
+// IdeaStatus is a step of a coffee shop's idea workflow.
+// Statuses without a coffee shop are legacy global ones.
 type IdeaStatus struct {
-	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	Title     string    `gorm:"not null;unique;size:50"`
-	IsDeleted bool      `gorm:"default:false"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID *uuid.UUID `gorm:"type:uuid;index"`
+	CoffeeShop   CoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	Title        string     `gorm:"not null;size:50"`
+	IsInitial    bool       `gorm:"default:false"`
+	IsTerminal   bool       `gorm:"default:false"`
+	IsDeleted    bool       `gorm:"default:false"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
 }
 
 func (IdeaStatus) TableName() string {
 	return "status"
 }
+
+// IdeaStatusTransition allows moving an idea from one status of a coffee shop to another.
+type IdeaStatusTransition struct {
+	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID *uuid.UUID `gorm:"type:uuid;index"`
+	CoffeeShop   CoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	FromStatusID *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_status_transition"`
+	FromStatus   IdeaStatus `gorm:"foreignKey:FromStatusID;references:ID;constraint:OnDelete:CASCADE"`
+	ToStatusID   *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_status_transition"`
+	ToStatus     IdeaStatus `gorm:"foreignKey:ToStatusID;references:ID;constraint:OnDelete:CASCADE"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+}
+
+func (IdeaStatusTransition) TableName() string {
+	return "status_transition"
+}
+
+// IdeaStatusHistory records a single status change of an idea.
+type IdeaStatusHistory struct {
+	ID           uuid.UUID   `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	IdeaID       *uuid.UUID  `gorm:"type:uuid;index"`
+	Idea         Idea        `gorm:"foreignKey:IdeaID;references:ID;constraint:OnDelete:CASCADE"`
+	FromStatusID *uuid.UUID  `gorm:"type:uuid"`
+	FromStatus   *IdeaStatus `gorm:"foreignKey:FromStatusID;references:ID;constraint:OnDelete:SET NULL"`
+	ToStatusID   *uuid.UUID  `gorm:"type:uuid"`
+	ToStatus     *IdeaStatus `gorm:"foreignKey:ToStatusID;references:ID;constraint:OnDelete:SET NULL"`
+	ActorID      *uuid.UUID  `gorm:"type:uuid"`
+	Actor        *User       `gorm:"foreignKey:ActorID;references:ID;constraint:OnDelete:SET NULL"`
+	Note         *string     `gorm:"size:500"`
+	CreatedAt    time.Time   `gorm:"autoCreateTime;index"`
+}
+
+func (IdeaStatusHistory) TableName() string {
+	return "idea_status_history"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImageUpload is an image the user uploads directly to the storage with a presigned URL.
+// The file is stored under ObjectKey as sent; finalizing the upload processes it into an
+// image at ImageURL. Uploads are deleted once they expire, finalized or not.
+type ImageUpload struct {
+	ID          uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	User        *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	ObjectKey   string    `gorm:"not null;uniqueIndex"`
+	ContentType string    `gorm:"not null;size:100"`
+	Size        int64     `gorm:"not null"`
+	ImageURL    *string
+	FinalizedAt *time.Time
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (ImageUpload) TableName() string {
+	return "image_upload"
+}
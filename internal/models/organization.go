@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization groups the coffee shops of a chain. Its members hold a role in every coffee shop
+// of the organization, and its categories and reward types are shared by all of them.
+type Organization struct {
+	ID          uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CreatorID   *uuid.UUID `gorm:"type:uuid"`
+	Creator     *User      `gorm:"foreignKey:CreatorID;references:ID;constraint:OnDelete:SET NULL"`
+	Name        string     `gorm:"not null;size:100"`
+	Description *string
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
+
+func (Organization) TableName() string {
+	return "organization"
+}
+
+// OrganizationMember gives a user a role in every coffee shop of the organization.
+type OrganizationMember struct {
+	ID             uuid.UUID    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_organization_member"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;references:ID;constraint:OnDelete:CASCADE"`
+	UserID         uuid.UUID    `gorm:"type:uuid;not null;uniqueIndex:idx_organization_member"`
+	User           User         `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	RoleID         uuid.UUID    `gorm:"type:uuid;not null"`
+	Role           Role         `gorm:"foreignKey:RoleID;references:ID"`
+	CreatedAt      time.Time    `gorm:"autoCreateTime"`
+}
+
+func (OrganizationMember) TableName() string {
+	return "organization_member"
+}
+
+// CoffeeShopStats holds activity counters of a coffee shop.
+type CoffeeShopStats struct {
+	CoffeeShopID    uuid.UUID
+	Name            string
+	Ideas           int64
+	Likes           int64
+	Comments        int64
+	RewardsGiven    int64
+	RewardsRedeemed int64
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of an ownership transfer. A pending transfer past its expiry is reported as expired.
+const (
+	TransferPending   = "pending"
+	TransferAccepted  = "accepted"
+	TransferDeclined  = "declined"
+	TransferCancelled = "cancelled"
+	TransferExpired   = "expired"
+)
+
+// OwnershipTransfer is an offer of an owner to make an admin of the coffee shop an owner.
+// It takes effect only when the admin accepts it. With KeepOwnership the offering owner
+// stays an owner next to the new one, otherwise they become an admin.
+type OwnershipTransfer struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CoffeeShop    CoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	FromUserID    *uuid.UUID `gorm:"type:uuid"`
+	FromUser      *User      `gorm:"foreignKey:FromUserID;references:ID;constraint:OnDelete:SET NULL"`
+	ToUserID      uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ToUser        User       `gorm:"foreignKey:ToUserID;references:ID;constraint:OnDelete:CASCADE"`
+	KeepOwnership bool       `gorm:"default:false"`
+	Status        string     `gorm:"not null;size:20;default:pending"`
+	ExpiresAt     time.Time  `gorm:"not null"`
+	RespondedAt   *time.Time
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (OwnershipTransfer) TableName() string {
+	return "ownership_transfer"
+}
+
+// StatusAt returns the status of the transfer, reporting pending transfers past their expiry as expired.
+func (t OwnershipTransfer) StatusAt(now time.Time) string {
+	if t.Status == TransferPending && !now.Before(t.ExpiresAt) {
+		return TransferExpired
+	}
+	return t.Status
+}
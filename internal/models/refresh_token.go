@@ -6,13 +6,49 @@ import (
 	"github.com/google/uuid"
 )
 
+// UserSession is a logged-in device. All refresh tokens issued by rotating the token
+// of one login belong to its session, which is the token family: replaying a token
+// that was already rotated revokes the session together with its live token.
+type UserSession struct {
+	ID         uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID     uuid.UUID `gorm:"not null;type:uuid;index"`
+	User       *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	DeviceName string    `gorm:"size:100"`
+	IP         string    `gorm:"size:45"`
+	UserAgent  string    `gorm:"size:512"`
+	CreatedAt  time.Time
+	LastUsedAt time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+}
+
+func (UserSession) TableName() string {
+	return "user_session"
+}
+
+// UserRefreshToken is the live refresh token of a session. Tokens issued before sessions
+// existed have no session and get one on their next refresh.
 type UserRefreshToken struct {
-	UserID       uuid.UUID `gorm:"not null;type:uuid;index"`
-	User         *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
-	RefreshToken string    `gorm:"primaryKey;type:text"`
-	ExpiresAt    time.Time `gorm:"not null"`
+	UserID       uuid.UUID    `gorm:"not null;type:uuid;index"`
+	User         *User        `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	SessionID    *uuid.UUID   `gorm:"type:uuid;uniqueIndex"`
+	Session      *UserSession `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE"`
+	RefreshToken string       `gorm:"primaryKey;type:text"`
+	ExpiresAt    time.Time    `gorm:"not null"`
 }
 
 func (UserRefreshToken) TableName() string {
 	return "user_refresh_tokens"
 }
+
+// RotatedRefreshToken remembers the hash of a refresh token that was exchanged for a new
+// one, so that a replay of it can be recognised as token reuse.
+type RotatedRefreshToken struct {
+	RefreshToken string       `gorm:"primaryKey;type:text"`
+	SessionID    uuid.UUID    `gorm:"not null;type:uuid;index"`
+	Session      *UserSession `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE"`
+	RotatedAt    time.Time    `gorm:"not null"`
+}
+
+func (RotatedRefreshToken) TableName() string {
+	return "rotated_refresh_token"
+}
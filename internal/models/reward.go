@@ -18,19 +18,38 @@ type Reward struct {
 	RewardType   *RewardType `gorm:"foreignKey:RewardTypeID"`
 	IsActivated  bool        `gorm:"default:false"`
 	GivenAt      *time.Time
+	ExpiresAt    *time.Time
+	IsExpired    bool      `gorm:"default:false"`
 	CreatedAt    time.Time `gorm:"autoCreateTime"`
+
+	// RedemptionCode is the one-time code the receiver shows to a barista to redeem the reward.
+	RedemptionCode          *string `gorm:"uniqueIndex"`
+	RedemptionCodeExpiresAt *time.Time
+	ActivatedAt             *time.Time
+	ActivatedByID           *uuid.UUID `gorm:"type:uuid"`
+	ActivatedBy             *User      `gorm:"foreignKey:ActivatedByID"`
 }
 
 func (Reward) TableName() string {
 	return "reward"
 }
 
+// RewardType belongs either to a coffee shop or to an organization, whose reward types can be given in all its coffee shops.
 type RewardType struct {
-	ID           uuid.UUID   `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	CoffeeShopID *uuid.UUID  `gorm:"type:uuid"`
-	CoffeeShop   *CoffeeShop `gorm:"foreignKey:CoffeeShopID"`
-	Description  string      `gorm:"not null"`
-	CreatedAt    time.Time   `gorm:"autoCreateTime"`
+	ID             uuid.UUID     `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID   *uuid.UUID    `gorm:"type:uuid"`
+	CoffeeShop     *CoffeeShop   `gorm:"foreignKey:CoffeeShopID"`
+	OrganizationID *uuid.UUID    `gorm:"type:uuid;index"`
+	Organization   *Organization `gorm:"foreignKey:OrganizationID;constraint:OnDelete:CASCADE"`
+	Title          string        `gorm:"not null;default:''"`
+	Description    string        `gorm:"not null"`
+	// ValidityDays is how long a reward stays redeemable after it is given; nil means it never expires.
+	ValidityDays *int
+	// TotalStock limits how many rewards of this type can ever be given; nil means unlimited.
+	TotalStock *int
+	// MonthlyBudget limits how many rewards of this type can be given per calendar month (UTC); nil means unlimited.
+	MonthlyBudget *int
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
 }
 
 func (RewardType) TableName() string {
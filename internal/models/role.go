@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,3 +18,90 @@ type Role struct {
 func (Role) TableName() string {
 	return "role"
 }
+
+// Names of the roles a worker can have in a coffee shop.
+const (
+	RoleOwner     = "owner"
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleBarista   = "barista"
+)
+
+// Permission is an action in a coffee shop that is granted to workers through their role.
+type Permission string
+
+const (
+	// PermShopManage allows changing the shop settings, categories and idea statuses.
+	PermShopManage Permission = "shop.manage"
+	// PermShopDelete allows deleting the shop.
+	PermShopDelete Permission = "shop.delete"
+	// PermIdeaStatusChange allows moving ideas along the status workflow.
+	PermIdeaStatusChange Permission = "idea.status.change"
+	// PermIdeaModerate allows editing and deleting ideas of other users.
+	PermIdeaModerate Permission = "idea.moderate"
+	// PermCommentDelete allows deleting comments of other users.
+	PermCommentDelete Permission = "comment.delete"
+	// PermRewardGive allows giving and revoking rewards.
+	PermRewardGive Permission = "reward.give"
+	// PermRewardTypeManage allows creating, changing and deleting reward types.
+	PermRewardTypeManage Permission = "reward_type.manage"
+	// PermRewardRedeem allows redeeming rewards shown by customers.
+	PermRewardRedeem Permission = "reward.redeem"
+	// PermWorkerManage allows adding and removing workers and changing their roles.
+	PermWorkerManage Permission = "worker.manage"
+	// PermUserBan allows banning users in the shop.
+	PermUserBan Permission = "user.ban"
+	// PermUserView allows viewing the profiles of the workers of the shop and of the users who posted ideas in it.
+	PermUserView Permission = "user.view"
+	// PermOwnershipManage allows handing ownership of the shop to other workers.
+	PermOwnershipManage Permission = "ownership.manage"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleOwner: {
+		PermShopManage, PermShopDelete, PermIdeaStatusChange, PermIdeaModerate, PermCommentDelete,
+		PermRewardGive, PermRewardTypeManage, PermRewardRedeem, PermWorkerManage, PermUserBan, PermUserView, PermOwnershipManage,
+	},
+	RoleAdmin: {
+		PermShopManage, PermIdeaStatusChange, PermIdeaModerate, PermCommentDelete,
+		PermRewardGive, PermRewardTypeManage, PermRewardRedeem, PermWorkerManage, PermUserBan, PermUserView,
+	},
+	RoleModerator: {
+		PermIdeaStatusChange, PermIdeaModerate, PermCommentDelete, PermRewardRedeem, PermUserBan, PermUserView,
+	},
+	RoleBarista: {
+		PermRewardRedeem,
+	},
+}
+
+// RoleNames returns the names of all known roles.
+func RoleNames() []string {
+	return []string{RoleOwner, RoleAdmin, RoleModerator, RoleBarista}
+}
+
+// IsKnownRole reports whether name is one of the roles returned by RoleNames.
+func IsKnownRole(name string) bool {
+	_, ok := rolePermissions[name]
+	return ok
+}
+
+// Can reports whether the role grants the permission. Unknown roles grant nothing.
+func (r Role) Can(permission Permission) bool {
+	return slices.Contains(rolePermissions[r.Name], permission)
+}
+
+// Permissions returns the permissions granted by the role.
+func (r Role) Permissions() []Permission {
+	return slices.Clone(rolePermissions[r.Name])
+}
+
+// RolesWith returns the names of the roles that grant the permission.
+func RolesWith(permission Permission) []string {
+	var names []string
+	for _, name := range RoleNames() {
+		if slices.Contains(rolePermissions[name], permission) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
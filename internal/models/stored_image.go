@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// StoredImage records an image stored by the API together with its variants. Images that
+// no idea or upload refers to are deleted by the garbage collection.
+type StoredImage struct {
+	ImageURL  string    `gorm:"primaryKey;type:text"`
+	CreatedAt time.Time `gorm:"not null;index"`
+}
+
+func (StoredImage) TableName() string {
+	return "stored_image"
+}
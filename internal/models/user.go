@@ -23,11 +23,16 @@ func (User) TableName() string {
 
 type BannedUser struct {
 	ID           uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
-	UserID       *uuid.UUID `gorm:"type:uuid"`
+	UserID       *uuid.UUID `gorm:"type:uuid;index:idx_banned_user_shop"`
 	User         User       `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
-	CoffeeShopID *uuid.UUID `gorm:"type:uuid"`
+	CoffeeShopID *uuid.UUID `gorm:"type:uuid;index:idx_banned_user_shop"`
 	CoffeeShop   CoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
-	CreatedAt    time.Time  `gorm:"autoCreateTime"`
+	BannedByID   *uuid.UUID `gorm:"type:uuid"`
+	BannedBy     *User      `gorm:"foreignKey:BannedByID;references:ID;constraint:OnDelete:SET NULL"`
+	Reason       *string    `gorm:"size:500"`
+	ExpiresAt    *time.Time
+	HideIdeas    bool      `gorm:"default:false"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
 }
 
 func (BannedUser) TableName() string {
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statuses of a worker invitation. A pending invitation past its expiry is reported as expired.
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+// WorkerInvitation invites a user to work in a coffee shop with a role.
+// It is addressed either to a phone number or to whoever holds its link token.
+// Invitations are never deleted and keep who created, answered or revoked them.
+type WorkerInvitation struct {
+	ID            uuid.UUID  `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CoffeeShop    CoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	RoleID        uuid.UUID  `gorm:"type:uuid;not null"`
+	Role          Role       `gorm:"foreignKey:RoleID;references:ID;constraint:OnDelete:CASCADE"`
+	Phone         *string    `gorm:"size:15;index"`
+	TokenHash     *string    `gorm:"uniqueIndex"`
+	Status        string     `gorm:"not null;size:20;default:pending"`
+	ExpiresAt     time.Time  `gorm:"not null"`
+	InvitedByID   *uuid.UUID `gorm:"type:uuid"`
+	InvitedBy     *User      `gorm:"foreignKey:InvitedByID;references:ID;constraint:OnDelete:SET NULL"`
+	RespondedByID *uuid.UUID `gorm:"type:uuid"`
+	RespondedBy   *User      `gorm:"foreignKey:RespondedByID;references:ID;constraint:OnDelete:SET NULL"`
+	RespondedAt   *time.Time
+	RevokedByID   *uuid.UUID `gorm:"type:uuid"`
+	RevokedBy     *User      `gorm:"foreignKey:RevokedByID;references:ID;constraint:OnDelete:SET NULL"`
+	RevokedAt     *time.Time
+	UpdatedAt     time.Time `gorm:"autoUpdateTime"`
+	CreatedAt     time.Time `gorm:"autoCreateTime"`
+}
+
+func (WorkerInvitation) TableName() string {
+	return "worker_invitation"
+}
+
+// StatusAt returns the status of the invitation, reporting pending invitations past their expiry as expired.
+func (i WorkerInvitation) StatusAt(now time.Time) string {
+	if i.Status == InvitationPending && !now.Before(i.ExpiresAt) {
+		return InvitationExpired
+	}
+	return i.Status
+}
@@ -0,0 +1,73 @@
+package otpsender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPSMSSender posts the code as a text message to a generic HTTP SMS gateway.
+type HTTPSMSSender struct {
+	url        string
+	apiKey     string
+	senderName string
+	client     *http.Client
+}
+
+type smsRequest struct {
+	Phone   string `json:"phone"`
+	Message string `json:"message"`
+	Sender  string `json:"sender,omitempty"`
+}
+
+func NewHTTPSMSSender(url, apiKey, senderName string, timeout time.Duration) *HTTPSMSSender {
+	return &HTTPSMSSender{
+		url:        url,
+		apiKey:     apiKey,
+		senderName: senderName,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPSMSSender) Send(ctx context.Context, phone, code string) error {
+	body, err := json.Marshal(smsRequest{
+		Phone:   phone,
+		Message: fmt.Sprintf(messageFormat, code),
+		Sender:  s.senderName,
+	})
+	if err != nil {
+		return permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return checkStatus("sms gateway", resp.StatusCode)
+}
+
+// checkStatus treats 5xx and 429 as transient and any other non-2xx status as permanent.
+func checkStatus(gateway string, status int) error {
+	if status >= 200 && status < 300 {
+		return nil
+	}
+	err := fmt.Errorf("%s responded with status %d", gateway, status)
+	if status >= 500 || status == http.StatusTooManyRequests {
+		return err
+	}
+	return permanent(err)
+}
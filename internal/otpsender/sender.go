@@ -0,0 +1,114 @@
+package otpsender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+)
+
+const messageFormat = "Ваш код подтверждения: %s"
+
+// OTPSender delivers a one-time code to a phone number in international format.
+type OTPSender interface {
+	Send(ctx context.Context, phone, code string) error
+}
+
+// permanentError marks a delivery failure that will not succeed on retry,
+// e.g. a rejected phone number or invalid credentials.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+func permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// NewOTPSender builds the sender selected by cfg.Provider wrapped with retries.
+func NewOTPSender(cfg *config.OTPSenderConfig, logger *slog.Logger) (OTPSender, error) {
+	var sender OTPSender
+	switch cfg.Provider {
+	case "sms":
+		if cfg.SMSGatewayURL == "" {
+			return nil, errors.New("sms gateway url is not configured")
+		}
+		sender = NewHTTPSMSSender(cfg.SMSGatewayURL, cfg.SMSGatewayAPIKey, cfg.SMSSenderName, cfg.Timeout)
+	case "telegram":
+		if cfg.TelegramToken == "" {
+			return nil, errors.New("telegram gateway token is not configured")
+		}
+		sender = NewTelegramSender(cfg.TelegramAPIURL, cfg.TelegramToken, cfg.Timeout)
+	case "file":
+		sender = NewFileSender(cfg.FilePath)
+	case "memory":
+		sender = NewMemorySender()
+	default:
+		return nil, fmt.Errorf("unknown otp sender provider: %q", cfg.Provider)
+	}
+
+	return WithRetry(sender, cfg.MaxAttempts, cfg.RetryDelay, logger), nil
+}
+
+type retryingSender struct {
+	next        OTPSender
+	maxAttempts int
+	delay       time.Duration
+	logger      *slog.Logger
+}
+
+// WithRetry retries failed deliveries up to maxAttempts times, doubling the delay
+// between attempts. Permanent failures are returned immediately.
+func WithRetry(next OTPSender, maxAttempts int, delay time.Duration, logger *slog.Logger) OTPSender {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &retryingSender{
+		next:        next,
+		maxAttempts: maxAttempts,
+		delay:       delay,
+		logger:      logger,
+	}
+}
+
+func (s *retryingSender) Send(ctx context.Context, phone, code string) error {
+	logger := s.logger.With("method", "Send", "phone", phone)
+
+	var err error
+	delay := s.delay
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		err = s.next.Send(ctx, phone, code)
+		if err == nil {
+			return nil
+		}
+
+		var permErr *permanentError
+		if errors.As(err, &permErr) {
+			logger.Warn("otp delivery rejected", "attempt", attempt, "error", err.Error())
+			return err
+		}
+		logger.Warn("otp delivery failed", "attempt", attempt, "error", err.Error())
+
+		if attempt == s.maxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+
+	return fmt.Errorf("otp delivery failed after %d attempts: %w", s.maxAttempts, err)
+}
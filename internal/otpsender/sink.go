@@ -0,0 +1,72 @@
+package otpsender
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileSender appends codes to a local file. Intended for development only.
+type FileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSender(path string) *FileSender {
+	return &FileSender{path: path}
+}
+
+func (s *FileSender) Send(ctx context.Context, phone, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return permanent(err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s phone=%s code=%s\n", time.Now().Format(time.RFC3339), phone, code)
+	return err
+}
+
+// MemorySender keeps the last code sent to every phone. Intended for tests.
+type MemorySender struct {
+	mu    sync.RWMutex
+	codes map[string]string
+	err   error
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{codes: make(map[string]string)}
+}
+
+func (s *MemorySender) Send(ctx context.Context, phone, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.codes[phone] = code
+	return nil
+}
+
+// LastCode returns the most recent code delivered to phone.
+func (s *MemorySender) LastCode(phone string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	code, ok := s.codes[phone]
+	return code, ok
+}
+
+// FailWith makes every subsequent Send return err. Pass nil to restore delivery.
+func (s *MemorySender) FailWith(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.err = err
+}
@@ -0,0 +1,64 @@
+package otpsender
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TelegramSender delivers codes through a Telegram-style verification gateway
+// that addresses users by phone number.
+type TelegramSender struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+type telegramResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+func NewTelegramSender(baseURL, token string, timeout time.Duration) *TelegramSender {
+	return &TelegramSender{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *TelegramSender) Send(ctx context.Context, phone, code string) error {
+	form := url.Values{}
+	form.Set("phone_number", phone)
+	form.Set("code", code)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/sendVerificationMessage", strings.NewReader(form.Encode()))
+	if err != nil {
+		return permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus("telegram gateway", resp.StatusCode); err != nil {
+		return err
+	}
+
+	var result telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode telegram gateway response: %w", err)
+	}
+	if !result.OK {
+		return permanent(fmt.Errorf("telegram gateway rejected message: %s", result.Error))
+	}
+	return nil
+}
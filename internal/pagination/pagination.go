@@ -0,0 +1,143 @@
+// Package pagination provides the cursor-based paging shared by all list endpoints.
+//
+// Lists are ordered newest first by (created_at, id) and paged with keyset cursors.
+// Lists with a custom order (e.g. by likes or search relevance) fall back to cursors that carry an offset.
+// Cursors are opaque to clients and are only valid for the query that produced them.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultLimit = 25
+	MaxLimit     = 50
+)
+
+// Request is a page request as sent by a client.
+type Request struct {
+	Limit     int
+	Cursor    string
+	WithTotal bool
+}
+
+// ParseQuery reads the limit, cursor and include_total query parameters shared by all list endpoints.
+func ParseQuery(query func(key string) string) Request {
+	limit, _ := strconv.Atoi(query("limit"))
+	withTotal, _ := strconv.ParseBool(query("include_total"))
+	return Request{
+		Limit:     limit,
+		Cursor:    query("cursor"),
+		WithTotal: withTotal,
+	}
+}
+
+// Query is a validated page request passed down to repositories.
+type Query struct {
+	Limit     int
+	After     Cursor
+	WithTotal bool
+}
+
+// Cursor points right after the last item of the previous page.
+type Cursor struct {
+	CreatedAt time.Time `json:"c,omitzero"`
+	ID        uuid.UUID `json:"i,omitzero"`
+	Offset    int       `json:"o,omitempty"`
+}
+
+// IsZero reports whether the cursor points to the first page.
+func (c Cursor) IsZero() bool {
+	return c.ID == uuid.Nil && c.Offset == 0
+}
+
+// Page is the response envelope of every list endpoint.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      *int64 `json:"total,omitempty"`
+}
+
+// ClampLimit returns the default limit for non-positive values and caps the rest at MaxLimit.
+func ClampLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	return min(limit, MaxLimit)
+}
+
+// NewQuery clamps the limit and decodes the cursor of req.
+func NewQuery(req Request) (Query, error) {
+	q := Query{Limit: ClampLimit(req.Limit), WithTotal: req.WithTotal}
+	if req.Cursor == "" {
+		return q, nil
+	}
+
+	cursor, err := Decode(req.Cursor)
+	if err != nil {
+		return q, err
+	}
+	q.After = cursor
+	return q, nil
+}
+
+// Encode returns the opaque representation of c.
+func Encode(c Cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decode parses a cursor produced by Encode.
+func Decode(s string) (Cursor, error) {
+	var c Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, apperrors.NewErrNotValid("invalid cursor")
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.Offset < 0 {
+		return Cursor{}, apperrors.NewErrNotValid("invalid cursor")
+	}
+	return c, nil
+}
+
+// NewPage builds a page from rows loaded with a limit of q.Limit+1; the extra row tells that a next page exists.
+// With keyset the next cursor points after the last row, whose created_at and id are returned by key.
+// Otherwise it carries the offset of the next page.
+func NewPage[M any](rows []M, total *int64, q Query, keyset bool, key func(M) (time.Time, uuid.UUID)) Page[M] {
+	if rows == nil {
+		rows = []M{}
+	}
+	page := Page[M]{Items: rows, Total: total}
+	if len(rows) <= q.Limit {
+		return page
+	}
+
+	page.Items = rows[:q.Limit]
+	if keyset {
+		createdAt, id := key(page.Items[q.Limit-1])
+		page.NextCursor = Encode(Cursor{CreatedAt: createdAt, ID: id})
+	} else {
+		page.NextCursor = Encode(Cursor{Offset: q.After.Offset + q.Limit})
+	}
+	return page
+}
+
+// Map converts the items of a page and keeps its cursor and total.
+func Map[M, T any](p Page[M], f func(M) T) Page[T] {
+	items := make([]T, len(p.Items))
+	for i, item := range p.Items {
+		items[i] = f(item)
+	}
+	return WithItems(p, items)
+}
+
+// WithItems replaces the items of a page with their converted counterparts, keeping its cursor and total.
+func WithItems[M, T any](p Page[M], items []T) Page[T] {
+	return Page[T]{Items: items, NextCursor: p.NextCursor, Total: p.Total}
+}
@@ -25,5 +25,20 @@ type AuthRepository interface {
 	CreateRefreshToken(ctx context.Context, token *models.UserRefreshToken) error
 	GetRefreshToken(ctx context.Context, token string) (*models.UserRefreshToken, error)
 	DeleteRefreshToken(ctx context.Context, token string) error
+	// DeleteRefreshTokensByUserID deletes every session and refresh token of the user.
 	DeleteRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error
+
+	// Sessions
+	// CreateSession stores a new session with its first refresh token and drops the expired sessions of the user.
+	CreateSession(ctx context.Context, session *models.UserSession, token *models.UserRefreshToken) error
+	// RotateRefreshToken replaces the live token old with next, remembers old as rotated and saves session,
+	// creating it for tokens that had none. It returns ErrNotFound when old was rotated or deleted concurrently.
+	RotateRefreshToken(ctx context.Context, old, next *models.UserRefreshToken, session *models.UserSession) error
+	GetRotatedRefreshToken(ctx context.Context, token string) (*models.RotatedRefreshToken, error)
+	// ListSessions returns the unexpired sessions of the user, most recently used first.
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]models.UserSession, error)
+	// DeleteSession revokes a session together with its live and rotated refresh tokens.
+	DeleteSession(ctx context.Context, sessionID uuid.UUID) error
+	// DeleteUserSession revokes a session of the given user.
+	DeleteUserSession(ctx context.Context, userID, sessionID uuid.UUID) error
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt" // Added this line
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
@@ -80,7 +81,7 @@ func (r *authRepository) CreateRefreshToken(ctx context.Context, token *models.U
 // GetRefreshToken retrieves a token by its value.
 func (r *authRepository) GetRefreshToken(ctx context.Context, token string) (*models.UserRefreshToken, error) {
 	var refreshToken models.UserRefreshToken
-	if err := r.db.WithContext(ctx).Preload("User").First(&refreshToken, "refresh_token = ?", token).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("User").Preload("Session").First(&refreshToken, "refresh_token = ?", token).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, apperrors.NewErrNotFound("refresh token", token)
 		}
@@ -134,6 +135,9 @@ func (r *authRepository) GetRoleByNameWithTx(ctx context.Context, name string, t
 
 // DeleteRefreshTokensByUserID deletes all refresh tokens for a specific user.
 func (r *authRepository) DeleteRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.UserSession{}).Error; err != nil {
+		return err
+	}
 	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.UserRefreshToken{})
 	if result.Error != nil {
 		fmt.Printf("DeleteRefreshTokensByUserID failed for userID %s: %v\n", userID, result.Error)
@@ -142,3 +146,82 @@ func (r *authRepository) DeleteRefreshTokensByUserID(ctx context.Context, userID
 	fmt.Printf("DeleteRefreshTokensByUserID affected %d rows for userID %s\n", result.RowsAffected, userID)
 	return nil
 }
+
+func (r *authRepository) CreateSession(ctx context.Context, session *models.UserSession, token *models.UserRefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND expires_at <= ?", session.UserID, time.Now()).Delete(&models.UserSession{}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		token.SessionID = &session.ID
+		return tx.Create(token).Error
+	})
+}
+
+func (r *authRepository) RotateRefreshToken(ctx context.Context, old, next *models.UserRefreshToken, session *models.UserSession) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("refresh_token = ?", old.RefreshToken).Delete(&models.UserRefreshToken{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewErrNotFound("refresh token", old.RefreshToken)
+		}
+
+		// Save inserts the session when the old token predates sessions.
+		if err := tx.Omit("User").Save(session).Error; err != nil {
+			return err
+		}
+		rotated := &models.RotatedRefreshToken{
+			RefreshToken: old.RefreshToken,
+			SessionID:    session.ID,
+			RotatedAt:    time.Now(),
+		}
+		if err := tx.Create(rotated).Error; err != nil {
+			return err
+		}
+		next.SessionID = &session.ID
+		return tx.Create(next).Error
+	})
+}
+
+func (r *authRepository) GetRotatedRefreshToken(ctx context.Context, token string) (*models.RotatedRefreshToken, error) {
+	var rotated models.RotatedRefreshToken
+	if err := r.db.WithContext(ctx).First(&rotated, "refresh_token = ?", token).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, apperrors.NewErrNotFound("rotated refresh token", token)
+		}
+		return nil, err
+	}
+	return &rotated, nil
+}
+
+func (r *authRepository) ListSessions(ctx context.Context, userID uuid.UUID) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC, id").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *authRepository) DeleteSession(ctx context.Context, sessionID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.UserSession{}, "id = ?", sessionID).Error
+}
+
+func (r *authRepository) DeleteUserSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", sessionID, userID).Delete(&models.UserSession{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrNotFound("session", sessionID.String())
+	}
+	return nil
+}
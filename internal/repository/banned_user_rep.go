@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+type BannedUserRepository interface {
+	Create(ctx context.Context, ban *models.BannedUser) (*models.BannedUser, error)
+	// GetActive returns the ban of the user in the coffee shop that has not expired yet.
+	GetActive(ctx context.Context, userID, coffeeShopID uuid.UUID) (*models.BannedUser, error)
+	IsBanned(ctx context.Context, userID, coffeeShopID uuid.UUID) (bool, error)
+	ListActiveByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.BannedUser], error)
+	// Delete removes every ban of the user in the coffee shop, including expired ones.
+	Delete(ctx context.Context, userID, coffeeShopID uuid.UUID) error
+}
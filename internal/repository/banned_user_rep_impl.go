@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const activeBanCondition = "expires_at IS NULL OR expires_at > NOW()"
+
+type bannedUserRepository struct {
+	db *gorm.DB
+}
+
+func NewBannedUserRepository(db *gorm.DB) BannedUserRepository {
+	return &bannedUserRepository{db: db}
+}
+
+func (r *bannedUserRepository) Create(ctx context.Context, ban *models.BannedUser) (*models.BannedUser, error) {
+	if err := r.db.WithContext(ctx).Create(ban).Error; err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+func (r *bannedUserRepository) GetActive(ctx context.Context, userID, coffeeShopID uuid.UUID) (*models.BannedUser, error) {
+	var ban models.BannedUser
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND coffee_shop_id = ?", userID, coffeeShopID).
+		Where(activeBanCondition).
+		First(&ban).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("ban", "user ID: "+userID.String()+" coffee shop ID: "+coffeeShopID.String())
+		}
+		return nil, err
+	}
+	return &ban, nil
+}
+
+func (r *bannedUserRepository) IsBanned(ctx context.Context, userID, coffeeShopID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.BannedUser{}).
+		Where("user_id = ? AND coffee_shop_id = ?", userID, coffeeShopID).
+		Where(activeBanCondition).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *bannedUserRepository) ListActiveByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.BannedUser], error) {
+	query := r.db.WithContext(ctx).
+		Where("coffee_shop_id = ?", coffeeShopID).
+		Where(activeBanCondition)
+	return findPage(query, "banned_user", page, nil, func(b models.BannedUser) (time.Time, uuid.UUID) {
+		return b.CreatedAt, b.ID
+	}, "User")
+}
+
+func (r *bannedUserRepository) Delete(ctx context.Context, userID, coffeeShopID uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND coffee_shop_id = ?", userID, coffeeShopID).
+		Delete(&models.BannedUser{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrNotFound("ban", "user ID: "+userID.String()+" coffee shop ID: "+coffeeShopID.String())
+	}
+	return nil
+}
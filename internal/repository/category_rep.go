@@ -3,14 +3,19 @@ package repository
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
 )
 
 type CategoryRepository interface {
 	Create(ctx context.Context, category *models.Category) (uuid.UUID, error)
 	Update(ctx context.Context, category *models.Category) error
 	Delete(ctx context.Context, categoryID, coffeeShopID uuid.UUID) error
+	// GetByID and GetByCoffeeShop include the categories of the organization of the coffee shop.
 	GetByID(ctx context.Context, categoryID, coffeeShopID uuid.UUID) (models.Category, error)
-	GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page, pageSize int) ([]models.Category, int, error)
+	GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.Category], error)
+	UpdateInOrganization(ctx context.Context, category *models.Category) error
+	DeleteInOrganization(ctx context.Context, categoryID, organizationID uuid.UUID) error
+	GetByOrganization(ctx context.Context, organizationID uuid.UUID, page pagination.Query) (pagination.Page[models.Category], error)
 }
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -34,30 +36,37 @@ func (r *CategoryRepositoryImpl) Delete(ctx context.Context, categoryID, coffeeS
 
 func (r *CategoryRepositoryImpl) GetByID(ctx context.Context, categoryID, coffeeShopID uuid.UUID) (models.Category, error) {
 	var category models.Category
-	err := r.db.WithContext(ctx).First(&category, "id = ? AND coffee_shop_id = ? AND is_deleted = false", categoryID, coffeeShopID).Error
+	err := r.db.WithContext(ctx).Scopes(availableInShop(coffeeShopID)).
+		First(&category, "id = ? AND is_deleted = false", categoryID).Error
 	return category, err
 }
 
-func (r *CategoryRepositoryImpl) GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page, pageSize int) ([]models.Category, int, error) {
-	var categories []models.Category
-	var total int64
+func (r *CategoryRepositoryImpl) GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.Category], error) {
+	query := r.db.WithContext(ctx).Scopes(availableInShop(coffeeShopID)).Where("is_deleted = false")
+	return findPage(query, "category", page, nil, categoryKey)
+}
 
-	offset := (page - 1) * pageSize
+func (r *CategoryRepositoryImpl) UpdateInOrganization(ctx context.Context, category *models.Category) error {
+	return r.db.WithContext(ctx).Model(&models.Category{}).Where("id = ? AND organization_id = ?", category.ID, category.OrganizationID).Updates(category).Error
+}
 
-	if err := r.db.WithContext(ctx).Model(&models.Category{}).
-		Where("coffee_shop_id = ? AND is_deleted = false", coffeeShopID).
-		Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
+func (r *CategoryRepositoryImpl) DeleteInOrganization(ctx context.Context, categoryID, organizationID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.Category{}).Where("id = ? AND organization_id = ?", categoryID, organizationID).Update("is_deleted", true).Error
+}
 
-	if err := r.db.WithContext(ctx).
-		Where("coffee_shop_id = ? AND is_deleted = false", coffeeShopID).
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(pageSize).
-		Find(&categories).Error; err != nil {
-		return nil, 0, err
-	}
+func (r *CategoryRepositoryImpl) GetByOrganization(ctx context.Context, organizationID uuid.UUID, page pagination.Query) (pagination.Page[models.Category], error) {
+	query := r.db.WithContext(ctx).Where("organization_id = ? AND is_deleted = false", organizationID)
+	return findPage(query, "category", page, nil, categoryKey)
+}
 
-	return categories, int(total), nil
+func categoryKey(category models.Category) (time.Time, uuid.UUID) {
+	return category.CreatedAt, category.ID
+}
+
+// availableInShop matches rows of a table with coffee_shop_id and organization_id columns that belong
+// to the coffee shop itself or are shared by its organization.
+func availableInShop(shopID uuid.UUID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(coffee_shop_id = ? OR organization_id = (SELECT organization_id FROM coffee_shop WHERE id = ?))", shopID, shopID)
+	}
 }
@@ -2,18 +2,35 @@ package repository
 
 import (
 	"context"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/geo"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// CoffeeShopQuery filters the list of coffee shops.
+type CoffeeShopQuery struct {
+	// Name is matched case-insensitively anywhere in the name of the shop.
+	Name string
+	// Near limits the list to located shops within RadiusMeters of the point and orders them by distance.
+	// Without it shops are listed newest first.
+	Near         *geo.Point
+	RadiusMeters float64
+	Page         pagination.Query
+}
+
 type CoffeeShopRep interface {
 	CreateCoffeeShop(ctx context.Context, shop *models.CoffeeShop) (*models.CoffeeShop, error)
 	CreateCoffeeShopWithTx(ctx context.Context, shop *models.CoffeeShop, tx *gorm.DB) (*models.CoffeeShop, error)
+	// UpdateCoffeeShop saves the fields of the shop without its opening hours.
 	UpdateCoffeeShop(ctx context.Context, shop *models.CoffeeShop) error
+	// SetOpeningHours replaces the opening hours of the shop.
+	SetOpeningHours(ctx context.Context, shopID uuid.UUID, hours []models.OpeningHours) error
 	DeleteCoffeeShop(ctx context.Context, ID uuid.UUID) error
 	GetCoffeeShop(ctx context.Context, ID uuid.UUID) (*models.CoffeeShop, error)
-	GetAllCoffeeShops(ctx context.Context, limit, offset int) ([]models.CoffeeShop, error)
+	GetAllCoffeeShops(ctx context.Context, q CoffeeShopQuery) (pagination.Page[models.CoffeeShop], error)
 	IsCoffeeShopExist(ctx context.Context, ID uuid.UUID) (bool, error)
 	IsWorker(ctx context.Context, userID, shopID uuid.UUID) (bool, error)
 }
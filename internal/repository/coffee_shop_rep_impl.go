@@ -2,15 +2,29 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/geo"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// coffeeShopDistance is the haversine distance in meters from the point @lat, @lon to the shop.
+const coffeeShopDistance = `(2 * @earth_radius * asin(least(1, sqrt(
+	power(sin(radians(coffee_shop.latitude - @lat) / 2), 2) +
+	cos(radians(@lat)) * cos(radians(coffee_shop.latitude)) * power(sin(radians(coffee_shop.longitude - @lon) / 2), 2)))))`
+
+// likeEscaper escapes the wildcards of LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type CoffeeShopRepImpl struct {
 	db *gorm.DB
 }
@@ -34,7 +48,7 @@ func (r *CoffeeShopRepImpl) CreateCoffeeShopWithTx(ctx context.Context, shop *mo
 }
 
 func (r *CoffeeShopRepImpl) UpdateCoffeeShop(ctx context.Context, shop *models.CoffeeShop) error {
-	result := r.db.WithContext(ctx).Save(shop)
+	result := r.db.WithContext(ctx).Omit("OpeningHours").Save(shop)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -44,6 +58,21 @@ func (r *CoffeeShopRepImpl) UpdateCoffeeShop(ctx context.Context, shop *models.C
 	return nil
 }
 
+func (r *CoffeeShopRepImpl) SetOpeningHours(ctx context.Context, shopID uuid.UUID, hours []models.OpeningHours) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.OpeningHours{}, "coffee_shop_id = ?", shopID).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		for i := range hours {
+			hours[i].CoffeeShopID = shopID
+		}
+		return tx.Create(&hours).Error
+	})
+}
+
 func (r *CoffeeShopRepImpl) DeleteCoffeeShop(ctx context.Context, ID uuid.UUID) error {
 	result := r.db.WithContext(ctx).Delete(&models.CoffeeShop{}, ID)
 	if result.Error != nil {
@@ -57,7 +86,7 @@ func (r *CoffeeShopRepImpl) DeleteCoffeeShop(ctx context.Context, ID uuid.UUID)
 
 func (r *CoffeeShopRepImpl) GetCoffeeShop(ctx context.Context, ID uuid.UUID) (*models.CoffeeShop, error) {
 	var shop models.CoffeeShop
-	if err := r.db.WithContext(ctx).First(&shop, "id = ?", ID).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("OpeningHours").First(&shop, "id = ?", ID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, apperrors.NewErrNotFound("coffee_shop", ID.String())
 		}
@@ -66,12 +95,31 @@ func (r *CoffeeShopRepImpl) GetCoffeeShop(ctx context.Context, ID uuid.UUID) (*m
 	return &shop, nil
 }
 
-func (r *CoffeeShopRepImpl) GetAllCoffeeShops(ctx context.Context, limit, offset int) ([]models.CoffeeShop, error) {
-	var shops []models.CoffeeShop
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&shops).Error; err != nil {
-		return nil, err
+func (r *CoffeeShopRepImpl) GetAllCoffeeShops(ctx context.Context, q CoffeeShopQuery) (pagination.Page[models.CoffeeShop], error) {
+	query := r.db.WithContext(ctx)
+	if q.Name != "" {
+		query = query.Where("coffee_shop.name ILIKE ?", "%"+likeEscaper.Replace(q.Name)+"%")
 	}
-	return shops, nil
+
+	var order func(*gorm.DB) *gorm.DB
+	if q.Near != nil {
+		args := []any{
+			sql.Named("lat", q.Near.Latitude),
+			sql.Named("lon", q.Near.Longitude),
+			sql.Named("earth_radius", geo.EarthRadiusMeters),
+		}
+		// The bounding box lets the location index discard far away shops before the exact distance is computed.
+		box := geo.BoundingBox(*q.Near, q.RadiusMeters)
+		query = query.
+			Where("coffee_shop.latitude BETWEEN ? AND ?", box.MinLatitude, box.MaxLatitude).
+			Where("coffee_shop.longitude BETWEEN ? AND ?", box.MinLongitude, box.MaxLongitude).
+			Where(coffeeShopDistance+" <= @radius", append(args, sql.Named("radius", q.RadiusMeters))...)
+		order = func(query *gorm.DB) *gorm.DB {
+			return query.Order(clause.OrderBy{Expression: clause.NamedExpr{SQL: coffeeShopDistance, Vars: args}})
+		}
+	}
+
+	return findPage(query, "coffee_shop", q.Page, order, coffeeShopKey, "OpeningHours")
 }
 
 func (r *CoffeeShopRepImpl) IsCoffeeShopExist(ctx context.Context, ID uuid.UUID) (bool, error) {
@@ -93,3 +141,7 @@ func (r *CoffeeShopRepImpl) IsWorker(ctx context.Context, userID uuid.UUID, shop
 
 	return count > 0, nil
 }
+
+func coffeeShopKey(shop models.CoffeeShop) (time.Time, uuid.UUID) {
+	return shop.CreatedAt, shop.ID
+}
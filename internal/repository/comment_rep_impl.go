@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -26,22 +28,13 @@ func (r *commentRepository) Create(ctx context.Context, comment *models.IdeaComm
 	return comment, nil
 }
 
-func (r *commentRepository) GetByIdeaID(ctx context.Context, ideaID uuid.UUID, limit, offset int) ([]models.IdeaComment, error) {
-	var comments []models.IdeaComment
-	query := r.db.WithContext(ctx).
-		Preload("Creator"). // Preload the Creator user data
-		Where("idea_id = ? AND is_deleted = ?", ideaID, false).
-		Order("created_at DESC") // Order by creation time, newest first
-
-	if limit > 0 {
-		query = query.Limit(limit)
-	}
-	if offset >= 0 {
-		query = query.Offset(offset)
-	}
-
-	if err := query.Find(&comments).Error; err != nil {
-		return nil, fmt.Errorf("failed to get comments by idea ID: %w", err)
+func (r *commentRepository) GetByIdeaID(ctx context.Context, ideaID uuid.UUID, page pagination.Query) (pagination.Page[models.IdeaComment], error) {
+	query := r.db.WithContext(ctx).Where("idea_id = ? AND is_deleted = ?", ideaID, false)
+	comments, err := findPage(query, "idea_comment", page, nil, func(comment models.IdeaComment) (time.Time, uuid.UUID) {
+		return comment.CreatedAt, comment.ID
+	}, "Creator")
+	if err != nil {
+		return comments, fmt.Errorf("failed to get comments by idea ID: %w", err)
 	}
 	return comments, nil
 }
@@ -2,15 +2,42 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
+// IdeaQuery describes a filtered idea search. Zero-valued fields do not filter.
+type IdeaQuery struct {
+	CoffeeShopID *uuid.UUID
+	// OrganizationID limits the search to ideas of all coffee shops of the organization.
+	OrganizationID *uuid.UUID
+	// Text is matched with PostgreSQL full-text search against the title and description
+	// using both Russian and English configurations.
+	Text        string
+	StatusIDs   []uuid.UUID
+	CategoryIDs []uuid.UUID
+	CreatorID   *uuid.UUID
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	HasImage    *bool
+	// Sort uses the same format as the idea lists. Text searches without Sort are ordered by relevance.
+	Sort string
+	Page pagination.Query
+}
+
 type IdeaRepository interface {
+	// CreateIdea stores the idea and, if it has a status, the first entry of its status history.
 	CreateIdea(ctx context.Context, idea *models.Idea) (*models.Idea, error)
 	GetIdea(ctx context.Context, ideaID uuid.UUID) (*models.Idea, error)
-	GetAllIdeasByShop(ctx context.Context, shopID uuid.UUID, limit, offset int, sort string) ([]models.Idea, error)
-	GetAllIdeasByUser(ctx context.Context, userID uuid.UUID, limit, offset int, sort string) ([]models.Idea, error)
-	UpdateIdea(ctx context.Context, idea *models.Idea) error
+	GetAllIdeasByShop(ctx context.Context, shopID uuid.UUID, page pagination.Query, sort string) (pagination.Page[models.Idea], error)
+	GetAllIdeasByUser(ctx context.Context, userID uuid.UUID, page pagination.Query, sort string) (pagination.Page[models.Idea], error)
+	SearchIdeas(ctx context.Context, q IdeaQuery) (pagination.Page[models.Idea], error)
+	// UpdateIdea saves the idea. A non-nil statusChange is recorded in the same transaction.
+	UpdateIdea(ctx context.Context, idea *models.Idea, statusChange *models.IdeaStatusHistory) error
 	DeleteIdea(ctx context.Context, IdeaID uuid.UUID) error
+	// GetStatusHistory returns the status changes of the idea, oldest first.
+	GetStatusHistory(ctx context.Context, ideaID uuid.UUID) ([]models.IdeaStatusHistory, error)
 }
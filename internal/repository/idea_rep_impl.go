@@ -2,15 +2,32 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"strings"
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// notHiddenByBanCondition excludes ideas whose creator is banned in the shop with hide_ideas.
+const notHiddenByBanCondition = `NOT EXISTS (
+	SELECT 1 FROM banned_user b
+	WHERE b.user_id = idea.creator_id AND b.coffee_shop_id = idea.coffee_shop_id
+		AND b.hide_ideas AND (b.expires_at IS NULL OR b.expires_at > NOW())
+)`
+
+// IdeaSearchVector is the document searched by SearchIdeas. The idx_idea_search migration builds a GIN index over the same expression.
+const IdeaSearchVector = `(to_tsvector('russian', coalesce(idea.title, '') || ' ' || coalesce(idea.description, '')) || ` +
+	`to_tsvector('english', coalesce(idea.title, '') || ' ' || coalesce(idea.description, '')))`
+
+const ideaSearchQuery = `(websearch_to_tsquery('russian', @text) || websearch_to_tsquery('english', @text))`
+
 type ideaRepository struct {
 	db *gorm.DB
 }
@@ -20,7 +37,20 @@ func NewIdeaRepository(db *gorm.DB) IdeaRepository {
 }
 
 func (r *ideaRepository) CreateIdea(ctx context.Context, idea *models.Idea) (*models.Idea, error) {
-	if err := r.db.WithContext(ctx).Create(idea).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(idea).Error; err != nil {
+			return err
+		}
+		if idea.StatusID == nil {
+			return nil
+		}
+		return tx.Create(&models.IdeaStatusHistory{
+			IdeaID:     &idea.ID,
+			ToStatusID: idea.StatusID,
+			ActorID:    idea.CreatorID,
+		}).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	// Reload the idea to get all associations
@@ -41,28 +71,86 @@ func (r *ideaRepository) GetIdea(ctx context.Context, ideaID uuid.UUID) (*models
 	return &idea, nil
 }
 
-func (r *ideaRepository) GetAllIdeasByShop(ctx context.Context, shopID uuid.UUID, limit, offset int, sort string) ([]models.Idea, error) {
-	var ideas []models.Idea
-	query := r.db.WithContext(ctx).Model(&models.Idea{}).Where("coffee_shop_id = ?", shopID).Preload("Status")
-
-	query = applyIdeaSorting(query, sort)
+func (r *ideaRepository) GetAllIdeasByShop(ctx context.Context, shopID uuid.UUID, page pagination.Query, sort string) (pagination.Page[models.Idea], error) {
+	query := r.db.WithContext(ctx).Where("idea.coffee_shop_id = ?", shopID).Where(notHiddenByBanCondition)
+	return findPage(query, "idea", page, ideaOrder(sort), ideaKey, "Status")
+}
 
-	err := query.Limit(limit).Offset(offset).Find(&ideas).Error
-	return ideas, err
+func (r *ideaRepository) GetAllIdeasByUser(ctx context.Context, userID uuid.UUID, page pagination.Query, sort string) (pagination.Page[models.Idea], error) {
+	query := r.db.WithContext(ctx).Where("idea.creator_id = ?", userID)
+	return findPage(query, "idea", page, ideaOrder(sort), ideaKey, "Status")
 }
 
-func (r *ideaRepository) GetAllIdeasByUser(ctx context.Context, userID uuid.UUID, limit, offset int, sort string) ([]models.Idea, error) {
-	var ideas []models.Idea
-	query := r.db.WithContext(ctx).Model(&models.Idea{}).Where("creator_id = ?", userID).Preload("Status")
+func (r *ideaRepository) SearchIdeas(ctx context.Context, q IdeaQuery) (pagination.Page[models.Idea], error) {
+	query := r.db.WithContext(ctx)
+
+	if q.CoffeeShopID != nil {
+		query = query.Where("idea.coffee_shop_id = ?", *q.CoffeeShopID).Where(notHiddenByBanCondition)
+	}
+	if q.OrganizationID != nil {
+		query = query.Where("idea.coffee_shop_id IN (SELECT id FROM coffee_shop WHERE organization_id = ?)", *q.OrganizationID).
+			Where(notHiddenByBanCondition)
+	}
+	if q.Text != "" {
+		query = query.Where(IdeaSearchVector+" @@ "+ideaSearchQuery, sql.Named("text", q.Text))
+	}
+	if len(q.StatusIDs) > 0 {
+		query = query.Where("idea.status_id IN ?", q.StatusIDs)
+	}
+	if len(q.CategoryIDs) > 0 {
+		query = query.Where("idea.category_id IN ?", q.CategoryIDs)
+	}
+	if q.CreatorID != nil {
+		query = query.Where("idea.creator_id = ?", *q.CreatorID)
+	}
+	if q.CreatedFrom != nil {
+		query = query.Where("idea.created_at >= ?", *q.CreatedFrom)
+	}
+	if q.CreatedTo != nil {
+		query = query.Where("idea.created_at <= ?", *q.CreatedTo)
+	}
+	if q.HasImage != nil {
+		if *q.HasImage {
+			query = query.Where("idea.image_url IS NOT NULL AND idea.image_url <> ''")
+		} else {
+			query = query.Where("(idea.image_url IS NULL OR idea.image_url = '')")
+		}
+	}
+
+	order := ideaOrder(q.Sort)
+	if q.Text != "" && q.Sort == "" {
+		order = func(query *gorm.DB) *gorm.DB {
+			return query.Order(clause.OrderBy{Expression: clause.NamedExpr{
+				SQL:  "ts_rank(" + IdeaSearchVector + ", " + ideaSearchQuery + ") DESC, idea.created_at DESC",
+				Vars: []any{sql.Named("text", q.Text)},
+			}})
+		}
+	}
 
-	query = applyIdeaSorting(query, sort)
+	return findPage(query, "idea", q.Page, order, ideaKey, "Status")
+}
 
-	err := query.Limit(limit).Offset(offset).Find(&ideas).Error
-	return ideas, err
+func (r *ideaRepository) UpdateIdea(ctx context.Context, idea *models.Idea, statusChange *models.IdeaStatusHistory) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(idea).Error; err != nil {
+			return err
+		}
+		if statusChange == nil {
+			return nil
+		}
+		statusChange.IdeaID = &idea.ID
+		return tx.Create(statusChange).Error
+	})
 }
 
-func (r *ideaRepository) UpdateIdea(ctx context.Context, idea *models.Idea) error {
-	return r.db.WithContext(ctx).Save(idea).Error
+func (r *ideaRepository) GetStatusHistory(ctx context.Context, ideaID uuid.UUID) ([]models.IdeaStatusHistory, error) {
+	var history []models.IdeaStatusHistory
+	err := r.db.WithContext(ctx).
+		Preload("FromStatus").Preload("ToStatus").Preload("Actor").
+		Where("idea_id = ?", ideaID).
+		Order("created_at ASC").
+		Find(&history).Error
+	return history, err
 }
 
 func (r *ideaRepository) DeleteIdea(ctx context.Context, ideaID uuid.UUID) error {
@@ -76,31 +164,35 @@ func (r *ideaRepository) DeleteIdea(ctx context.Context, ideaID uuid.UUID) error
 	return nil
 }
 
-func applyIdeaSorting(query *gorm.DB, sort string) *gorm.DB {
+func ideaKey(idea models.Idea) (time.Time, uuid.UUID) {
+	return idea.CreatedAt, idea.ID
+}
+
+// ideaOrder returns the ordering requested by sort, or nil for the default newest-first keyset order.
+func ideaOrder(sort string) func(*gorm.DB) *gorm.DB {
 	if sort == "" {
-		// Default sort order
-		return query.Order("created_at DESC")
+		return nil
 	}
 
-	sorts := strings.SplitSeq(sort, ",")
-	for s := range sorts {
-		direction := "ASC"
-		if strings.HasPrefix(s, "-") {
-			direction = "DESC"
-			s = s[1:]
-		}
-
-		switch s {
-		case "status":
-			query = query.Order("status_id " + direction)
-		case "created_at":
-			query = query.Order("created_at " + direction)
-		case "likes":
-			query = query.Joins("LEFT JOIN idea_likes ON idea_likes.idea_id = ideas.id").
-				Group("ideas.id").
-				Order("COUNT(idea_likes.id) " + direction)
+	return func(query *gorm.DB) *gorm.DB {
+		for s := range strings.SplitSeq(sort, ",") {
+			direction := "ASC"
+			if strings.HasPrefix(s, "-") {
+				direction = "DESC"
+				s = s[1:]
+			}
+
+			switch s {
+			case "status":
+				query = query.Order("idea.status_id " + direction)
+			case "created_at":
+				query = query.Order("idea.created_at " + direction)
+			case "likes":
+				// A correlated subquery keeps the idea columns free of GROUP BY and works with any filters.
+				query = query.Order("(SELECT COUNT(*) FROM idea_like WHERE idea_like.idea_id = idea.id) " + direction)
+			}
 		}
+		return query
 	}
-	return query
 }
 
@@ -8,10 +8,24 @@ import (
 )
 
 type IdeaStatusRepository interface {
+	// Create stores the status. If it is initial, the previous initial status of the shop stops being initial.
 	Create(ctx context.Context, status *models.IdeaStatus) (uuid.UUID, error)
+	// Update saves the status. If it is initial, the previous initial status of the shop stops being initial.
 	Update(ctx context.Context, status *models.IdeaStatus) error
+	// Delete marks the status as deleted and removes all transitions from or to it.
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (models.IdeaStatus, error)
+	// GetAll returns legacy statuses that do not belong to any coffee shop.
 	GetAll(ctx context.Context) ([]models.IdeaStatus, error)
-	GetByTitle(ctx context.Context, title string) (models.IdeaStatus, error)
+	GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID) ([]models.IdeaStatus, error)
+	GetByTitle(ctx context.Context, coffeeShopID uuid.UUID, title string) (models.IdeaStatus, error)
+	GetInitial(ctx context.Context, coffeeShopID uuid.UUID) (models.IdeaStatus, error)
+	// EnsureDefaultWorkflow creates the default workflow for a coffee shop without statuses and returns its initial status.
+	// If the shop already has statuses, its initial status is returned.
+	EnsureDefaultWorkflow(ctx context.Context, coffeeShopID uuid.UUID) (models.IdeaStatus, error)
+
+	CreateTransition(ctx context.Context, transition *models.IdeaStatusTransition) (*models.IdeaStatusTransition, error)
+	DeleteTransition(ctx context.Context, id, coffeeShopID uuid.UUID) error
+	GetTransitionsByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID) ([]models.IdeaStatusTransition, error)
+	IsTransitionAllowed(ctx context.Context, fromStatusID, toStatusID uuid.UUID) (bool, error)
 }
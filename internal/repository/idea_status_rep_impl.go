@@ -2,11 +2,26 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultStatusTitles and defaultStatusTransitions describe the workflow a coffee shop gets
+// until its admins configure their own. The first status is initial.
+var (
+	defaultStatusTitles      = []string{"Создана", "В работе", "Реализована", "Отклонена"}
+	defaultTerminalStatuses  = map[string]bool{"Реализована": true, "Отклонена": true}
+	defaultStatusTransitions = [][2]string{
+		{"Создана", "В работе"},
+		{"Создана", "Отклонена"},
+		{"В работе", "Реализована"},
+		{"В работе", "Отклонена"},
+	}
 )
 
 type IdeaStatusRepositoryImpl struct {
@@ -18,25 +33,48 @@ func NewIdeaStatusRepository(db *gorm.DB) IdeaStatusRepository {
 }
 
 func (r *IdeaStatusRepositoryImpl) Create(ctx context.Context, status *models.IdeaStatus) (uuid.UUID, error) {
-	if err := r.db.WithContext(ctx).Create(status).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(status).Error; err != nil {
+			return err
+		}
+		return resetInitialStatus(tx, status)
+	})
+	if err != nil {
 		return uuid.Nil, err
 	}
 	return status.ID, nil
 }
 
 func (r *IdeaStatusRepositoryImpl) Update(ctx context.Context, status *models.IdeaStatus) error {
-	return r.db.WithContext(ctx).Save(status).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(status).Error; err != nil {
+			return err
+		}
+		return resetInitialStatus(tx, status)
+	})
 }
 
-func (r *IdeaStatusRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Model(&models.IdeaStatus{}).Where("id = ?", id).Update("is_deleted", true)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return apperrors.NewErrNotFound("idea status", id.String())
+// resetInitialStatus keeps a single initial status per coffee shop.
+func resetInitialStatus(tx *gorm.DB, status *models.IdeaStatus) error {
+	if !status.IsInitial || status.CoffeeShopID == nil {
+		return nil
 	}
-	return nil
+	return tx.Model(&models.IdeaStatus{}).
+		Where("coffee_shop_id = ? AND id <> ? AND is_initial = true", status.CoffeeShopID, status.ID).
+		Update("is_initial", false).Error
+}
+
+func (r *IdeaStatusRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.IdeaStatus{}).Where("id = ? AND is_deleted = false", id).Update("is_deleted", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewErrNotFound("idea status", id.String())
+		}
+		return tx.Where("from_status_id = ? OR to_status_id = ?", id, id).Delete(&models.IdeaStatusTransition{}).Error
+	})
 }
 
 func (r *IdeaStatusRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (models.IdeaStatus, error) {
@@ -53,13 +91,22 @@ func (r *IdeaStatusRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (m
 
 func (r *IdeaStatusRepositoryImpl) GetAll(ctx context.Context) ([]models.IdeaStatus, error) {
 	var statuses []models.IdeaStatus
-	err := r.db.WithContext(ctx).Where("is_deleted = false").Find(&statuses).Error
+	err := r.db.WithContext(ctx).Where("coffee_shop_id IS NULL AND is_deleted = false").Find(&statuses).Error
+	return statuses, err
+}
+
+func (r *IdeaStatusRepositoryImpl) GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID) ([]models.IdeaStatus, error) {
+	var statuses []models.IdeaStatus
+	err := r.db.WithContext(ctx).
+		Where("coffee_shop_id = ? AND is_deleted = false", coffeeShopID).
+		Order("created_at ASC").
+		Find(&statuses).Error
 	return statuses, err
 }
 
-func (r *IdeaStatusRepositoryImpl) GetByTitle(ctx context.Context, title string) (models.IdeaStatus, error) {
+func (r *IdeaStatusRepositoryImpl) GetByTitle(ctx context.Context, coffeeShopID uuid.UUID, title string) (models.IdeaStatus, error) {
 	var status models.IdeaStatus
-	err := r.db.WithContext(ctx).First(&status, "title = ? AND is_deleted = false", title).Error
+	err := r.db.WithContext(ctx).First(&status, "coffee_shop_id = ? AND title = ? AND is_deleted = false", coffeeShopID, title).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return status, apperrors.NewErrNotFound("idea status", title)
@@ -67,4 +114,113 @@ func (r *IdeaStatusRepositoryImpl) GetByTitle(ctx context.Context, title string)
 		return status, err
 	}
 	return status, err
-}
\ No newline at end of file
+}
+
+func (r *IdeaStatusRepositoryImpl) GetInitial(ctx context.Context, coffeeShopID uuid.UUID) (models.IdeaStatus, error) {
+	return getInitialStatus(r.db.WithContext(ctx), coffeeShopID)
+}
+
+func getInitialStatus(db *gorm.DB, coffeeShopID uuid.UUID) (models.IdeaStatus, error) {
+	var status models.IdeaStatus
+	err := db.First(&status, "coffee_shop_id = ? AND is_initial = true AND is_deleted = false", coffeeShopID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return status, apperrors.NewErrNotFound("initial idea status", "coffee shop ID: "+coffeeShopID.String())
+		}
+		return status, err
+	}
+	return status, nil
+}
+
+func (r *IdeaStatusRepositoryImpl) EnsureDefaultWorkflow(ctx context.Context, coffeeShopID uuid.UUID) (models.IdeaStatus, error) {
+	var initial models.IdeaStatus
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the coffee shop so concurrent callers do not create two workflows.
+		var shop models.CoffeeShop
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&shop, "id = ?", coffeeShopID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.NewErrNotFound("coffee shop", coffeeShopID.String())
+			}
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.IdeaStatus{}).Where("coffee_shop_id = ? AND is_deleted = false", coffeeShopID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			var err error
+			initial, err = getInitialStatus(tx, coffeeShopID)
+			return err
+		}
+
+		byTitle := make(map[string]uuid.UUID, len(defaultStatusTitles))
+		for i, title := range defaultStatusTitles {
+			status := models.IdeaStatus{
+				CoffeeShopID: &coffeeShopID,
+				Title:        title,
+				IsInitial:    i == 0,
+				IsTerminal:   defaultTerminalStatuses[title],
+			}
+			if err := tx.Create(&status).Error; err != nil {
+				return err
+			}
+			byTitle[title] = status.ID
+			if status.IsInitial {
+				initial = status
+			}
+		}
+
+		for _, t := range defaultStatusTransitions {
+			from, to := byTitle[t[0]], byTitle[t[1]]
+			transition := models.IdeaStatusTransition{
+				CoffeeShopID: &coffeeShopID,
+				FromStatusID: &from,
+				ToStatusID:   &to,
+			}
+			if err := tx.Create(&transition).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return initial, err
+}
+
+func (r *IdeaStatusRepositoryImpl) CreateTransition(ctx context.Context, transition *models.IdeaStatusTransition) (*models.IdeaStatusTransition, error) {
+	if err := r.db.WithContext(ctx).Create(transition).Error; err != nil {
+		return nil, err
+	}
+	return transition, nil
+}
+
+func (r *IdeaStatusRepositoryImpl) DeleteTransition(ctx context.Context, id, coffeeShopID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND coffee_shop_id = ?", id, coffeeShopID).Delete(&models.IdeaStatusTransition{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrNotFound("status transition", id.String())
+	}
+	return nil
+}
+
+func (r *IdeaStatusRepositoryImpl) GetTransitionsByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID) ([]models.IdeaStatusTransition, error) {
+	var transitions []models.IdeaStatusTransition
+	err := r.db.WithContext(ctx).
+		Where("coffee_shop_id = ?", coffeeShopID).
+		Order("created_at ASC").
+		Find(&transitions).Error
+	return transitions, err
+}
+
+func (r *IdeaStatusRepositoryImpl) IsTransitionAllowed(ctx context.Context, fromStatusID, toStatusID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.IdeaStatusTransition{}).
+		Where("from_status_id = ? AND to_status_id = ?", fromStatusID, toStatusID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
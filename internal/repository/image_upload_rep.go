@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/google/uuid"
+)
+
+type ImageUploadRepository interface {
+	Create(ctx context.Context, upload *models.ImageUpload) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.ImageUpload, error)
+	// Finalize records the image made from an unfinalized upload. It returns ErrConflict
+	// when the upload was finalized concurrently.
+	Finalize(ctx context.Context, id uuid.UUID, imageURL string, now time.Time) error
+	// ListExpired returns up to limit uploads that expired before now, oldest first.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]models.ImageUpload, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type imageUploadRepository struct {
+	db *gorm.DB
+}
+
+func NewImageUploadRepository(db *gorm.DB) ImageUploadRepository {
+	return &imageUploadRepository{db: db}
+}
+
+func (r *imageUploadRepository) Create(ctx context.Context, upload *models.ImageUpload) error {
+	return r.db.WithContext(ctx).Create(upload).Error
+}
+
+func (r *imageUploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ImageUpload, error) {
+	var upload models.ImageUpload
+	if err := r.db.WithContext(ctx).First(&upload, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("upload", id.String())
+		}
+		return nil, err
+	}
+	return &upload, nil
+}
+
+func (r *imageUploadRepository) Finalize(ctx context.Context, id uuid.UUID, imageURL string, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.ImageUpload{}).
+		Where("id = ? AND finalized_at IS NULL", id).
+		Updates(map[string]any{"image_url": imageURL, "finalized_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrConflict("upload is already finalized")
+	}
+	return nil
+}
+
+func (r *imageUploadRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]models.ImageUpload, error) {
+	var uploads []models.ImageUpload
+	err := r.db.WithContext(ctx).
+		Where("expires_at <= ?", now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&uploads).Error
+	return uploads, err
+}
+
+func (r *imageUploadRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.ImageUpload{}, "id = ?", id).Error
+}
@@ -11,4 +11,6 @@ type LikeRepository interface {
 	UnlikeIdea(ctx context.Context, userID, ideaID uuid.UUID) error
 	GetLikesCount(ctx context.Context, ideaID uuid.UUID) (int64, error)
 	HasUserLiked(ctx context.Context, userID, ideaID uuid.UUID) (bool, error)
+	GetLikesCounts(ctx context.Context, ideaIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	GetLikedIdeaIDs(ctx context.Context, userID uuid.UUID, ideaIDs []uuid.UUID) (map[uuid.UUID]bool, error)
 }
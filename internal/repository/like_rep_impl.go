@@ -58,3 +58,48 @@ func (r *likeRepository) HasUserLiked(ctx context.Context, userID, ideaID uuid.U
 	return count > 0, err
 
 }
+
+func (r *likeRepository) GetLikesCounts(ctx context.Context, ideaIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(ideaIDs))
+	if len(ideaIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		IdeaID uuid.UUID
+		Count  int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.IdeaLike{}).
+		Select("idea_id, COUNT(*) AS count").
+		Where("idea_id IN ?", ideaIDs).
+		Group("idea_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.IdeaID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *likeRepository) GetLikedIdeaIDs(ctx context.Context, userID uuid.UUID, ideaIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	liked := make(map[uuid.UUID]bool, len(ideaIDs))
+	if len(ideaIDs) == 0 {
+		return liked, nil
+	}
+
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&models.IdeaLike{}).
+		Where("user_id = ? AND idea_id IN ?", userID, ideaIDs).
+		Pluck("idea_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		liked[id] = true
+	}
+	return liked, nil
+}
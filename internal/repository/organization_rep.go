@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+type OrganizationRepository interface {
+	// Create stores the organization and its first member in one transaction.
+	Create(ctx context.Context, org *models.Organization, owner *models.OrganizationMember) (*models.Organization, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+	Update(ctx context.Context, org *models.Organization) error
+	// Delete removes the organization. Its coffee shops stay and become standalone.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ListByMember returns organizations in which the user is a member.
+	ListByMember(ctx context.Context, userID uuid.UUID, page pagination.Query) (pagination.Page[models.Organization], error)
+
+	AddMember(ctx context.Context, member *models.OrganizationMember) (*models.OrganizationMember, error)
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrganizationMember, error)
+	// GetMemberForShop returns the membership of the user in the organization of the coffee shop.
+	GetMemberForShop(ctx context.Context, userID, shopID uuid.UUID) (*models.OrganizationMember, error)
+	ListMembers(ctx context.Context, orgID uuid.UUID, page pagination.Query) (pagination.Page[models.OrganizationMember], error)
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+
+	// AttachCoffeeShop adds a standalone coffee shop to the organization.
+	AttachCoffeeShop(ctx context.Context, orgID, shopID uuid.UUID) error
+	// DetachCoffeeShop makes a coffee shop of the organization standalone again.
+	DetachCoffeeShop(ctx context.Context, orgID, shopID uuid.UUID) error
+	ListCoffeeShops(ctx context.Context, orgID uuid.UUID, page pagination.Query) (pagination.Page[models.CoffeeShop], error)
+	// GetStats returns activity counters of every coffee shop of the organization, ordered by name.
+	GetStats(ctx context.Context, orgID uuid.UUID) ([]models.CoffeeShopStats, error)
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, org *models.Organization, owner *models.OrganizationMember) (*models.Organization, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+		owner.OrganizationID = org.ID
+		return tx.Create(owner).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func (r *organizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	var org models.Organization
+	if err := r.db.WithContext(ctx).First(&org, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("organization", id.String())
+		}
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *organizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	return r.db.WithContext(ctx).Save(org).Error
+}
+
+func (r *organizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.Organization{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrNotFound("organization", id.String())
+	}
+	return nil
+}
+
+func (r *organizationRepository) ListByMember(ctx context.Context, userID uuid.UUID, page pagination.Query) (pagination.Page[models.Organization], error) {
+	query := r.db.WithContext(ctx).
+		Where("organization.id IN (?)", r.db.Model(&models.OrganizationMember{}).Select("organization_id").Where("user_id = ?", userID))
+	return findPage(query, "organization", page, nil, organizationKey)
+}
+
+func (r *organizationRepository) AddMember(ctx context.Context, member *models.OrganizationMember) (*models.OrganizationMember, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", member.OrganizationID, member.UserID).
+		Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, apperrors.NewErrConflict("user is already a member of this organization")
+	}
+	if err := r.db.WithContext(ctx).Create(member).Error; err != nil {
+		return nil, err
+	}
+	return r.GetMember(ctx, member.OrganizationID, member.UserID)
+}
+
+func (r *organizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.db.WithContext(ctx).Preload("Role").Preload("User").
+		First(&member, "organization_id = ? AND user_id = ?", orgID, userID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("organization member", userID.String())
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *organizationRepository) GetMemberForShop(ctx context.Context, userID, shopID uuid.UUID) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := r.db.WithContext(ctx).Preload("Role").
+		Joins("JOIN coffee_shop ON coffee_shop.organization_id = organization_member.organization_id").
+		First(&member, "organization_member.user_id = ? AND coffee_shop.id = ?", userID, shopID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("organization member", userID.String())
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *organizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID, page pagination.Query) (pagination.Page[models.OrganizationMember], error) {
+	query := r.db.WithContext(ctx).Where("organization_id = ?", orgID)
+	return findPage(query, "organization_member", page, nil, func(m models.OrganizationMember) (time.Time, uuid.UUID) {
+		return m.CreatedAt, m.ID
+	}, "Role", "User")
+}
+
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&models.OrganizationMember{}, "organization_id = ? AND user_id = ?", orgID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrNotFound("organization member", userID.String())
+	}
+	return nil
+}
+
+func (r *organizationRepository) AttachCoffeeShop(ctx context.Context, orgID, shopID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&models.CoffeeShop{}).
+		Where("id = ? AND organization_id IS NULL", shopID).
+		Update("organization_id", orgID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrConflict("coffee shop already belongs to an organization")
+	}
+	return nil
+}
+
+func (r *organizationRepository) DetachCoffeeShop(ctx context.Context, orgID, shopID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&models.CoffeeShop{}).
+		Where("id = ? AND organization_id = ?", shopID, orgID).
+		Update("organization_id", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrNotFound("coffee shop", shopID.String())
+	}
+	return nil
+}
+
+func (r *organizationRepository) ListCoffeeShops(ctx context.Context, orgID uuid.UUID, page pagination.Query) (pagination.Page[models.CoffeeShop], error) {
+	query := r.db.WithContext(ctx).Where("organization_id = ?", orgID)
+	return findPage(query, "coffee_shop", page, nil, coffeeShopKey, "OpeningHours")
+}
+
+func (r *organizationRepository) GetStats(ctx context.Context, orgID uuid.UUID) ([]models.CoffeeShopStats, error) {
+	var stats []models.CoffeeShopStats
+	err := r.db.WithContext(ctx).Table("coffee_shop").
+		Select(`coffee_shop.id AS coffee_shop_id, coffee_shop.name,
+			(SELECT COUNT(*) FROM idea WHERE idea.coffee_shop_id = coffee_shop.id) AS ideas,
+			(SELECT COUNT(*) FROM idea_like JOIN idea ON idea.id = idea_like.idea_id WHERE idea.coffee_shop_id = coffee_shop.id) AS likes,
+			(SELECT COUNT(*) FROM idea_comment JOIN idea ON idea.id = idea_comment.idea_id
+				WHERE idea.coffee_shop_id = coffee_shop.id AND NOT idea_comment.is_deleted) AS comments,
+			(SELECT COUNT(*) FROM reward WHERE reward.coffee_shop_id = coffee_shop.id) AS rewards_given,
+			(SELECT COUNT(*) FROM reward WHERE reward.coffee_shop_id = coffee_shop.id AND reward.is_activated) AS rewards_redeemed`).
+		Where("coffee_shop.organization_id = ?", orgID).
+		Order("coffee_shop.name").
+		Scan(&stats).Error
+	return stats, err
+}
+
+func organizationKey(o models.Organization) (time.Time, uuid.UUID) {
+	return o.CreatedAt, o.ID
+}
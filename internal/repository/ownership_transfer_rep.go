@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+type OwnershipTransferRepository interface {
+	Create(ctx context.Context, transfer *models.OwnershipTransfer) (*models.OwnershipTransfer, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.OwnershipTransfer, error)
+	// ListByCoffeeShopID returns transfers of the coffee shop in any status.
+	ListByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.OwnershipTransfer], error)
+	// ListPendingForUser returns unexpired pending transfers offered to the user.
+	ListPendingForUser(ctx context.Context, userID uuid.UUID, now time.Time, page pagination.Query) (pagination.Page[models.OwnershipTransfer], error)
+	// Accept marks a pending unexpired transfer as accepted and makes the recipient an owner in one
+	// transaction. Unless the transfer keeps the ownership of the initiator, the initiator becomes an admin.
+	Accept(ctx context.Context, id uuid.UUID, now time.Time) (*models.OwnershipTransfer, error)
+	// Decline marks a pending unexpired transfer as declined by the recipient.
+	Decline(ctx context.Context, id uuid.UUID, now time.Time) (*models.OwnershipTransfer, error)
+	// Cancel marks a pending transfer as cancelled.
+	Cancel(ctx context.Context, id uuid.UUID, now time.Time) (*models.OwnershipTransfer, error)
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ownershipTransferRepository struct {
+	db *gorm.DB
+}
+
+func NewOwnershipTransferRepository(db *gorm.DB) OwnershipTransferRepository {
+	return &ownershipTransferRepository{db: db}
+}
+
+func (r *ownershipTransferRepository) Create(ctx context.Context, transfer *models.OwnershipTransfer) (*models.OwnershipTransfer, error) {
+	if err := r.db.WithContext(ctx).Create(transfer).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, transfer.ID)
+}
+
+func (r *ownershipTransferRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.OwnershipTransfer, error) {
+	var transfer models.OwnershipTransfer
+	err := r.db.WithContext(ctx).Preload("CoffeeShop").First(&transfer, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("ownership transfer", id.String())
+		}
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *ownershipTransferRepository) ListByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.OwnershipTransfer], error) {
+	query := r.db.WithContext(ctx).Where("coffee_shop_id = ?", coffeeShopID)
+	return findPage(query, "ownership_transfer", page, nil, ownershipTransferKey, "CoffeeShop")
+}
+
+func (r *ownershipTransferRepository) ListPendingForUser(ctx context.Context, userID uuid.UUID, now time.Time, page pagination.Query) (pagination.Page[models.OwnershipTransfer], error) {
+	query := r.db.WithContext(ctx).
+		Where("to_user_id = ? AND status = ? AND expires_at > ?", userID, models.TransferPending, now)
+	return findPage(query, "ownership_transfer", page, nil, ownershipTransferKey, "CoffeeShop")
+}
+
+func (r *ownershipTransferRepository) Accept(ctx context.Context, id uuid.UUID, now time.Time) (*models.OwnershipTransfer, error) {
+	var transfer *models.OwnershipTransfer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		transfer, err = closePendingTransfer(tx, id, now, models.TransferAccepted)
+		if err != nil {
+			return err
+		}
+
+		// Roles may have changed since the transfer was offered.
+		promoted, err := setWorkerRole(tx, transfer.CoffeeShopID, transfer.ToUserID, models.RoleAdmin, models.RoleOwner)
+		if err != nil {
+			return err
+		}
+		if !promoted {
+			return apperrors.NewErrConflict("recipient is no longer an admin of the coffee shop")
+		}
+
+		if transfer.KeepOwnership {
+			return nil
+		}
+		if transfer.FromUserID == nil {
+			return apperrors.NewErrConflict("initiator of the transfer no longer exists")
+		}
+		demoted, err := setWorkerRole(tx, transfer.CoffeeShopID, *transfer.FromUserID, models.RoleOwner, models.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		if !demoted {
+			return apperrors.NewErrConflict("initiator of the transfer is no longer an owner of the coffee shop")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (r *ownershipTransferRepository) Decline(ctx context.Context, id uuid.UUID, now time.Time) (*models.OwnershipTransfer, error) {
+	return closePendingTransfer(r.db.WithContext(ctx), id, now, models.TransferDeclined)
+}
+
+func (r *ownershipTransferRepository) Cancel(ctx context.Context, id uuid.UUID, now time.Time) (*models.OwnershipTransfer, error) {
+	var transfers []models.OwnershipTransfer
+	result := r.db.WithContext(ctx).Model(&transfers).Clauses(clause.Returning{}).
+		Where("id = ? AND status = ?", id, models.TransferPending).
+		Updates(map[string]any{"status": models.TransferCancelled, "responded_at": now})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(transfers) == 0 {
+		return nil, apperrors.NewErrConflict("ownership transfer is no longer pending")
+	}
+	return &transfers[0], nil
+}
+
+// closePendingTransfer answers only a pending unexpired transfer,
+// so concurrent answers to the same transfer cannot both succeed.
+func closePendingTransfer(db *gorm.DB, id uuid.UUID, now time.Time, status string) (*models.OwnershipTransfer, error) {
+	var transfers []models.OwnershipTransfer
+	result := db.Model(&transfers).Clauses(clause.Returning{}).
+		Where("id = ? AND status = ? AND expires_at > ?", id, models.TransferPending, now).
+		Updates(map[string]any{"status": status, "responded_at": now})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(transfers) == 0 {
+		return nil, apperrors.NewErrConflict("ownership transfer is no longer pending or has expired")
+	}
+	return &transfers[0], nil
+}
+
+// setWorkerRole changes the role of the worker only if they still have the expected role.
+func setWorkerRole(tx *gorm.DB, shopID, workerID uuid.UUID, from, to string) (bool, error) {
+	result := tx.Model(&models.WorkerCoffeeShop{}).
+		Where("coffee_shop_id = ? AND worker_id = ? AND is_deleted = ?", shopID, workerID, false).
+		Where("role_id = (?)", tx.Model(&models.Role{}).Select("id").Where("name = ?", from)).
+		Update("role_id", tx.Model(&models.Role{}).Select("id").Where("name = ?", to))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func ownershipTransferKey(t models.OwnershipTransfer) (time.Time, uuid.UUID) {
+	return t.CreatedAt, t.ID
+}
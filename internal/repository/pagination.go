@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// findPage loads one page of the rows matched by query. Without an order the rows are paged by a keyset over
+// table.created_at and table.id, newest first; with an order the cursor carries an offset instead.
+// Preloads are applied to the rows only, so the total is counted without them.
+func findPage[M any](query *gorm.DB, table string, q pagination.Query, order func(*gorm.DB) *gorm.DB, key func(M) (time.Time, uuid.UUID), preloads ...string) (pagination.Page[M], error) {
+	query = query.Model(new(M)).Session(&gorm.Session{})
+	keyset := order == nil
+	if keyset && q.After.Offset != 0 || !keyset && q.After.ID != uuid.Nil {
+		return pagination.Page[M]{}, apperrors.NewErrNotValid("cursor does not match the requested order")
+	}
+
+	var total *int64
+	if q.WithTotal {
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return pagination.Page[M]{}, err
+		}
+		total = &count
+	}
+
+	if keyset {
+		if !q.After.IsZero() {
+			query = query.Where("("+table+".created_at, "+table+".id) < (?, ?)", q.After.CreatedAt, q.After.ID)
+		}
+		query = query.Order(table + ".created_at DESC").Order(table + ".id DESC")
+	} else {
+		query = order(query).Order(table + ".id").Offset(q.After.Offset)
+	}
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+
+	var rows []M
+	if err := query.Limit(q.Limit + 1).Find(&rows).Error; err != nil {
+		return pagination.Page[M]{}, err
+	}
+	return pagination.NewPage(rows, total, q, keyset, key), nil
+}
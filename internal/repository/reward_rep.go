@@ -2,15 +2,23 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
 type RewardRepository interface {
 	GetReward(ctx context.Context, rewardID uuid.UUID) (*models.Reward, error)
-	GetRewardsByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]models.Reward, error)
-	GetRewardsByCoffeeShopID(ctx context.Context, CoffeeShopID uuid.UUID, offset, limit int) ([]models.Reward, error)
+	GetRewardsByUserID(ctx context.Context, userID uuid.UUID, page pagination.Query) (pagination.Page[models.Reward], error)
+	GetRewardsByCoffeeShopID(ctx context.Context, CoffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.Reward], error)
 	UpdateReward(ctx context.Context, reward *models.Reward) error
 	DeleteReward(ctx context.Context, rewardID uuid.UUID) error
 	CreateReward(ctx context.Context, reward *models.Reward) (*models.Reward, error)
+	CreateRewardWithinLimits(ctx context.Context, reward *models.Reward, monthStart time.Time) (*models.Reward, error)
+	ExpireRewards(ctx context.Context, now time.Time) (int64, error)
+	GetRewardByRedemptionCode(ctx context.Context, code string) (*models.Reward, error)
+	SetRedemptionCode(ctx context.Context, rewardID uuid.UUID, code string, expiresAt time.Time) error
+	ActivateReward(ctx context.Context, rewardID uuid.UUID, code string, activatedByID uuid.UUID, now time.Time) (*models.Reward, error)
 }
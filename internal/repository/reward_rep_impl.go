@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RewardRepositoryImpl struct {
@@ -25,6 +28,59 @@ func (r *RewardRepositoryImpl) CreateReward(ctx context.Context, reward *models.
 	return reward, nil
 }
 
+// CreateRewardWithinLimits creates the reward unless its type is out of stock or has used up
+// the budget of the month starting at monthStart. The reward type row is locked so concurrent
+// issuances cannot exceed the limits.
+func (r *RewardRepositoryImpl) CreateRewardWithinLimits(ctx context.Context, reward *models.Reward, monthStart time.Time) (*models.Reward, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rewardType models.RewardType
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&rewardType, "id = ?", reward.RewardTypeID).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.NewErrNotFound("reward type", reward.RewardTypeID.String())
+			}
+			return err
+		}
+
+		if rewardType.TotalStock != nil {
+			var issued int64
+			if err := tx.Model(&models.Reward{}).Where("reward_type_id = ?", rewardType.ID).Count(&issued).Error; err != nil {
+				return err
+			}
+			if issued >= int64(*rewardType.TotalStock) {
+				return apperrors.NewErrConflict("reward type is out of stock")
+			}
+		}
+
+		if rewardType.MonthlyBudget != nil {
+			var issued int64
+			err := tx.Model(&models.Reward{}).
+				Where("reward_type_id = ? AND given_at >= ?", rewardType.ID, monthStart).
+				Count(&issued).Error
+			if err != nil {
+				return err
+			}
+			if issued >= int64(*rewardType.MonthlyBudget) {
+				return apperrors.NewErrConflict("monthly budget of the reward type is used up")
+			}
+		}
+
+		return tx.Create(reward).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reward, nil
+}
+
+// ExpireRewards marks rewards that were not redeemed before their expiry and returns how many were marked.
+func (r *RewardRepositoryImpl) ExpireRewards(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Reward{}).
+		Where("NOT is_activated AND NOT is_expired AND expires_at <= ?", now).
+		Update("is_expired", true)
+	return result.RowsAffected, result.Error
+}
+
 func (r *RewardRepositoryImpl) UpdateReward(ctx context.Context, reward *models.Reward) error {
 	return r.db.WithContext(ctx).Save(reward).Error
 }
@@ -51,18 +107,55 @@ func (r *RewardRepositoryImpl) GetReward(ctx context.Context, ID uuid.UUID) (*mo
 	return &reward, nil
 }
 
-func (r *RewardRepositoryImpl) GetRewardsByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]models.Reward, error) {
-	var rewards []models.Reward
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Where("receiver_id = ?", userID).Find(&rewards).Error; err != nil {
+func (r *RewardRepositoryImpl) GetRewardByRedemptionCode(ctx context.Context, code string) (*models.Reward, error) {
+	var reward models.Reward
+	if err := r.db.WithContext(ctx).First(&reward, "redemption_code = ?", code).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("reward", code)
+		}
 		return nil, err
 	}
-	return rewards, nil
+	return &reward, nil
 }
 
-func (r *RewardRepositoryImpl) GetRewardsByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, offset, limit int) ([]models.Reward, error) {
+func (r *RewardRepositoryImpl) SetRedemptionCode(ctx context.Context, rewardID uuid.UUID, code string, expiresAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.Reward{}).
+		Where("id = ? AND NOT is_activated AND NOT is_expired", rewardID).
+		Updates(map[string]any{"redemption_code": code, "redemption_code_expires_at": expiresAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NewErrConflict("reward is already redeemed or has expired")
+	}
+	return nil
+}
+
+// ActivateReward marks the reward as redeemed only if it is still not activated and the code is unexpired,
+// so concurrent redemptions of the same code cannot both succeed.
+func (r *RewardRepositoryImpl) ActivateReward(ctx context.Context, rewardID uuid.UUID, code string, activatedByID uuid.UUID, now time.Time) (*models.Reward, error) {
 	var rewards []models.Reward
-	if err := r.db.WithContext(ctx).Limit(limit).Offset(offset).Where("coffee_shop_id = ?", coffeeShopID).Find(&rewards).Error; err != nil {
-		return nil, err
+	result := r.db.WithContext(ctx).Model(&rewards).Clauses(clause.Returning{}).
+		Where("id = ? AND redemption_code = ? AND redemption_code_expires_at > ? AND NOT is_activated", rewardID, code, now).
+		Where("NOT is_expired AND (expires_at IS NULL OR expires_at > ?)", now).
+		Updates(map[string]any{"is_activated": true, "activated_at": now, "activated_by_id": activatedByID})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(rewards) == 0 {
+		return nil, apperrors.NewErrConflict("reward is already redeemed or has expired, or the code has expired")
 	}
-	return rewards, nil
+	return &rewards[0], nil
+}
+
+func (r *RewardRepositoryImpl) GetRewardsByUserID(ctx context.Context, userID uuid.UUID, page pagination.Query) (pagination.Page[models.Reward], error) {
+	return findPage(r.db.WithContext(ctx).Where("receiver_id = ?", userID), "reward", page, nil, rewardKey)
+}
+
+func (r *RewardRepositoryImpl) GetRewardsByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.Reward], error) {
+	return findPage(r.db.WithContext(ctx).Where("coffee_shop_id = ?", coffeeShopID), "reward", page, nil, rewardKey)
+}
+
+func rewardKey(r models.Reward) (time.Time, uuid.UUID) {
+	return r.CreatedAt, r.ID
 }
@@ -3,12 +3,17 @@ package repository
 import (
 	"context"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
 type RewardTypeRepository interface {
 	GetRewardType(ctx context.Context, rewardTypeID uuid.UUID) (*models.RewardType, error)
-	GetRewardsTypeByCoffeeShopID(ctx context.Context, CoffeeShopID uuid.UUID, offset, limit int) ([]models.RewardType, error)
+	// GetRewardsTypeByCoffeeShopID includes the reward types of the organization of the coffee shop.
+	GetRewardsTypeByCoffeeShopID(ctx context.Context, CoffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.RewardType], error)
+	GetRewardsTypeByOrganizationID(ctx context.Context, organizationID uuid.UUID, page pagination.Query) (pagination.Page[models.RewardType], error)
+	// IsAvailableInShop reports whether rewards of the type can be given in the coffee shop.
+	IsAvailableInShop(ctx context.Context, rewardTypeID, shopID uuid.UUID) (bool, error)
 	UpdateReward(ctx context.Context, rewardType *models.RewardType) error
 	DeleteReward(ctx context.Context, rewardTypeID uuid.UUID) error
 	CreateReward(ctx context.Context, rewardType *models.RewardType) (*models.RewardType, error)
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -55,14 +57,28 @@ func (r RewardTypeRepositoryImpl) GetRewardType(ctx context.Context, rewardTypeI
 	return &rewardType, nil
 }
 
-func (r RewardTypeRepositoryImpl) GetRewardsTypeByCoffeeShopID(ctx context.Context, CoffeeShopID uuid.UUID, offset int, limit int) ([]models.RewardType, error) {
-	var rewardTypes []models.RewardType
-	err := r.db.WithContext(ctx).Offset(offset).Limit(limit).Where("coffee_shop_id = ?", CoffeeShopID).Find(&rewardTypes).Error
+func (r RewardTypeRepositoryImpl) GetRewardsTypeByCoffeeShopID(ctx context.Context, CoffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.RewardType], error) {
+	query := r.db.WithContext(ctx).Scopes(availableInShop(CoffeeShopID))
+	return findPage(query, "reward_type", page, nil, rewardTypeKey)
+}
+
+func (r RewardTypeRepositoryImpl) GetRewardsTypeByOrganizationID(ctx context.Context, organizationID uuid.UUID, page pagination.Query) (pagination.Page[models.RewardType], error) {
+	query := r.db.WithContext(ctx).Where("organization_id = ?", organizationID)
+	return findPage(query, "reward_type", page, nil, rewardTypeKey)
+}
+
+func (r RewardTypeRepositoryImpl) IsAvailableInShop(ctx context.Context, rewardTypeID, shopID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.RewardType{}).Scopes(availableInShop(shopID)).
+		Where("id = ?", rewardTypeID).Count(&count).Error
 	if err != nil {
-		return rewardTypes, err
+		return false, err
 	}
+	return count > 0, nil
+}
 
-	return rewardTypes, nil
+func rewardTypeKey(t models.RewardType) (time.Time, uuid.UUID) {
+	return t.CreatedAt, t.ID
 }
 
 func (r RewardTypeRepositoryImpl) UpdateReward(ctx context.Context, rewardType *models.RewardType) error {
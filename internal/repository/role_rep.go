@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+)
+
+type RoleRepository interface {
+	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
+}
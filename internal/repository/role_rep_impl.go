@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"gorm.io/gorm"
+)
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+func (r *roleRepository) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.WithContext(ctx).Where("name = ? AND is_deleted = ?", name, false).First(&role).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("role", name)
+		}
+		return nil, err
+	}
+	return &role, nil
+}
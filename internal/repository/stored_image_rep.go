@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+)
+
+type StoredImageRepository interface {
+	// Create records an image. Recording an image again is not an error.
+	Create(ctx context.Context, image *models.StoredImage) error
+	// Exists reports whether the image is recorded.
+	Exists(ctx context.Context, imageURL string) (bool, error)
+	// ListUnreferenced returns up to limit images created before createdBefore that no idea
+	// or upload refers to, ordered by URL and starting after afterURL.
+	ListUnreferenced(ctx context.Context, createdBefore time.Time, afterURL string, limit int) ([]models.StoredImage, error)
+	// DeleteUnreferenced deletes the record of an image unless something refers to it again.
+	// It reports whether the record was deleted.
+	DeleteUnreferenced(ctx context.Context, imageURL string) (bool, error)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// unreferencedImage matches stored images that neither an idea nor a finalized upload uses.
+const unreferencedImage = `NOT EXISTS (SELECT 1 FROM idea WHERE idea.image_url = stored_image.image_url)
+	AND NOT EXISTS (SELECT 1 FROM image_upload WHERE image_upload.image_url = stored_image.image_url)`
+
+type storedImageRepository struct {
+	db *gorm.DB
+}
+
+func NewStoredImageRepository(db *gorm.DB) StoredImageRepository {
+	return &storedImageRepository{db: db}
+}
+
+func (r *storedImageRepository) Create(ctx context.Context, image *models.StoredImage) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(image).Error
+}
+
+func (r *storedImageRepository) Exists(ctx context.Context, imageURL string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.StoredImage{}).Where("image_url = ?", imageURL).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *storedImageRepository) ListUnreferenced(ctx context.Context, createdBefore time.Time, afterURL string, limit int) ([]models.StoredImage, error) {
+	var images []models.StoredImage
+	err := r.db.WithContext(ctx).
+		Where("created_at < ? AND image_url > ?", createdBefore, afterURL).
+		Where(unreferencedImage).
+		Order("image_url").
+		Limit(limit).
+		Find(&images).Error
+	return images, err
+}
+
+func (r *storedImageRepository) DeleteUnreferenced(ctx context.Context, imageURL string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("image_url = ?", imageURL).
+		Where(unreferencedImage).
+		Delete(&models.StoredImage{})
+	return result.RowsAffected > 0, result.Error
+}
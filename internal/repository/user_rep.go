@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
@@ -10,6 +11,10 @@ type UserRep interface {
 	UpdateUser(ctx context.Context, user *models.User) error
 	DeleteUser(ctx context.Context, ID uuid.UUID) error
 	GetUser(ctx context.Context, ID uuid.UUID) (*models.User, error)
-	GetAllUsers(ctx context.Context, limit, offset int) ([]models.User, error)
+	// GetCoffeeShopUsers returns the users who work in the coffee shop or posted ideas in it.
+	GetCoffeeShopUsers(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.User], error)
+	// HasRoleInRelatedShop reports whether the actor has one of the roles, as a worker or through the
+	// organization, in a coffee shop the user works in or posted ideas in.
+	HasRoleInRelatedShop(ctx context.Context, actorID, userID uuid.UUID, roleNames []string) (bool, error)
 	IsUserExist(ctx context.Context, ID uuid.UUID) (bool, error)
 }
@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -29,10 +32,42 @@ func (u *UserRepImpl) DeleteUser(ctx context.Context, ID uuid.UUID) error {
 	return nil
 }
 
-func (u *UserRepImpl) GetAllUsers(ctx context.Context, limit int, offset int) ([]models.User, error) {
-	var users []models.User
-	err := u.db.WithContext(ctx).Where("is_deleted = ?", false).Limit(limit).Offset(offset).Find(&users).Error
-	return users, err
+func (u *UserRepImpl) GetCoffeeShopUsers(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.User], error) {
+	workers := u.db.Model(&models.WorkerCoffeeShop{}).Select("worker_id").
+		Where("coffee_shop_id = ? AND is_deleted = ?", coffeeShopID, false)
+	authors := u.db.Model(&models.Idea{}).Select("creator_id").
+		Where("coffee_shop_id = ? AND is_deleted = ?", coffeeShopID, false)
+	query := u.db.WithContext(ctx).Where("users.is_deleted = ?", false).
+		Where("users.id IN (?) OR users.id IN (?)", workers, authors)
+	return findPage(query, "users", page, nil, func(user models.User) (time.Time, uuid.UUID) {
+		return user.CreatedAt, user.ID
+	})
+}
+
+func (u *UserRepImpl) HasRoleInRelatedShop(ctx context.Context, actorID, userID uuid.UUID, roleNames []string) (bool, error) {
+	var found bool
+	err := u.db.WithContext(ctx).Raw(`
+		WITH related AS (
+			SELECT coffee_shop_id FROM worker_coffee_shop
+			WHERE worker_id = @user AND is_deleted = false AND coffee_shop_id IS NOT NULL
+			UNION
+			SELECT coffee_shop_id FROM idea
+			WHERE creator_id = @user AND is_deleted = false AND coffee_shop_id IS NOT NULL
+		)
+		SELECT EXISTS (
+			SELECT 1 FROM worker_coffee_shop
+			JOIN role ON role.id = worker_coffee_shop.role_id
+			WHERE worker_coffee_shop.worker_id = @actor AND worker_coffee_shop.is_deleted = false
+				AND role.name IN @roles AND worker_coffee_shop.coffee_shop_id IN (SELECT coffee_shop_id FROM related)
+		) OR EXISTS (
+			SELECT 1 FROM organization_member
+			JOIN role ON role.id = organization_member.role_id
+			JOIN coffee_shop ON coffee_shop.organization_id = organization_member.organization_id
+			WHERE organization_member.user_id = @actor AND role.name IN @roles
+				AND coffee_shop.id IN (SELECT coffee_shop_id FROM related)
+		)`, sql.Named("user", userID), sql.Named("actor", actorID), sql.Named("roles", roleNames)).
+		Scan(&found).Error
+	return found, err
 }
 
 func (u *UserRepImpl) GetUser(ctx context.Context, ID uuid.UUID) (*models.User, error) {
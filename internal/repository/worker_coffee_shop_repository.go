@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -11,10 +12,19 @@ type WorkerCoffeeShopRepository interface {
 	Create(ctx context.Context, workerShop *models.WorkerCoffeeShop) (*models.WorkerCoffeeShop, error)
 	CreateWithTx(ctx context.Context, workerShop *models.WorkerCoffeeShop, tx *gorm.DB) (*models.WorkerCoffeeShop, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*models.WorkerCoffeeShop, error)
-	ListByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, limit, offset int) ([]models.WorkerCoffeeShop, error)
-	ListByWorkerID(ctx context.Context, workerID uuid.UUID, limit, offset int) ([]models.WorkerCoffeeShop, error)
+	ListByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.WorkerCoffeeShop], error)
+	ListByWorkerID(ctx context.Context, workerID uuid.UUID, page pagination.Query) (pagination.Page[models.WorkerCoffeeShop], error)
 	Update(ctx context.Context, workerShop *models.WorkerCoffeeShop) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteOwner soft-deletes the relation of an owner, unless no other owner of the coffee shop remains.
+	DeleteOwner(ctx context.Context, workerShop *models.WorkerCoffeeShop) error
+	// UpdateOwner saves the relation of an owner who gets another role, unless no other owner
+	// of the coffee shop remains.
+	UpdateOwner(ctx context.Context, workerShop *models.WorkerCoffeeShop) error
 	GetByUserIDAndShopID(ctx context.Context, userID, shopID uuid.UUID) (*models.WorkerCoffeeShop, error)
 	IsAdminInAnyShop(ctx context.Context, userID uuid.UUID) (bool, error)
+	// CountByRole returns the number of workers of the coffee shop with the role.
+	CountByRole(ctx context.Context, shopID uuid.UUID, roleName string) (int64, error)
+	// HasSoleOwnedShop reports whether the user is the only owner of at least one coffee shop.
+	HasSoleOwnedShop(ctx context.Context, userID uuid.UUID) (bool, error)
 }
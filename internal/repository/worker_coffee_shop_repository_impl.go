@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type WorkerCoffeeShopRepositoryImpl struct {
@@ -24,7 +27,7 @@ func (r *WorkerCoffeeShopRepositoryImpl) Create(ctx context.Context, workerShop
 		return nil, err
 	}
 	// Preload associated data for the returned object
-	if err := r.db.WithContext(ctx).Preload("Worker").Preload("CoffeeShop").First(workerShop).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Worker").Preload("CoffeeShop").Preload("Role").First(workerShop).Error; err != nil {
 		return nil, err
 	}
 	return workerShop, nil
@@ -34,7 +37,7 @@ func (r *WorkerCoffeeShopRepositoryImpl) CreateWithTx(ctx context.Context, worke
 	if err := tx.WithContext(ctx).Create(workerShop).Error; err != nil {
 		return nil, err
 	}
-	if err := tx.WithContext(ctx).Preload("Worker").Preload("CoffeeShop").First(workerShop).Error; err != nil {
+	if err := tx.WithContext(ctx).Preload("Worker").Preload("CoffeeShop").Preload("Role").First(workerShop).Error; err != nil {
 		return nil, err
 	}
 	return workerShop, nil
@@ -43,7 +46,7 @@ func (r *WorkerCoffeeShopRepositoryImpl) CreateWithTx(ctx context.Context, worke
 // GetByID retrieves a worker-coffeeshop relationship by its ID
 func (r *WorkerCoffeeShopRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*models.WorkerCoffeeShop, error) {
 	var workerShop models.WorkerCoffeeShop
-	err := r.db.WithContext(ctx).Preload("Worker").Preload("CoffeeShop").
+	err := r.db.WithContext(ctx).Preload("Worker").Preload("CoffeeShop").Preload("Role").
 		Where("is_deleted = ?", false).First(&workerShop, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -55,23 +58,19 @@ func (r *WorkerCoffeeShopRepositoryImpl) GetByID(ctx context.Context, id uuid.UU
 }
 
 // ListByCoffeeShopID lists all workers for a given coffee shop
-func (r *WorkerCoffeeShopRepositoryImpl) ListByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, limit, offset int) ([]models.WorkerCoffeeShop, error) {
-	var workers []models.WorkerCoffeeShop
-	err := r.db.WithContext(ctx).Preload("Worker").
-		Where("coffee_shop_id = ? AND is_deleted = ?", coffeeShopID, false).
-		Limit(limit).Offset(offset).
-		Find(&workers).Error
-	return workers, err
+func (r *WorkerCoffeeShopRepositoryImpl) ListByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.WorkerCoffeeShop], error) {
+	query := r.db.WithContext(ctx).Where("coffee_shop_id = ? AND is_deleted = ?", coffeeShopID, false)
+	return findPage(query, "worker_coffee_shop", page, nil, workerCoffeeShopKey, "Worker")
 }
 
 // ListByWorkerID lists all coffee shops for a given worker
-func (r *WorkerCoffeeShopRepositoryImpl) ListByWorkerID(ctx context.Context, workerID uuid.UUID, limit, offset int) ([]models.WorkerCoffeeShop, error) {
-	var coffeeShops []models.WorkerCoffeeShop
-	err := r.db.WithContext(ctx).Preload("CoffeeShop").
-		Where("worker_id = ? AND is_deleted = ?", workerID, false).
-		Limit(limit).Offset(offset).
-		Find(&coffeeShops).Error
-	return coffeeShops, err
+func (r *WorkerCoffeeShopRepositoryImpl) ListByWorkerID(ctx context.Context, workerID uuid.UUID, page pagination.Query) (pagination.Page[models.WorkerCoffeeShop], error) {
+	query := r.db.WithContext(ctx).Where("worker_id = ? AND is_deleted = ?", workerID, false)
+	return findPage(query, "worker_coffee_shop", page, nil, workerCoffeeShopKey, "CoffeeShop")
+}
+
+func workerCoffeeShopKey(w models.WorkerCoffeeShop) (time.Time, uuid.UUID) {
+	return w.CreatedAt, w.ID
 }
 
 // Update updates a worker-coffeeshop relationship
@@ -92,6 +91,58 @@ func (r *WorkerCoffeeShopRepositoryImpl) Delete(ctx context.Context, id uuid.UUI
 	return nil
 }
 
+func (r *WorkerCoffeeShopRepositoryImpl) DeleteOwner(ctx context.Context, workerShop *models.WorkerCoffeeShop) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireOtherOwner(tx, workerShop); err != nil {
+			return err
+		}
+		result := tx.Model(&models.WorkerCoffeeShop{}).Where("id = ? AND is_deleted = ?", workerShop.ID, false).
+			Update("is_deleted", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NewErrNotFound("worker_coffee_shop", workerShop.ID.String())
+		}
+		return nil
+	})
+}
+
+func (r *WorkerCoffeeShopRepositoryImpl) UpdateOwner(ctx context.Context, workerShop *models.WorkerCoffeeShop) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := requireOtherOwner(tx, workerShop); err != nil {
+			return err
+		}
+		return tx.Save(workerShop).Error
+	})
+}
+
+// requireOtherOwner fails unless an owner other than the one of workerShop remains. It locks the
+// coffee shop first, so that owners stepping down at the same time are checked one after another.
+func requireOtherOwner(tx *gorm.DB, workerShop *models.WorkerCoffeeShop) error {
+	var shop models.CoffeeShop
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&shop, "id = ?", workerShop.CoffeeShopID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewErrNotFound("coffee shop", workerShop.CoffeeShopID.String())
+		}
+		return err
+	}
+
+	var others int64
+	err := tx.Model(&models.WorkerCoffeeShop{}).
+		Joins("JOIN role ON role.id = worker_coffee_shop.role_id").
+		Where("worker_coffee_shop.coffee_shop_id = ? AND worker_coffee_shop.id <> ? AND role.name = ? AND worker_coffee_shop.is_deleted = ?",
+			workerShop.CoffeeShopID, workerShop.ID, models.RoleOwner, false).
+		Count(&others).Error
+	if err != nil {
+		return err
+	}
+	if others == 0 {
+		return apperrors.NewErrConflict("the last owner cannot step down; transfer ownership first")
+	}
+	return nil
+}
+
 func (r *WorkerCoffeeShopRepositoryImpl) GetByUserIDAndShopID(ctx context.Context, userID, shopID uuid.UUID) (*models.WorkerCoffeeShop, error) {
 	var worker *models.WorkerCoffeeShop
 	err := r.db.WithContext(ctx).Preload("Worker").Preload("CoffeeShop").Preload("Role").
@@ -106,11 +157,48 @@ func (r *WorkerCoffeeShopRepositoryImpl) GetByUserIDAndShopID(ctx context.Contex
 	return worker, nil
 }
 
+// IsAdminInAnyShop reports whether the user has a role that manages a coffee shop in at least one shop,
+// either as a worker of the shop or as a member of an organization.
 func (r *WorkerCoffeeShopRepositoryImpl) IsAdminInAnyShop(ctx context.Context, userID uuid.UUID) (bool, error) {
+	managerRoles := models.RolesWith(models.PermShopManage)
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.WorkerCoffeeShop{}).
+		Joins("JOIN role ON role.id = worker_coffee_shop.role_id").
+		Where("worker_coffee_shop.worker_id = ? AND role.name IN ? AND worker_coffee_shop.is_deleted = ?", userID, managerRoles, false).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+	err = r.db.WithContext(ctx).Model(&models.OrganizationMember{}).
+		Joins("JOIN role ON role.id = organization_member.role_id").
+		Where("organization_member.user_id = ? AND role.name IN ?", userID, managerRoles).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *WorkerCoffeeShopRepositoryImpl) CountByRole(ctx context.Context, shopID uuid.UUID, roleName string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.WorkerCoffeeShop{}).
+		Joins("JOIN role ON role.id = worker_coffee_shop.role_id").
+		Where("worker_coffee_shop.coffee_shop_id = ? AND role.name = ? AND worker_coffee_shop.is_deleted = ?", shopID, roleName, false).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *WorkerCoffeeShopRepositoryImpl) HasSoleOwnedShop(ctx context.Context, userID uuid.UUID) (bool, error) {
 	var count int64
 	err := r.db.WithContext(ctx).Model(&models.WorkerCoffeeShop{}).
 		Joins("JOIN role ON role.id = worker_coffee_shop.role_id").
-		Where("worker_coffee_shop.worker_id = ? AND role.name = ? AND worker_coffee_shop.is_deleted = ?", userID, "admin", false).
+		Where("worker_coffee_shop.worker_id = ? AND role.name = ? AND worker_coffee_shop.is_deleted = ?", userID, models.RoleOwner, false).
+		Where(`NOT EXISTS (SELECT 1 FROM worker_coffee_shop other JOIN role other_role ON other_role.id = other.role_id
+			WHERE other.coffee_shop_id = worker_coffee_shop.coffee_shop_id AND other.worker_id <> worker_coffee_shop.worker_id
+			AND other_role.name = ? AND other.is_deleted = ?)`, models.RoleOwner, false).
 		Count(&count).Error
 	if err != nil {
 		return false, err
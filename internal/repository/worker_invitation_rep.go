@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+type WorkerInvitationRepository interface {
+	Create(ctx context.Context, invitation *models.WorkerInvitation) (*models.WorkerInvitation, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.WorkerInvitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.WorkerInvitation, error)
+	// ListByCoffeeShopID returns invitations of the coffee shop in any status.
+	ListByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.WorkerInvitation], error)
+	// ListPendingByPhone returns unexpired pending invitations addressed to the phone.
+	ListPendingByPhone(ctx context.Context, phone string, now time.Time, page pagination.Query) (pagination.Page[models.WorkerInvitation], error)
+	// Accept marks a pending unexpired invitation as accepted by the user and adds the user
+	// to the coffee shop with the invited role in one transaction. It returns the new worker relation.
+	Accept(ctx context.Context, id, userID uuid.UUID, now time.Time) (*models.WorkerCoffeeShop, error)
+	// Decline marks a pending unexpired invitation as declined by the user.
+	Decline(ctx context.Context, id, userID uuid.UUID, now time.Time) (*models.WorkerInvitation, error)
+	// Revoke marks a pending invitation as revoked by the actor.
+	Revoke(ctx context.Context, id, actorID uuid.UUID, now time.Time) (*models.WorkerInvitation, error)
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type workerInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewWorkerInvitationRepository(db *gorm.DB) WorkerInvitationRepository {
+	return &workerInvitationRepository{db: db}
+}
+
+func (r *workerInvitationRepository) Create(ctx context.Context, invitation *models.WorkerInvitation) (*models.WorkerInvitation, error) {
+	if err := r.db.WithContext(ctx).Create(invitation).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, invitation.ID)
+}
+
+func (r *workerInvitationRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WorkerInvitation, error) {
+	return r.get(ctx, id.String(), "id = ?", id)
+}
+
+func (r *workerInvitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.WorkerInvitation, error) {
+	return r.get(ctx, "token", "token_hash = ?", tokenHash)
+}
+
+func (r *workerInvitationRepository) get(ctx context.Context, recordID, query string, arg any) (*models.WorkerInvitation, error) {
+	var invitation models.WorkerInvitation
+	err := r.db.WithContext(ctx).Preload("Role").Preload("CoffeeShop").First(&invitation, query, arg).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewErrNotFound("invitation", recordID)
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *workerInvitationRepository) ListByCoffeeShopID(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Query) (pagination.Page[models.WorkerInvitation], error) {
+	query := r.db.WithContext(ctx).Where("coffee_shop_id = ?", coffeeShopID)
+	return findPage(query, "worker_invitation", page, nil, invitationKey, "Role", "CoffeeShop")
+}
+
+func (r *workerInvitationRepository) ListPendingByPhone(ctx context.Context, phone string, now time.Time, page pagination.Query) (pagination.Page[models.WorkerInvitation], error) {
+	query := r.db.WithContext(ctx).
+		Where("phone = ? AND status = ? AND expires_at > ?", phone, models.InvitationPending, now)
+	return findPage(query, "worker_invitation", page, nil, invitationKey, "Role", "CoffeeShop")
+}
+
+func (r *workerInvitationRepository) Accept(ctx context.Context, id, userID uuid.UUID, now time.Time) (*models.WorkerCoffeeShop, error) {
+	var relation *models.WorkerCoffeeShop
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		invitation, err := closePending(tx, id, now, map[string]any{
+			"status": models.InvitationAccepted, "responded_by_id": userID, "responded_at": now,
+		})
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		err = tx.Model(&models.WorkerCoffeeShop{}).
+			Where("worker_id = ? AND coffee_shop_id = ? AND is_deleted = ?", userID, invitation.CoffeeShopID, false).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return apperrors.NewErrConflict("user is already a worker in this coffee shop")
+		}
+
+		relation = &models.WorkerCoffeeShop{
+			WorkerID:     &userID,
+			CoffeeShopID: &invitation.CoffeeShopID,
+			RoleID:       &invitation.RoleID,
+		}
+		return tx.Create(relation).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return relation, nil
+}
+
+func (r *workerInvitationRepository) Decline(ctx context.Context, id, userID uuid.UUID, now time.Time) (*models.WorkerInvitation, error) {
+	return closePending(r.db.WithContext(ctx), id, now, map[string]any{
+		"status": models.InvitationDeclined, "responded_by_id": userID, "responded_at": now,
+	})
+}
+
+func (r *workerInvitationRepository) Revoke(ctx context.Context, id, actorID uuid.UUID, now time.Time) (*models.WorkerInvitation, error) {
+	var invitations []models.WorkerInvitation
+	result := r.db.WithContext(ctx).Model(&invitations).Clauses(clause.Returning{}).
+		Where("id = ? AND status = ?", id, models.InvitationPending).
+		Updates(map[string]any{"status": models.InvitationRevoked, "revoked_by_id": actorID, "revoked_at": now})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(invitations) == 0 {
+		return nil, apperrors.NewErrConflict("invitation is no longer pending")
+	}
+	return &invitations[0], nil
+}
+
+// closePending applies updates only to a pending unexpired invitation,
+// so concurrent answers to the same invitation cannot both succeed.
+func closePending(db *gorm.DB, id uuid.UUID, now time.Time, updates map[string]any) (*models.WorkerInvitation, error) {
+	var invitations []models.WorkerInvitation
+	result := db.Model(&invitations).Clauses(clause.Returning{}).
+		Where("id = ? AND status = ? AND expires_at > ?", id, models.InvitationPending, now).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(invitations) == 0 {
+		return nil, apperrors.NewErrConflict("invitation is no longer pending or has expired")
+	}
+	return &invitations[0], nil
+}
+
+func invitationKey(i models.WorkerInvitation) (time.Time, uuid.UUID) {
+	return i.CreatedAt, i.ID
+}
@@ -29,6 +29,12 @@ type AppRouter struct {
 	ideaStatusHandler       *handlers.IdeaStatusHandler
 	workerCoffeeShopRepo    repository.WorkerCoffeeShopRepository
 	imageHandler            *handlers.ImageHandler
+	storageHandler          *handlers.StorageHandler
+	banHandler              *handlers.BanHandler
+	invitationHandler       *handlers.WorkerInvitationHandler
+	ownershipHandler        *handlers.OwnershipTransferHandler
+	organizationHandler     *handlers.OrganizationHandler
+	healthHandler           *handlers.HealthHandler
 
 	authUsecase usecase.AuthUsecase
 	logger      *slog.Logger
@@ -48,6 +54,13 @@ func NewRouter(cfg *config.Config,
 	ideaStatusHandler *handlers.IdeaStatusHandler,
 	workerCoffeeShopRepo repository.WorkerCoffeeShopRepository,
 	imageHandler *handlers.ImageHandler, // Add this line
+	// storageHandler is nil unless images are stored in a local directory that accepts direct uploads.
+	storageHandler *handlers.StorageHandler,
+	banHandler *handlers.BanHandler,
+	invitationHandler *handlers.WorkerInvitationHandler,
+	ownershipHandler *handlers.OwnershipTransferHandler,
+	organizationHandler *handlers.OrganizationHandler,
+	healthHandler *handlers.HealthHandler,
 
 	authUsecase usecase.AuthUsecase,
 	logger *slog.Logger,
@@ -67,6 +80,12 @@ func NewRouter(cfg *config.Config,
 		ideaStatusHandler:       ideaStatusHandler,
 		workerCoffeeShopRepo:    workerCoffeeShopRepo,
 		imageHandler:            imageHandler, // Add this line
+		storageHandler:          storageHandler,
+		banHandler:              banHandler,
+		invitationHandler:       invitationHandler,
+		ownershipHandler:        ownershipHandler,
+		organizationHandler:     organizationHandler,
+		healthHandler:           healthHandler,
 
 		authUsecase: authUsecase,
 		logger:      logger,
@@ -77,6 +96,9 @@ func (ar AppRouter) SetupRouter() *gin.Engine {
 	r := gin.Default()
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	r.GET("/.well-known/jwks.json", ar.authHandler.JWKS)
+	r.GET("/livez", ar.healthHandler.Live)
+	r.GET("/readyz", ar.healthHandler.Ready)
 
 	v1 := r.Group("/api/v1")
 	{
@@ -92,11 +114,19 @@ func (ar AppRouter) SetupRouter() *gin.Engine {
 		v1.POST("/auth/refresh", ar.authHandler.Refresh)
 
 		// ideas
-		v1.GET("/ideas/:id", ar.ideaHandler.GetIdea)
-		v1.GET("/coffee-shops/:id/ideas", ar.ideaHandler.GetIdeasFromShop)
+		optionalAuth := middleware.OptionalAuthMiddleware(ar.authUsecase, ar.logger)
+		v1.GET("/ideas/:id", optionalAuth, ar.ideaHandler.GetIdea)
+		v1.GET("/ideas/:id/history", ar.ideaHandler.GetIdeaStatusHistory)
+		v1.GET("/coffee-shops/:id/ideas", optionalAuth, ar.ideaHandler.GetIdeasFromShop)
+		v1.GET("/coffee-shops/:id/ideas/search", optionalAuth, ar.ideaHandler.SearchIdeasInShop)
 
 		// images (public access)
 		v1.GET("/images/*imagePath", ar.imageHandler.GetImage)
+		v1.HEAD("/images/*imagePath", ar.imageHandler.GetImage)
+		if ar.storageHandler != nil {
+			// Authorized by the signature of the presigned URL.
+			v1.PUT("/storage/*key", ar.storageHandler.PutObject)
+		}
 
 		// rewards
 		v1.GET("/rewards/:id", ar.rewardHandler.GetReward)
@@ -108,6 +138,14 @@ func (ar AppRouter) SetupRouter() *gin.Engine {
 		// statuses
 		v1.GET("/statuses", ar.ideaStatusHandler.GetAll)
 		v1.GET("/statuses/:id", ar.ideaStatusHandler.GetByID)
+		v1.GET("/coffee-shops/:id/statuses", ar.ideaStatusHandler.GetByCoffeeShop)
+		v1.GET("/coffee-shops/:id/status-transitions", ar.ideaStatusHandler.GetTransitions)
+
+		// organizations
+		v1.GET("/organizations/:id", ar.organizationHandler.GetOrganization)
+		v1.GET("/organizations/:id/coffee-shops", ar.organizationHandler.ListCoffeeShops)
+		v1.GET("/organizations/:id/categories", ar.categoryHandler.GetByOrganization)
+		v1.GET("/organizations/:id/ideas", optionalAuth, ar.ideaHandler.SearchIdeasInOrganization)
 	}
 
 	authRequired := v1.Group("")
@@ -121,6 +159,11 @@ func (ar AppRouter) SetupRouter() *gin.Engine {
 		authRequired.DELETE("/users/:id", ar.userHandler.DeleteUser)
 		authRequired.GET("/users/me/rewards", ar.rewardHandler.GetMyRewards)
 		authRequired.GET("/users/me/ideas", ar.ideaHandler.GetIdeasFromUser)
+		authRequired.GET("/users/me/invitations", ar.invitationHandler.ListMyInvitations)
+		authRequired.GET("/users/me/ownership-transfers", ar.ownershipHandler.ListMyTransfers)
+		authRequired.GET("/users/me/organizations", ar.organizationHandler.ListMyOrganizations)
+		authRequired.GET("/users/me/sessions", ar.authHandler.ListSessions)
+		authRequired.DELETE("/users/me/sessions/:id", ar.authHandler.RevokeSession)
 
 		// auth
 		authRequired.POST("/logout", ar.authHandler.Logout)
@@ -142,6 +185,12 @@ func (ar AppRouter) SetupRouter() *gin.Engine {
 		authRequired.GET("/ideas/:id/liked", ar.likeHandler.HasUserLiked)
 		authRequired.GET("/rewards/type/:id", ar.rewardTypeHandler.GetRewardType)
 
+		// uploads
+		authRequired.POST("/uploads", ar.imageHandler.CreateUpload)
+
+		// rewards
+		authRequired.POST("/rewards/redeem", ar.rewardHandler.RedeemReward)
+
 		// categories
 		authRequired.POST("/coffee-shops/:id/categories", ar.categoryHandler.Create)
 		authRequired.PUT("/coffee-shops/:id/categories/:category_id", ar.categoryHandler.Update)
@@ -152,7 +201,49 @@ func (ar AppRouter) SetupRouter() *gin.Engine {
 		authRequired.GET("/ideas/:id/comments", ar.commentHandler.GetComments)
 		authRequired.DELETE("/ideas/:id/comments/:comment_id", ar.commentHandler.DeleteComment)
 
+		// statuses
+		authRequired.POST("/coffee-shops/:id/statuses", ar.ideaStatusHandler.Create)
+		authRequired.PUT("/coffee-shops/:id/statuses/:status_id", ar.ideaStatusHandler.Update)
+		authRequired.DELETE("/coffee-shops/:id/statuses/:status_id", ar.ideaStatusHandler.Delete)
+		authRequired.POST("/coffee-shops/:id/status-transitions", ar.ideaStatusHandler.CreateTransition)
+		authRequired.DELETE("/coffee-shops/:id/status-transitions/:transition_id", ar.ideaStatusHandler.DeleteTransition)
+
 		authRequired.GET("/users/:id/coffee-shops", ar.workerCoffeeShopHandler.ListCoffeeShopsForWorker)
+
+		// bans
+		authRequired.POST("/coffee-shops/:id/bans", ar.banHandler.BanUser)
+		authRequired.GET("/coffee-shops/:id/bans", ar.banHandler.ListBans)
+		authRequired.DELETE("/coffee-shops/:id/bans/:user_id", ar.banHandler.UnbanUser)
+
+		// invitations
+		authRequired.POST("/coffee-shops/:id/invitations", ar.invitationHandler.CreateInvitation)
+		authRequired.GET("/coffee-shops/:id/invitations", ar.invitationHandler.ListInvitations)
+		authRequired.DELETE("/coffee-shops/:id/invitations/:invitation_id", ar.invitationHandler.RevokeInvitation)
+		authRequired.POST("/invitations/accept", ar.invitationHandler.AcceptInvitationByToken)
+		authRequired.POST("/invitations/:id/accept", ar.invitationHandler.AcceptInvitation)
+		authRequired.POST("/invitations/:id/decline", ar.invitationHandler.DeclineInvitation)
+
+		// ownership
+		authRequired.POST("/coffee-shops/:id/ownership-transfers", ar.ownershipHandler.CreateTransfer)
+		authRequired.GET("/coffee-shops/:id/ownership-transfers", ar.ownershipHandler.ListTransfers)
+		authRequired.DELETE("/coffee-shops/:id/ownership-transfers/:transfer_id", ar.ownershipHandler.CancelTransfer)
+		authRequired.POST("/ownership-transfers/:id/accept", ar.ownershipHandler.AcceptTransfer)
+		authRequired.POST("/ownership-transfers/:id/decline", ar.ownershipHandler.DeclineTransfer)
+
+		// organizations
+		authRequired.POST("/organizations", ar.organizationHandler.CreateOrganization)
+		authRequired.PUT("/organizations/:id", ar.organizationHandler.UpdateOrganization)
+		authRequired.DELETE("/organizations/:id", ar.organizationHandler.DeleteOrganization)
+		authRequired.GET("/organizations/:id/stats", ar.organizationHandler.GetStats)
+		authRequired.POST("/organizations/:id/coffee-shops", ar.organizationHandler.AttachCoffeeShop)
+		authRequired.DELETE("/organizations/:id/coffee-shops/:shop_id", ar.organizationHandler.DetachCoffeeShop)
+		authRequired.POST("/organizations/:id/members", ar.organizationHandler.AddMember)
+		authRequired.GET("/organizations/:id/members", ar.organizationHandler.ListMembers)
+		authRequired.DELETE("/organizations/:id/members/:user_id", ar.organizationHandler.RemoveMember)
+		authRequired.POST("/organizations/:id/categories", ar.categoryHandler.CreateInOrganization)
+		authRequired.PUT("/organizations/:id/categories/:category_id", ar.categoryHandler.UpdateInOrganization)
+		authRequired.DELETE("/organizations/:id/categories/:category_id", ar.categoryHandler.DeleteInOrganization)
+		authRequired.GET("/organizations/:id/rewards/type", ar.rewardTypeHandler.GetRewardTypesByOrganization)
 	}
 
 	adminRequired := authRequired.Group("/admin")
@@ -169,12 +260,8 @@ func (ar AppRouter) SetupRouter() *gin.Engine {
 		// worker-coffee-shops
 		adminRequired.POST("/worker-coffee-shops", ar.workerCoffeeShopHandler.AddWorker)
 		adminRequired.DELETE("/worker-coffee-shops/:id", ar.workerCoffeeShopHandler.RemoveWorker)
+		adminRequired.PUT("/worker-coffee-shops/:id/role", ar.workerCoffeeShopHandler.ChangeWorkerRole)
 		adminRequired.GET("/coffee-shops/:id/workers", ar.workerCoffeeShopHandler.ListWorkersInShop)
-
-		// statuses
-		// adminRequired.POST("/statuses", ar.ideaStatusHandler.Create)
-		// adminRequired.PUT("/statuses/:id", ar.ideaStatusHandler.Update)
-		// adminRequired.DELETE("/statuses/:id", ar.ideaStatusHandler.Delete)
 	}
 	return r
 }
@@ -2,40 +2,29 @@ package usecase
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
 
 type AccessControlUsecase interface {
-	CanManageCoffeeShop(ctx context.Context, userID, coffeeShopID uuid.UUID) error
+	// Can returns ErrAccessDenied unless the user works in the coffee shop, or is a member of its organization,
+	// with a role granting the permission.
+	Can(ctx context.Context, userID, coffeeShopID uuid.UUID, permission models.Permission) error
+	// CanAccessAsWorker returns ErrAccessDenied unless the user works in the coffee shop, or is a member
+	// of its organization, with any role.
+	CanAccessAsWorker(ctx context.Context, userID, coffeeShopID uuid.UUID) error
+	// CanInOrganization returns ErrAccessDenied unless the user is a member of the organization with a role
+	// granting the permission.
+	CanInOrganization(ctx context.Context, userID, organizationID uuid.UUID, permission models.Permission) error
+	// CanAccessOrganization returns ErrAccessDenied unless the user is a member of the organization with any role.
+	CanAccessOrganization(ctx context.Context, userID, organizationID uuid.UUID) error
 }
 
-// CheckShopAdminAccess verifies if a user is a worker in the shop with the 'admin' role.
-func CheckShopAdminAccess(ctx context.Context, logger *slog.Logger, workerShopRepo repository.WorkerCoffeeShopRepository, actorID, shopID uuid.UUID) error {
-	l := logger.With("method", "CheckShopAdminAccess", "actorID", actorID, "shopID", shopID)
-
-	worker, err := workerShopRepo.GetByUserIDAndShopID(ctx, actorID, shopID)
-	if err != nil {
-		var errNotFound *apperrors.ErrNotFound
-		if errors.As(err, &errNotFound) {
-			return apperrors.NewErrAccessDenied("user is not worker for this coffee shop")
-		}
-		return err
-	}
-	if worker.Role.Name == "admin" {
-		l.Debug("access granted: user is a worker with admin role")
-		return nil
-	}
-
-	l.Warn("access denied: user is not shop creator or admin worker")
-	return apperrors.NewErrAccessDenied("user is not an admin for this coffee shop")
-}
-
-// CheckAnyShopAdminAccess verifies if a user is an admin in at least one coffee shop.
+// CheckAnyShopAdminAccess verifies if a user manages at least one coffee shop.
 func CheckAnyShopAdminAccess(ctx context.Context, logger *slog.Logger, workerShopRepo repository.WorkerCoffeeShopRepository, actorID uuid.UUID) error {
 	l := logger.With("method", "CheckAnyShopAdminAccess", "actorID", actorID)
 
@@ -51,4 +40,21 @@ func CheckAnyShopAdminAccess(ctx context.Context, logger *slog.Logger, workerSho
 
 	l.Warn("access denied: user is not an admin in any coffee shop")
 	return apperrors.NewErrAccessDenied("user is not an admin in any coffee shop")
-}
\ No newline at end of file
+}
+
+// CheckNotBanned verifies that a user does not have an active ban in the coffee shop.
+func CheckNotBanned(ctx context.Context, logger *slog.Logger, banRepo repository.BannedUserRepository, userID, shopID uuid.UUID) error {
+	l := logger.With("method", "CheckNotBanned", "userID", userID, "shopID", shopID)
+
+	banned, err := banRepo.IsBanned(ctx, userID, shopID)
+	if err != nil {
+		l.Error("failed to check ban", "error", err)
+		return err
+	}
+	if banned {
+		l.Warn("access denied: user is banned in this coffee shop")
+		return apperrors.NewErrAccessDenied("user is banned in this coffee shop")
+	}
+
+	return nil
+}
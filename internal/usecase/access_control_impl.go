@@ -2,24 +2,128 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
 
 type AccessControlUsecaseImpl struct {
 	workerShopRepo repository.WorkerCoffeeShopRepository
+	orgRepo        repository.OrganizationRepository
 	logger         *slog.Logger
 }
 
-func NewAccessControlUsecase(workerShopRepo repository.WorkerCoffeeShopRepository, logger *slog.Logger) AccessControlUsecase {
+func NewAccessControlUsecase(workerShopRepo repository.WorkerCoffeeShopRepository, orgRepo repository.OrganizationRepository, logger *slog.Logger) AccessControlUsecase {
 	return &AccessControlUsecaseImpl{
 		workerShopRepo: workerShopRepo,
+		orgRepo:        orgRepo,
 		logger:         logger,
 	}
 }
 
-func (u *AccessControlUsecaseImpl) CanManageCoffeeShop(ctx context.Context, userID, coffeeShopID uuid.UUID) error {
-	return CheckShopAdminAccess(ctx, u.logger, u.workerShopRepo, userID, coffeeShopID)
+func (u *AccessControlUsecaseImpl) Can(ctx context.Context, userID, coffeeShopID uuid.UUID, permission models.Permission) error {
+	logger := u.logger.With("method", "Can", "userID", userID, "shopID", coffeeShopID, "permission", permission)
+
+	roles, err := u.getShopRoles(ctx, logger, userID, coffeeShopID)
+	if err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		logger.Warn("access denied: user is not worker for this coffee shop")
+		return apperrors.NewErrAccessDenied("user is not a worker for this coffee shop")
+	}
+	for _, role := range roles {
+		if role.Can(permission) {
+			logger.Debug("access granted", "role", role.Name)
+			return nil
+		}
+	}
+
+	logger.Warn("access denied: role does not grant the permission", "role", roles[0].Name)
+	return apperrors.NewErrAccessDenied("your role in this coffee shop does not allow " + string(permission))
+}
+
+func (u *AccessControlUsecaseImpl) CanAccessAsWorker(ctx context.Context, userID, coffeeShopID uuid.UUID) error {
+	logger := u.logger.With("method", "CanAccessAsWorker", "userID", userID, "shopID", coffeeShopID)
+
+	roles, err := u.getShopRoles(ctx, logger, userID, coffeeShopID)
+	if err != nil {
+		return err
+	}
+	if len(roles) == 0 {
+		logger.Warn("access denied: user is not worker for this coffee shop")
+		return apperrors.NewErrAccessDenied("user is not a worker for this coffee shop")
+	}
+
+	logger.Debug("access granted: user is a worker")
+	return nil
+}
+
+func (u *AccessControlUsecaseImpl) CanInOrganization(ctx context.Context, userID, organizationID uuid.UUID, permission models.Permission) error {
+	logger := u.logger.With("method", "CanInOrganization", "userID", userID, "organizationID", organizationID, "permission", permission)
+
+	member, err := u.getMember(ctx, logger, userID, organizationID)
+	if err != nil {
+		return err
+	}
+	if !member.Role.Can(permission) {
+		logger.Warn("access denied: role does not grant the permission", "role", member.Role.Name)
+		return apperrors.NewErrAccessDenied("your role in this organization does not allow " + string(permission))
+	}
+
+	logger.Debug("access granted", "role", member.Role.Name)
+	return nil
+}
+
+func (u *AccessControlUsecaseImpl) CanAccessOrganization(ctx context.Context, userID, organizationID uuid.UUID) error {
+	logger := u.logger.With("method", "CanAccessOrganization", "userID", userID, "organizationID", organizationID)
+
+	if _, err := u.getMember(ctx, logger, userID, organizationID); err != nil {
+		return err
+	}
+
+	logger.Debug("access granted: user is a member")
+	return nil
+}
+
+// getShopRoles returns the role of the user in the coffee shop and the role inherited from its organization, if any.
+func (u *AccessControlUsecaseImpl) getShopRoles(ctx context.Context, logger *slog.Logger, userID, coffeeShopID uuid.UUID) ([]models.Role, error) {
+	var roles []models.Role
+	var errNotFound *apperrors.ErrNotFound
+
+	worker, err := u.workerShopRepo.GetByUserIDAndShopID(ctx, userID, coffeeShopID)
+	if err == nil {
+		roles = append(roles, worker.Role)
+	} else if !errors.As(err, &errNotFound) {
+		logger.Error("failed to get worker", "error", err)
+		return nil, err
+	}
+
+	member, err := u.orgRepo.GetMemberForShop(ctx, userID, coffeeShopID)
+	if err == nil {
+		roles = append(roles, member.Role)
+	} else if !errors.As(err, &errNotFound) {
+		logger.Error("failed to get organization member", "error", err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func (u *AccessControlUsecaseImpl) getMember(ctx context.Context, logger *slog.Logger, userID, organizationID uuid.UUID) (*models.OrganizationMember, error) {
+	member, err := u.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		var errNotFound *apperrors.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			logger.Warn("access denied: user is not a member of this organization")
+			return nil, apperrors.NewErrAccessDenied("user is not a member of this organization")
+		}
+		logger.Error("failed to get organization member", "error", err)
+		return nil, err
+	}
+	return member, nil
 }
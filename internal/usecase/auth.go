@@ -10,16 +10,27 @@ type AuthUsecase interface {
 
 	GetOTP(ctx context.Context, phone string) error
 
-	VerifyOTP(ctx context.Context, req *dto.VerifyOTPRequest) (*dto.AuthResponse, error)
+	VerifyOTP(ctx context.Context, req *dto.VerifyOTPRequest, client dto.ClientInfo) (*dto.AuthResponse, error)
 
-	RegisterAdminAndCoffeeShop(ctx context.Context, req *dto.RegisterAdminRequest) (*dto.AdminAuthResponse, error)
-	LoginAdmin(ctx context.Context, req *dto.AdminLoginRequest) (*dto.AdminAuthResponse, error)
-	Refresh(ctx context.Context, token string) (*dto.AuthResponse, error)
+	RegisterAdminAndCoffeeShop(ctx context.Context, req *dto.RegisterAdminRequest, client dto.ClientInfo) (*dto.AdminAuthResponse, error)
+	LoginAdmin(ctx context.Context, req *dto.AdminLoginRequest, client dto.ClientInfo) (*dto.AdminAuthResponse, error)
+	// Refresh exchanges a refresh token for a new token pair. Presenting a token that was
+	// already exchanged revokes its whole session, because either it or its successor leaked.
+	Refresh(ctx context.Context, token string, client dto.ClientInfo) (*dto.AuthResponse, error)
 
 	Logout(ctx context.Context, token string) error
 
 	LogoutEverywhere(ctx context.Context, userID uuid.UUID) error
 
+	// ListSessions returns the logged-in devices of the user; currentSessionID marks the caller's own session.
+	ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]dto.SessionResponse, error)
+
+	// RevokeSession logs the user out on one device.
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+
 	ValidateJWTToken(ctx context.Context, tokenString string) (*dto.JWTClaims, error)
 
+	// JWKS returns the public keys that verify access tokens. It is empty when tokens are signed with a shared secret.
+	JWKS() dto.JWKSResponse
+
 }
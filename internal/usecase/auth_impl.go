@@ -11,11 +11,15 @@ import (
 	"log/slog"
 	"math/big"
 	"time"
+	"unicode/utf8"
 
 	"github.com/GeorgiiMalishev/ideas-platform/config"
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/jwtkeys"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/otpsender"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -23,24 +27,32 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	maxDeviceNameLength = 100
+	maxIPLength         = 45
+	maxUserAgentLength  = 512
+)
+
 type AuthUsecaseImpl struct {
 	rep        repository.AuthRepository
 	csRepo     repository.CoffeeShopRep
 	workerRepo repository.WorkerCoffeeShopRepository
 	db         *gorm.DB
-	jwtSecret  string
+	jwtKeys    *jwtkeys.KeySet
 	authCfg    *config.AuthConfig
+	otpSender  otpsender.OTPSender
 	logger     *slog.Logger
 }
 
-func NewAuthUsecase(rep repository.AuthRepository, csRepo repository.CoffeeShopRep, workerRepo repository.WorkerCoffeeShopRepository, db *gorm.DB, jwtSecret string, authCfg *config.AuthConfig, logger *slog.Logger) AuthUsecase {
+func NewAuthUsecase(rep repository.AuthRepository, csRepo repository.CoffeeShopRep, workerRepo repository.WorkerCoffeeShopRepository, db *gorm.DB, jwtKeys *jwtkeys.KeySet, authCfg *config.AuthConfig, otpSender otpsender.OTPSender, logger *slog.Logger) AuthUsecase {
 	return &AuthUsecaseImpl{
 		rep:        rep,
 		csRepo:     csRepo,
 		workerRepo: workerRepo,
 		db:         db,
-		jwtSecret:  jwtSecret,
+		jwtKeys:    jwtKeys,
 		authCfg:    authCfg,
+		otpSender:  otpSender,
 		logger:     logger,
 	}
 }
@@ -95,17 +107,19 @@ func (a *AuthUsecaseImpl) GetOTP(ctx context.Context, phone string) error {
 		return err
 	}
 
-	err = a.saveOTP(ctx, savedOTP, hashedCode, phone)
-	if err != nil {
-		logger.Error("failed to save OTP", "error", err.Error())
-		return err
-	}
-
 	logger.Info("sending OTP to phone")
 
-	err = sendOTPToPhone(phone, code)
+	// The code is saved only once it is delivered, so that a failed delivery neither replaces
+	// the previous code nor counts towards the resend limit.
+	err = a.otpSender.Send(ctx, internationalPhone(phone), code)
 	if err != nil {
 		logger.Error("failed to send OTP", "error", err.Error())
+		return apperrors.NewErrDeliveryFailed("failed to deliver otp code, try again later")
+	}
+
+	err = a.saveOTP(ctx, savedOTP, hashedCode, phone)
+	if err != nil {
+		logger.Error("failed to save OTP", "error", err.Error())
 		return err
 	}
 
@@ -158,7 +172,7 @@ func (a *AuthUsecaseImpl) updateResendCount(savedOTP *models.OTP) {
 }
 
 // VerifyOTP implements AuthUsecase.
-func (a *AuthUsecaseImpl) VerifyOTP(ctx context.Context, req *dto.VerifyOTPRequest) (*dto.AuthResponse, error) {
+func (a *AuthUsecaseImpl) VerifyOTP(ctx context.Context, req *dto.VerifyOTPRequest, client dto.ClientInfo) (*dto.AuthResponse, error) {
 	logger := a.logger.With(
 		"method", "GetOTP",
 		"phone", req.Phone,
@@ -211,7 +225,7 @@ func (a *AuthUsecaseImpl) VerifyOTP(ctx context.Context, req *dto.VerifyOTPReque
 		return nil, err
 	}
 
-	return a.makeAuthResponse(ctx, user, "")
+	return a.startSession(ctx, user, client)
 }
 
 func generateCode() (string, error) {
@@ -229,11 +243,6 @@ func generateCode() (string, error) {
 	return string(result), nil
 }
 
-func sendOTPToPhone(phone string, code string) error {
-	fmt.Printf("code %s for phone %s\n", code, phone)
-	return nil
-}
-
 func hashCode(code string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
 	return string(hash), err
@@ -289,83 +298,172 @@ func (a *AuthUsecaseImpl) createOTP(ctx context.Context, phone, hashedCode strin
 // Logout implements AuthUsecase.
 func (a *AuthUsecaseImpl) Logout(ctx context.Context, tokenString string) error {
 	hashedToken := hashToken(tokenString)
-	return a.rep.DeleteRefreshToken(ctx, hashedToken)
+	token, err := a.rep.GetRefreshToken(ctx, hashedToken)
+	if err != nil {
+		return err
+	}
+	if token.SessionID == nil {
+		return a.rep.DeleteRefreshToken(ctx, hashedToken)
+	}
+	return a.rep.DeleteSession(ctx, *token.SessionID)
 }
 
 func (a *AuthUsecaseImpl) LogoutEverywhere(ctx context.Context, userID uuid.UUID) error {
 	return a.rep.DeleteRefreshTokensByUserID(ctx, userID)
 }
 
-func (a *AuthUsecaseImpl) Refresh(ctx context.Context, oldTokenString string) (*dto.AuthResponse, error) {
+// ListSessions implements AuthUsecase.
+func (a *AuthUsecaseImpl) ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]dto.SessionResponse, error) {
+	logger := a.logger.With("method", "ListSessions", "userID", userID.String())
+
+	sessions, err := a.rep.ListSessions(ctx, userID)
+	if err != nil {
+		logger.Error("failed to list sessions", "error", err.Error())
+		return nil, err
+	}
+
+	resp := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, dto.SessionResponse{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			IP:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeSession implements AuthUsecase.
+func (a *AuthUsecaseImpl) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	logger := a.logger.With("method", "RevokeSession", "userID", userID.String(), "sessionID", sessionID.String())
+
+	err := a.rep.DeleteUserSession(ctx, userID, sessionID)
+	if err != nil {
+		var errNotFound *apperrors.ErrNotFound
+		if !errors.As(err, &errNotFound) {
+			logger.Error("failed to revoke session", "error", err.Error())
+		}
+		return err
+	}
+
+	logger.Info("session revoked")
+	return nil
+}
+
+func (a *AuthUsecaseImpl) Refresh(ctx context.Context, oldTokenString string, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	logger := a.logger.With("method", "Refresh")
+
 	oldToken, err := a.validateAndGetRefreshToken(ctx, oldTokenString)
 	if err != nil {
 		return nil, err
 	}
 
 	if oldToken.User == nil {
-		a.logger.Warn("refresh token exists but user not found", "token_hash", oldTokenString)
-		_ = a.rep.DeleteRefreshToken(ctx, oldTokenString)
+		logger.Warn("refresh token exists but user not found", "token_hash", oldToken.RefreshToken)
+		_ = a.rep.DeleteRefreshToken(ctx, oldToken.RefreshToken)
 		return nil, apperrors.NewErrUnauthorized("user not found")
 	}
 
-	return a.makeAuthResponse(ctx, oldToken.User, oldToken.RefreshToken)
-}
+	now := time.Now()
+	session := oldToken.Session
+	if session == nil {
+		logger.Info("moving refresh token issued before sessions into a new session", "userID", oldToken.UserID.String())
+		session = &models.UserSession{
+			ID:         uuid.New(),
+			UserID:     oldToken.UserID,
+			DeviceName: truncate(client.DeviceName, maxDeviceNameLength),
+			CreatedAt:  now,
+		}
+	}
+	session.IP = truncate(client.IP, maxIPLength)
+	session.UserAgent = truncate(client.UserAgent, maxUserAgentLength)
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(a.authCfg.JWTConfig.RefreshTokenTimer)
 
-func (a *AuthUsecaseImpl) makeAuthResponse(ctx context.Context, user *models.User, oldToken string) (*dto.AuthResponse, error) {
-	logger := a.logger.With("method", "makeAuthResponse", "userID", user.ID.String())
+	newTokenString, newToken, err := a.newRefreshToken(oldToken.UserID, session.ExpiresAt)
+	if err != nil {
+		logger.Error("failed to generate refresh token", "error", err.Error())
+		return nil, err
+	}
 
-	logger.Debug("starting make auth response")
-	jwtToken, err := a.createJWTToken(user)
+	err = a.rep.RotateRefreshToken(ctx, oldToken, newToken, session)
 	if err != nil {
-		logger.Error("failed to create JWT token", "error", err.Error())
+		var errNotFound *apperrors.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			logger.Info("refresh token was rotated concurrently")
+			return nil, apperrors.NewErrUnauthorized("refresh token not found")
+		}
+		logger.Error("failed to rotate refresh token", "error", err.Error())
 		return nil, err
 	}
 
-	refreshToken, err := a.createRefreshToken(ctx, user.ID, oldToken)
+	jwtToken, err := a.createJWTToken(oldToken.User, session.ID)
 	if err != nil {
-		logger.Error("failed to create refresh token token", "error", err.Error())
+		logger.Error("failed to create JWT token", "error", err.Error())
 		return nil, err
 	}
 
 	return &dto.AuthResponse{
 		AccessToken:  *jwtToken,
-		RefreshToken: *refreshToken,
+		RefreshToken: newTokenString,
 	}, nil
 }
 
-func (a *AuthUsecaseImpl) createRefreshToken(ctx context.Context, userID uuid.UUID, oldTokenString string) (*string, error) {
-	logger := a.logger.With(
-		"method", "createRefreshToken",
-		"userID", userID.String(),
-	)
+// startSession logs the user in on a new device and issues its first token pair.
+func (a *AuthUsecaseImpl) startSession(ctx context.Context, user *models.User, client dto.ClientInfo) (*dto.AuthResponse, error) {
+	logger := a.logger.With("method", "startSession", "userID", user.ID.String())
 
-	logger.Debug("starting create refresh token")
-	if oldTokenString != "" {
-		var errNotFound *apperrors.ErrNotFound
-		err := a.rep.DeleteRefreshToken(ctx, oldTokenString)
-		if err != nil && !errors.As(err, &errNotFound) {
-			logger.Error("failed to delete old token", "error", err.Error())
-			return nil, err
-		}
+	logger.Debug("starting session")
+	now := time.Now()
+	session := &models.UserSession{
+		UserID:     user.ID,
+		DeviceName: truncate(client.DeviceName, maxDeviceNameLength),
+		IP:         truncate(client.IP, maxIPLength),
+		UserAgent:  truncate(client.UserAgent, maxUserAgentLength),
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(a.authCfg.JWTConfig.RefreshTokenTimer),
 	}
 
-	newTokenString, err := generateRefreshToken()
+	refreshToken, token, err := a.newRefreshToken(user.ID, session.ExpiresAt)
 	if err != nil {
 		logger.Error("failed to generate refresh token", "error", err.Error())
 		return nil, err
 	}
-	hashedToken := hashToken(newTokenString)
-	err = a.rep.CreateRefreshToken(ctx, &models.UserRefreshToken{
-		UserID:       userID,
-		RefreshToken: hashedToken,
-		ExpiresAt:    time.Now().Add(a.authCfg.JWTConfig.RefreshTokenTimer),
-	})
+
+	err = a.rep.CreateSession(ctx, session, token)
+	if err != nil {
+		logger.Error("failed to create session", "error", err.Error())
+		return nil, err
+	}
+
+	jwtToken, err := a.createJWTToken(user, session.ID)
 	if err != nil {
-		logger.Error("failed to create refresh token", "error", err.Error())
+		logger.Error("failed to create JWT token", "error", err.Error())
 		return nil, err
 	}
 
-	return &newTokenString, nil
+	return &dto.AuthResponse{
+		AccessToken:  *jwtToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// newRefreshToken generates a refresh token and the record that stores its hash.
+func (a *AuthUsecaseImpl) newRefreshToken(userID uuid.UUID, expiresAt time.Time) (string, *models.UserRefreshToken, error) {
+	tokenString, err := generateRefreshToken()
+	if err != nil {
+		return "", nil, err
+	}
+	return tokenString, &models.UserRefreshToken{
+		UserID:       userID,
+		RefreshToken: hashToken(tokenString),
+		ExpiresAt:    expiresAt,
+	}, nil
 }
 
 func (a *AuthUsecaseImpl) ValidateJWTToken(ctx context.Context, tokenString string) (*dto.JWTClaims, error) {
@@ -375,26 +473,28 @@ func (a *AuthUsecaseImpl) ValidateJWTToken(ctx context.Context, tokenString stri
 
 	logger.Debug("starting validate JWT token")
 	var claims dto.JWTClaims
-	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			logger.Info(fmt.Sprintf("unexpexted singing method: %v", token.Header["alg"]))
-			return nil, apperrors.NewErrUnauthorized(fmt.Sprintf("unexpexted singing method: %v", token.Header["alg"]))
-		}
-
-		return []byte(a.jwtSecret), nil
-	})
+	token, err := a.jwtKeys.Parse(tokenString, &claims)
 	if err != nil || !token.Valid {
-		logger.Info("invalid token")
+		logger.Info("invalid token", "error", err)
 		return nil, apperrors.NewErrUnauthorized("invalid token")
 	}
 	logger.Info("JWT claims successfully getted", "userID", claims.UserID.String())
 	return &claims, nil
 }
 
+// JWKS implements AuthUsecase.
+func (a *AuthUsecaseImpl) JWKS() dto.JWKSResponse {
+	keys := a.jwtKeys.PublicKeys()
+	resp := dto.JWKSResponse{Keys: make([]dto.JWK, 0, len(keys))}
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, dto.JWK(key))
+	}
+	return resp
+}
+
 func (a *AuthUsecaseImpl) validateAndGetRefreshToken(ctx context.Context, token string) (*models.UserRefreshToken, error) {
 	logger := a.logger.With(
 		"method", "validateAndGetRefreshToken",
-		"token", token,
 	)
 
 	logger.Debug("starting validate and refresh token")
@@ -403,8 +503,7 @@ func (a *AuthUsecaseImpl) validateAndGetRefreshToken(ctx context.Context, token
 	if err != nil {
 		var errNotFound *apperrors.ErrNotFound
 		if errors.As(err, &errNotFound) {
-			logger.Info("refresh token not found")
-			return nil, apperrors.NewErrUnauthorized("refresh token not found")
+			return nil, a.checkRefreshTokenReuse(ctx, hashedToken)
 		}
 
 		logger.Error("failed to get refresh token", "error", err.Error())
@@ -418,29 +517,53 @@ func (a *AuthUsecaseImpl) validateAndGetRefreshToken(ctx context.Context, token
 	return savedToken, nil
 }
 
+// checkRefreshTokenReuse handles a refresh token that is not live. If it was rotated before,
+// it has been used twice, so the session it belongs to is revoked.
+func (a *AuthUsecaseImpl) checkRefreshTokenReuse(ctx context.Context, hashedToken string) error {
+	logger := a.logger.With("method", "checkRefreshTokenReuse")
+
+	rotated, err := a.rep.GetRotatedRefreshToken(ctx, hashedToken)
+	if err != nil {
+		var errNotFound *apperrors.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			logger.Info("refresh token not found")
+			return apperrors.NewErrUnauthorized("refresh token not found")
+		}
+		logger.Error("failed to get rotated refresh token", "error", err.Error())
+		return err
+	}
+
+	logger.Warn("rotated refresh token reused, revoking session", "sessionID", rotated.SessionID.String())
+	if err := a.rep.DeleteSession(ctx, rotated.SessionID); err != nil {
+		logger.Error("failed to revoke session", "error", err.Error())
+		return err
+	}
+	return apperrors.NewErrUnauthorized("refresh token reused, session revoked")
+}
+
 func hashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(hash[:])
 }
 
-func (a *AuthUsecaseImpl) createJWTToken(user *models.User) (*string, error) {
+func (a *AuthUsecaseImpl) createJWTToken(user *models.User, sessionID uuid.UUID) (*string, error) {
 	JWTClaims := dto.JWTClaims{
-		UserID: user.ID,
+		UserID:    user.ID,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(a.authCfg.JWTConfig.JWTTokenTimer)),
 		},
 	}
 
-	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims)
-	tokenString, err := jwtToken.SignedString([]byte(a.jwtSecret))
+	tokenString, err := a.jwtKeys.Sign(JWTClaims)
 	if err != nil {
 		return nil, err
 	}
 	return &tokenString, nil
 }
 
-func (a *AuthUsecaseImpl) RegisterAdminAndCoffeeShop(ctx context.Context, req *dto.RegisterAdminRequest) (*dto.AdminAuthResponse, error) {
+func (a *AuthUsecaseImpl) RegisterAdminAndCoffeeShop(ctx context.Context, req *dto.RegisterAdminRequest, client dto.ClientInfo) (*dto.AdminAuthResponse, error) {
 	logger := a.logger.With(
 		"method", "RegisterAdminAndCoffeeShop",
 		"login", req.Login,
@@ -492,7 +615,7 @@ func (a *AuthUsecaseImpl) RegisterAdminAndCoffeeShop(ctx context.Context, req *d
 
 	// Create Coffee Shop
 	coffeeShop := &models.CoffeeShop{
-		CreatorID: createdUser.ID,
+		CreatorID: &createdUser.ID,
 		Name:      req.CoffeeShopName,
 		Address:   req.Address,
 	}
@@ -504,20 +627,20 @@ func (a *AuthUsecaseImpl) RegisterAdminAndCoffeeShop(ctx context.Context, req *d
 	}
 	logger.Debug("coffee shop created", "coffee_shop_id", createdCoffeeShop.ID)
 
-	// Get Admin Role
-	adminRole, err := a.rep.GetRoleByNameWithTx(ctx, "admin", tx)
+	// Get Owner Role
+	ownerRole, err := a.rep.GetRoleByNameWithTx(ctx, models.RoleOwner, tx)
 	if err != nil {
-		logger.Error("failed to get admin role", "error", err.Error())
+		logger.Error("failed to get owner role", "error", err.Error())
 		tx.Rollback()
 		return nil, err
 	}
-	logger.Debug("admin role fetched", "role_id", adminRole.ID)
+	logger.Debug("owner role fetched", "role_id", ownerRole.ID)
 
 	// Create WorkerCoffeeShop Link
 	workerLink := &models.WorkerCoffeeShop{
 		WorkerID:     &createdUser.ID,
 		CoffeeShopID: &createdCoffeeShop.ID,
-		RoleID:       &adminRole.ID,
+		RoleID:       &ownerRole.ID,
 	}
 	_, err = a.workerRepo.CreateWithTx(ctx, workerLink, tx)
 	if err != nil {
@@ -534,7 +657,8 @@ func (a *AuthUsecaseImpl) RegisterAdminAndCoffeeShop(ctx context.Context, req *d
 
 	logger.Info("admin and coffee shop registered successfully")
 
-	authResp, err := a.makeAuthResponse(ctx, createdUser, "")
+	client.DeviceName = req.DeviceName
+	authResp, err := a.startSession(ctx, createdUser, client)
 	if err != nil {
 		return nil, err
 	}
@@ -546,7 +670,7 @@ func (a *AuthUsecaseImpl) RegisterAdminAndCoffeeShop(ctx context.Context, req *d
 	}, nil
 }
 
-func (a *AuthUsecaseImpl) LoginAdmin(ctx context.Context, req *dto.AdminLoginRequest) (*dto.AdminAuthResponse, error) {
+func (a *AuthUsecaseImpl) LoginAdmin(ctx context.Context, req *dto.AdminLoginRequest, client dto.ClientInfo) (*dto.AdminAuthResponse, error) {
 	logger := a.logger.With(
 		"method", "LoginAdmin",
 		"login", req.Login,
@@ -573,15 +697,16 @@ func (a *AuthUsecaseImpl) LoginAdmin(ctx context.Context, req *dto.AdminLoginReq
 
 	logger.Info("admin logged in successfully")
 
-	authResp, err := a.makeAuthResponse(ctx, user, "")
+	client.DeviceName = req.DeviceName
+	authResp, err := a.startSession(ctx, user, client)
 	if err != nil {
 		return nil, err
 	}
 
 	var shopID uuid.UUID
-	workers, err := a.workerRepo.ListByWorkerID(ctx, user.ID, 1, 0)
-	if err == nil && len(workers) > 0 && workers[0].CoffeeShopID != nil {
-		shopID = *workers[0].CoffeeShopID
+	workers, err := a.workerRepo.ListByWorkerID(ctx, user.ID, pagination.Query{Limit: 1})
+	if err == nil && len(workers.Items) > 0 && workers.Items[0].CoffeeShopID != nil {
+		shopID = *workers.Items[0].CoffeeShopID
 	} else {
 		logger.Warn("admin has no associated coffee shop", "user_id", user.ID)
 	}
@@ -601,3 +726,14 @@ func generateRefreshToken() (string, error) {
 
 	return base64.URLEncoding.EncodeToString(bytes), nil
 }
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
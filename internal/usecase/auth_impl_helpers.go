@@ -18,4 +18,9 @@ func normalizePhone(phone string) string {
 		return phone[1:]
 	}
 	return phone
-}
\ No newline at end of file
+}
+
+// internationalPhone restores the +7 prefix stripped by normalizePhone.
+func internationalPhone(phone string) string {
+	return "+7" + phone
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+type BanUsecase interface {
+	// BanUser bans a user in a coffee shop. Banned users cannot create ideas, comment or like.
+	// Requires the user.ban permission in the coffee shop.
+	BanUser(ctx context.Context, actorID, shopID uuid.UUID, req *dto.BanUserRequest) (*dto.BannedUserResponse, error)
+
+	// UnbanUser lifts the ban of a user in a coffee shop.
+	// Requires the user.ban permission in the coffee shop.
+	UnbanUser(ctx context.Context, actorID, shopID, userID uuid.UUID) error
+
+	// ListBans retrieves a paginated list of active bans in a coffee shop.
+	// Requires the user.ban permission in the coffee shop.
+	ListBans(ctx context.Context, actorID, shopID uuid.UUID, page pagination.Request) (pagination.Page[dto.BannedUserResponse], error)
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
+	"github.com/google/uuid"
+)
+
+type banUsecase struct {
+	banRepo        repository.BannedUserRepository
+	workerShopRepo repository.WorkerCoffeeShopRepository
+	userRepo       repository.UserRep
+	accessControl  AccessControlUsecase
+	logger         *slog.Logger
+}
+
+func NewBanUsecase(
+	banRepo repository.BannedUserRepository,
+	workerShopRepo repository.WorkerCoffeeShopRepository,
+	userRepo repository.UserRep,
+	accessControl AccessControlUsecase,
+	logger *slog.Logger,
+) BanUsecase {
+	return &banUsecase{
+		banRepo:        banRepo,
+		workerShopRepo: workerShopRepo,
+		userRepo:       userRepo,
+		accessControl:  accessControl,
+		logger:         logger,
+	}
+}
+
+func (u *banUsecase) BanUser(ctx context.Context, actorID, shopID uuid.UUID, req *dto.BanUserRequest) (*dto.BannedUserResponse, error) {
+	logger := u.logger.With("method", "BanUser", "actorID", actorID, "shopID", shopID, "userID", req.UserID)
+	logger.Debug("starting to ban user")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermUserBan); err != nil {
+		return nil, err
+	}
+
+	if req.UserID == actorID {
+		logger.Info("attempt to ban yourself")
+		return nil, apperrors.NewErrNotValid("you cannot ban yourself")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		logger.Info("ban expiry is in the past", "expiresAt", req.ExpiresAt)
+		return nil, apperrors.NewErrNotValid("expires_at must be in the future")
+	}
+
+	exists, err := u.userRepo.IsUserExist(ctx, req.UserID)
+	if err != nil {
+		logger.Error("failed to check user existence", "error", err)
+		return nil, err
+	}
+	if !exists {
+		logger.Info("user to ban does not exist")
+		return nil, apperrors.NewErrNotFound("user", req.UserID.String())
+	}
+
+	worker, err := u.workerShopRepo.GetByUserIDAndShopID(ctx, req.UserID, shopID)
+	if err != nil {
+		var errNotFound *apperrors.ErrNotFound
+		if !errors.As(err, &errNotFound) {
+			logger.Error("failed to check worker role", "error", err)
+			return nil, err
+		}
+	} else if worker.Role.Can(models.PermShopManage) {
+		logger.Info("attempt to ban a coffee shop admin")
+		return nil, apperrors.NewErrAccessDenied("coffee shop admin cannot be banned")
+	}
+
+	_, err = u.banRepo.GetActive(ctx, req.UserID, shopID)
+	if err == nil {
+		logger.Info("user is already banned in this shop")
+		return nil, apperrors.NewErrConflict(fmt.Sprintf("user %s is already banned in shop %s", req.UserID, shopID))
+	}
+	var errNotFound *apperrors.ErrNotFound
+	if !errors.As(err, &errNotFound) {
+		logger.Error("failed to check existing ban", "error", err)
+		return nil, err
+	}
+
+	// Drop expired bans so that only one ban row exists per user and shop.
+	if err := u.banRepo.Delete(ctx, req.UserID, shopID); err != nil && !errors.As(err, &errNotFound) {
+		logger.Error("failed to clean up expired bans", "error", err)
+		return nil, err
+	}
+
+	ban := &models.BannedUser{
+		UserID:       &req.UserID,
+		CoffeeShopID: &shopID,
+		BannedByID:   &actorID,
+		Reason:       req.Reason,
+		ExpiresAt:    req.ExpiresAt,
+		HideIdeas:    req.HideIdeas,
+	}
+
+	createdBan, err := u.banRepo.Create(ctx, ban)
+	if err != nil {
+		logger.Error("failed to create ban", "error", err)
+		return nil, err
+	}
+
+	logger.Info("user banned successfully", "banID", createdBan.ID)
+	return toBannedUserResponse(createdBan), nil
+}
+
+func (u *banUsecase) UnbanUser(ctx context.Context, actorID, shopID, userID uuid.UUID) error {
+	logger := u.logger.With("method", "UnbanUser", "actorID", actorID, "shopID", shopID, "userID", userID)
+	logger.Debug("starting to unban user")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermUserBan); err != nil {
+		return err
+	}
+
+	if err := u.banRepo.Delete(ctx, userID, shopID); err != nil {
+		logger.Error("failed to delete ban", "error", err)
+		return err
+	}
+
+	logger.Info("user unbanned successfully")
+	return nil
+}
+
+func (u *banUsecase) ListBans(ctx context.Context, actorID, shopID uuid.UUID, page pagination.Request) (pagination.Page[dto.BannedUserResponse], error) {
+	logger := u.logger.With("method", "ListBans", "actorID", actorID, "shopID", shopID, "limit", page.Limit)
+	logger.Debug("starting to list bans")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermUserBan); err != nil {
+		return pagination.Page[dto.BannedUserResponse]{}, err
+	}
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.BannedUserResponse]{}, err
+	}
+
+	bans, err := u.banRepo.ListActiveByCoffeeShopID(ctx, shopID, q)
+	if err != nil {
+		logger.Error("failed to list bans", "error", err)
+		return pagination.Page[dto.BannedUserResponse]{}, err
+	}
+
+	resp := pagination.Map(bans, func(b models.BannedUser) dto.BannedUserResponse {
+		return *toBannedUserResponse(&b)
+	})
+
+	logger.Info("bans listed successfully", "count", len(resp.Items))
+	return resp, nil
+}
+
+func toBannedUserResponse(b *models.BannedUser) *dto.BannedUserResponse {
+	var userName string
+	if b.User.Name != nil {
+		userName = *b.User.Name
+	}
+	return &dto.BannedUserResponse{
+		ID:           b.ID,
+		UserID:       b.UserID,
+		UserName:     userName,
+		CoffeeShopID: b.CoffeeShopID,
+		BannedByID:   b.BannedByID,
+		Reason:       b.Reason,
+		ExpiresAt:    b.ExpiresAt,
+		HideIdeas:    b.HideIdeas,
+		CreatedAt:    b.CreatedAt,
+	}
+}
@@ -3,8 +3,9 @@ package usecase
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
 )
 
 type CategoryUsecase interface {
@@ -12,5 +13,12 @@ type CategoryUsecase interface {
 	Update(ctx context.Context, userID, coffeeShopID, categoryID uuid.UUID, category dto.UpdateCategory) error
 	Delete(ctx context.Context, userID uuid.UUID, coffeeShopID uuid.UUID, categoryID uuid.UUID) error
 	GetByID(ctx context.Context, coffeeShopID, categoryID uuid.UUID) (dto.CategoryResponse, error)
-	GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page, pageSize int) ([]dto.CategoryResponse, int, error)
+	GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Request) (pagination.Page[dto.CategoryResponse], error)
+
+	// CreateInOrganization, UpdateInOrganization and DeleteInOrganization manage categories shared by all
+	// coffee shops of the organization. They require the shop.manage permission in the organization.
+	CreateInOrganization(ctx context.Context, userID, organizationID uuid.UUID, category dto.CreateCategory) (uuid.UUID, error)
+	UpdateInOrganization(ctx context.Context, userID, organizationID, categoryID uuid.UUID, category dto.UpdateCategory) error
+	DeleteInOrganization(ctx context.Context, userID, organizationID, categoryID uuid.UUID) error
+	GetByOrganization(ctx context.Context, organizationID uuid.UUID, page pagination.Request) (pagination.Page[dto.CategoryResponse], error)
 }
@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/uuid"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -22,7 +23,7 @@ func NewCategoryUsecase(categoryRepo repository.CategoryRepository, accessContro
 }
 
 func (u *CategoryUsecaseImpl) Create(ctx context.Context, userID, coffeeShopID uuid.UUID, category dto.CreateCategory) (uuid.UUID, error) {
-	if err := u.accessControl.CanManageCoffeeShop(ctx, userID, coffeeShopID); err != nil {
+	if err := u.accessControl.Can(ctx, userID, coffeeShopID, models.PermShopManage); err != nil {
 		return uuid.Nil, err
 	}
 
@@ -36,7 +37,7 @@ func (u *CategoryUsecaseImpl) Create(ctx context.Context, userID, coffeeShopID u
 }
 
 func (u *CategoryUsecaseImpl) Update(ctx context.Context, userID, coffeeShopID, categoryID uuid.UUID, category dto.UpdateCategory) error {
-	if err := u.accessControl.CanManageCoffeeShop(ctx, userID, coffeeShopID); err != nil {
+	if err := u.accessControl.Can(ctx, userID, coffeeShopID, models.PermShopManage); err != nil {
 		return err
 	}
 
@@ -51,7 +52,7 @@ func (u *CategoryUsecaseImpl) Update(ctx context.Context, userID, coffeeShopID,
 }
 
 func (u *CategoryUsecaseImpl) Delete(ctx context.Context, userID, coffeeShopID, categoryID uuid.UUID) error {
-	if err := u.accessControl.CanManageCoffeeShop(ctx, userID, coffeeShopID); err != nil {
+	if err := u.accessControl.Can(ctx, userID, coffeeShopID, models.PermShopManage); err != nil {
 		return err
 	}
 
@@ -67,29 +68,80 @@ func (u *CategoryUsecaseImpl) GetByID(ctx context.Context, coffeeShopID, categor
 		return dto.CategoryResponse{}, fmt.Errorf("failed to get category by id: %w", err)
 	}
 
-	return dto.CategoryResponse{
-		ID:           category.ID,
-		CoffeeShopID: category.CoffeeShopID,
-		Title:        category.Title,
-		Description:  category.Description,
-	}, nil
+	return toCategoryResponse(category), nil
+}
+
+func (u *CategoryUsecaseImpl) GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Request) (pagination.Page[dto.CategoryResponse], error) {
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		return pagination.Page[dto.CategoryResponse]{}, err
+	}
+
+	categories, err := u.categoryRepo.GetByCoffeeShop(ctx, coffeeShopID, q)
+	if err != nil {
+		return pagination.Page[dto.CategoryResponse]{}, fmt.Errorf("failed to get categories by coffee shop: %w", err)
+	}
+
+	return pagination.Map(categories, toCategoryResponse), nil
+}
+
+func (u *CategoryUsecaseImpl) CreateInOrganization(ctx context.Context, userID, organizationID uuid.UUID, category dto.CreateCategory) (uuid.UUID, error) {
+	if err := u.accessControl.CanInOrganization(ctx, userID, organizationID, models.PermShopManage); err != nil {
+		return uuid.Nil, err
+	}
+
+	newCategory := &models.Category{
+		OrganizationID: &organizationID,
+		Title:          category.Title,
+		Description:    category.Description,
+	}
+
+	return u.categoryRepo.Create(ctx, newCategory)
+}
+
+func (u *CategoryUsecaseImpl) UpdateInOrganization(ctx context.Context, userID, organizationID, categoryID uuid.UUID, category dto.UpdateCategory) error {
+	if err := u.accessControl.CanInOrganization(ctx, userID, organizationID, models.PermShopManage); err != nil {
+		return err
+	}
+
+	updateCategory := &models.Category{
+		ID:             categoryID,
+		OrganizationID: &organizationID,
+		Title:          category.Title,
+		Description:    category.Description,
+	}
+
+	return u.categoryRepo.UpdateInOrganization(ctx, updateCategory)
 }
 
-func (u *CategoryUsecaseImpl) GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page, pageSize int) ([]dto.CategoryResponse, int, error) {
-	categories, total, err := u.categoryRepo.GetByCoffeeShop(ctx, coffeeShopID, page, pageSize)
+func (u *CategoryUsecaseImpl) DeleteInOrganization(ctx context.Context, userID, organizationID, categoryID uuid.UUID) error {
+	if err := u.accessControl.CanInOrganization(ctx, userID, organizationID, models.PermShopManage); err != nil {
+		return err
+	}
+
+	return u.categoryRepo.DeleteInOrganization(ctx, categoryID, organizationID)
+}
+
+func (u *CategoryUsecaseImpl) GetByOrganization(ctx context.Context, organizationID uuid.UUID, page pagination.Request) (pagination.Page[dto.CategoryResponse], error) {
+	q, err := pagination.NewQuery(page)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get categories by coffee shop: %w", err)
+		return pagination.Page[dto.CategoryResponse]{}, err
 	}
 
-	var categoryResponses []dto.CategoryResponse
-	for _, category := range categories {
-		categoryResponses = append(categoryResponses, dto.CategoryResponse{
-			ID:           category.ID,
-			CoffeeShopID: category.CoffeeShopID,
-			Title:        category.Title,
-			Description:  category.Description,
-		})
+	categories, err := u.categoryRepo.GetByOrganization(ctx, organizationID, q)
+	if err != nil {
+		return pagination.Page[dto.CategoryResponse]{}, fmt.Errorf("failed to get categories by organization: %w", err)
 	}
 
-	return categoryResponses, total, nil
+	return pagination.Map(categories, toCategoryResponse), nil
+}
+
+func toCategoryResponse(category models.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:             category.ID,
+		CoffeeShopID:   category.CoffeeShopID,
+		OrganizationID: category.OrganizationID,
+		Title:          category.Title,
+		Description:    category.Description,
+	}
 }
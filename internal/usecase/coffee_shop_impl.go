@@ -3,32 +3,52 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/geo"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
 
+const (
+	// defaultNearbyRadiusMeters is the radius of a nearby search that does not specify one.
+	defaultNearbyRadiusMeters = 5000
+	// maxNearbyRadiusMeters caps the radius of a nearby search.
+	maxNearbyRadiusMeters = 100000
+	// maxOpeningHours caps the number of opening intervals of a coffee shop.
+	maxOpeningHours = 28
+	// maxCoffeeShopQueryLength caps the length of a search by name.
+	maxCoffeeShopQueryLength = 100
+)
+
 type CoffeeShopUsecaseImpl struct {
-	rep         repository.CoffeeShopRep
-	workerCsRep repository.WorkerCoffeeShopRepository
-	adminRoleID uuid.UUID // Added adminRoleID
-	logger      *slog.Logger
+	rep           repository.CoffeeShopRep
+	workerCsRep   repository.WorkerCoffeeShopRepository
+	accessControl AccessControlUsecase
+	ownerRoleID   uuid.UUID
+	logger        *slog.Logger
 }
 
 func NewCoffeeShopUsecase(rep repository.CoffeeShopRep,
 	workerCsRep repository.WorkerCoffeeShopRepository,
-	adminRoleID uuid.UUID,
+	accessControl AccessControlUsecase,
+	ownerRoleID uuid.UUID,
 	logger *slog.Logger,
 ) CoffeeShopUsecase {
 	return &CoffeeShopUsecaseImpl{
-		rep:         rep,
-		workerCsRep: workerCsRep,
-		adminRoleID: adminRoleID,
-		logger:      logger,
+		rep:           rep,
+		workerCsRep:   workerCsRep,
+		accessControl: accessControl,
+		ownerRoleID:   ownerRoleID,
+		logger:        logger,
 	}
 }
 
@@ -36,8 +56,19 @@ func (u *CoffeeShopUsecaseImpl) CreateCoffeeShop(ctx context.Context, userID uui
 	logger := u.logger.With("method", "CreateCoffeeShop", "userID", userID.String())
 	logger.Debug("starting create coffee shop")
 
+	if err := validateLocation(req.Location); err != nil {
+		logger.Info("invalid coffee shop location", "error", err)
+		return nil, err
+	}
+	hours, err := toOpeningHours(req.OpeningHours)
+	if err != nil {
+		logger.Info("invalid coffee shop opening hours", "error", err)
+		return nil, err
+	}
+
 	shop := toCoffeeShop(req)
-	shop.CreatorID = userID
+	shop.CreatorID = &userID
+	shop.OpeningHours = hours
 	createdShop, err := u.rep.CreateCoffeeShop(ctx, shop)
 	if err != nil {
 		logger.Error("failed to create coffee shop", "error", err.Error())
@@ -46,7 +77,7 @@ func (u *CoffeeShopUsecaseImpl) CreateCoffeeShop(ctx context.Context, userID uui
 	_, err = u.workerCsRep.Create(ctx, &models.WorkerCoffeeShop{
 		CoffeeShopID: &createdShop.ID,
 		WorkerID:     &userID,
-		RoleID:       &u.adminRoleID, // Assign AdminRoleID
+		RoleID:       &u.ownerRoleID, // the creator owns the shop
 	})
 	if err != nil {
 		return nil, err
@@ -59,11 +90,10 @@ func (u *CoffeeShopUsecaseImpl) DeleteCoffeeShop(ctx context.Context, userID uui
 	logger := u.logger.With("method", "DeleteCoffeeShop", "userID", userID.String(), "shopID", ID.String())
 	logger.Debug("starting delete coffee shop")
 
-	_, err := u.getIfCreator(ctx, userID, ID)
-	if err != nil {
+	if err := u.accessControl.Can(ctx, userID, ID, models.PermShopDelete); err != nil {
 		return err
 	}
-	err = u.rep.DeleteCoffeeShop(ctx, ID)
+	err := u.rep.DeleteCoffeeShop(ctx, ID)
 	if err != nil {
 		var errNotFound *apperrors.ErrNotFound
 		if errors.As(err, &errNotFound) {
@@ -78,24 +108,54 @@ func (u *CoffeeShopUsecaseImpl) DeleteCoffeeShop(ctx context.Context, userID uui
 	return nil
 }
 
-func (u *CoffeeShopUsecaseImpl) GetAllCoffeeShops(ctx context.Context, page int, limit int) ([]dto.CoffeeShopResponse, error) {
-	logger := u.logger.With("method", "GetAllCoffeeShops", "page", page, "limit", limit)
+func (u *CoffeeShopUsecaseImpl) GetAllCoffeeShops(ctx context.Context, params dto.GetCoffeeShopsRequest) (pagination.Page[dto.CoffeeShopResponse], error) {
+	logger := u.logger.With("method", "GetAllCoffeeShops", "limit", params.Limit, "query", params.Query, "near", params.Near != nil)
 	logger.Debug("starting get all coffee shops")
 
-	if limit <= 0 || limit > 25 {
-		limit = 25
+	q, err := pagination.NewQuery(params.Request)
+	if err != nil {
+		logger.Info("invalid page request", "error", err.Error())
+		return pagination.Page[dto.CoffeeShopResponse]{}, err
+	}
+
+	query := repository.CoffeeShopQuery{Name: strings.TrimSpace(params.Query), Page: q}
+	if len(query.Name) > maxCoffeeShopQueryLength {
+		logger.Info("search query is too long")
+		return pagination.Page[dto.CoffeeShopResponse]{}, apperrors.NewErrNotValid(fmt.Sprintf("query must be at most %d characters", maxCoffeeShopQueryLength))
 	}
-	if page < 0 {
-		page = 0
+	if params.Near != nil {
+		if err := validateLocation(params.Near); err != nil {
+			logger.Info("invalid nearby point", "error", err)
+			return pagination.Page[dto.CoffeeShopResponse]{}, err
+		}
+		radius := params.RadiusMeters
+		if radius == 0 {
+			radius = defaultNearbyRadiusMeters
+		}
+		if radius < 0 || radius > maxNearbyRadiusMeters {
+			logger.Info("invalid nearby radius", "radius", radius)
+			return pagination.Page[dto.CoffeeShopResponse]{}, apperrors.NewErrNotValid(fmt.Sprintf("radius must be between 1 and %d meters", maxNearbyRadiusMeters))
+		}
+		query.Near = &geo.Point{Latitude: params.Near.Latitude, Longitude: params.Near.Longitude}
+		query.RadiusMeters = float64(radius)
 	}
-	shops, err := u.rep.GetAllCoffeeShops(ctx, limit, limit*page)
+
+	shops, err := u.rep.GetAllCoffeeShops(ctx, query)
 	if err != nil {
 		logger.Error("failed to get all coffee shops", "error", err.Error())
-		return nil, err
+		return pagination.Page[dto.CoffeeShopResponse]{}, err
+	}
+
+	resp := toCoffeeShopResponses(shops.Items)
+	if query.Near != nil {
+		for i, shop := range shops.Items {
+			distance := geo.Distance(*query.Near, geo.Point{Latitude: *shop.Latitude, Longitude: *shop.Longitude})
+			resp[i].DistanceMeters = &distance
+		}
 	}
 
-	logger.Info("coffee shops fetched successfully", "count", len(shops))
-	return toCoffeeShopResponses(shops), nil
+	logger.Info("coffee shops fetched successfully", "count", len(shops.Items))
+	return pagination.WithItems(shops, resp), nil
 }
 
 func (u *CoffeeShopUsecaseImpl) GetCoffeeShop(ctx context.Context, ID uuid.UUID) (*dto.CoffeeShopResponse, error) {
@@ -121,8 +181,27 @@ func (u *CoffeeShopUsecaseImpl) UpdateCoffeeShop(ctx context.Context, userID uui
 	logger := u.logger.With("method", "UpdateCoffeeShop", "userID", userID.String(), "shopID", ID.String())
 	logger.Debug("starting update coffee shop")
 
-	shop, err := u.getIfCreator(ctx, userID, ID)
+	if err := u.accessControl.Can(ctx, userID, ID, models.PermShopManage); err != nil {
+		return err
+	}
+	shop, err := u.rep.GetCoffeeShop(ctx, ID)
 	if err != nil {
+		var errNotFound *apperrors.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			logger.Info("coffee shop to update not found")
+			return err
+		}
+		logger.Error("failed to get coffee shop", "error", err.Error())
+		return err
+	}
+
+	if err := validateLocation(req.Location); err != nil {
+		logger.Info("invalid coffee shop location", "error", err)
+		return err
+	}
+	hours, err := toOpeningHours(req.OpeningHours)
+	if err != nil {
+		logger.Info("invalid coffee shop opening hours", "error", err)
 		return err
 	}
 
@@ -141,6 +220,22 @@ func (u *CoffeeShopUsecaseImpl) UpdateCoffeeShop(ctx context.Context, userID uui
 	if req.Rules != nil {
 		shop.Rules = req.Rules
 	}
+	if req.Country != nil {
+		shop.Country = req.Country
+	}
+	if req.City != nil {
+		shop.City = req.City
+	}
+	if req.Street != nil {
+		shop.Street = req.Street
+	}
+	if req.PostalCode != nil {
+		shop.PostalCode = req.PostalCode
+	}
+	if req.Location != nil {
+		shop.Latitude = &req.Location.Latitude
+		shop.Longitude = &req.Location.Longitude
+	}
 
 	err = u.rep.UpdateCoffeeShop(ctx, shop)
 	if err != nil {
@@ -152,30 +247,55 @@ func (u *CoffeeShopUsecaseImpl) UpdateCoffeeShop(ctx context.Context, userID uui
 		logger.Error("failed to update coffee shop", "error", err.Error())
 		return err
 	}
+	if req.OpeningHours != nil {
+		if err := u.rep.SetOpeningHours(ctx, ID, hours); err != nil {
+			logger.Error("failed to set opening hours", "error", err.Error())
+			return err
+		}
+	}
 
 	logger.Info("coffee shop updated successfully")
 	return nil
 }
 
 func toCoffeeShop(req *dto.CreateCoffeeShopRequest) *models.CoffeeShop {
-	return &models.CoffeeShop{
+	shop := &models.CoffeeShop{
 		Name:           req.Name,
 		Address:        req.Address,
+		Country:        req.Country,
+		City:           req.City,
+		Street:         req.Street,
+		PostalCode:     req.PostalCode,
 		Contacts:       req.Contacts,
 		WelcomeMessage: req.WelcomeMessage,
 		Rules:          req.Rules,
 	}
+	if req.Location != nil {
+		shop.Latitude = &req.Location.Latitude
+		shop.Longitude = &req.Location.Longitude
+	}
+	return shop
 }
 
 func toCoffeeShopResponse(shop *models.CoffeeShop) *dto.CoffeeShopResponse {
-	return &dto.CoffeeShopResponse{
+	resp := &dto.CoffeeShopResponse{
 		ID:             shop.ID,
 		Name:           shop.Name,
 		Address:        shop.Address,
+		Country:        shop.Country,
+		City:           shop.City,
+		Street:         shop.Street,
+		PostalCode:     shop.PostalCode,
+		OpeningHours:   toOpeningHoursResponse(shop.OpeningHours),
 		Contacts:       shop.Contacts,
 		WelcomeMessage: shop.WelcomeMessage,
 		Rules:          shop.Rules,
+		OrganizationID: shop.OrganizationID,
+	}
+	if shop.Latitude != nil && shop.Longitude != nil {
+		resp.Location = &dto.Location{Latitude: *shop.Latitude, Longitude: *shop.Longitude}
 	}
+	return resp
 }
 
 func toCoffeeShopResponses(shops []models.CoffeeShop) []dto.CoffeeShopResponse {
@@ -187,24 +307,48 @@ func toCoffeeShopResponses(shops []models.CoffeeShop) []dto.CoffeeShopResponse {
 	return res
 }
 
-func (u *CoffeeShopUsecaseImpl) getIfCreator(ctx context.Context, userID uuid.UUID, shopID uuid.UUID) (*models.CoffeeShop, error) {
-	logger := u.logger.With("method", "getIfCreator", "userID", userID.String(), "shopID", shopID.String())
-	logger.Debug("checking if user is creator of coffee shop")
+// validateLocation accepts a missing location or one with coordinates in range.
+func validateLocation(l *dto.Location) error {
+	if l == nil {
+		return nil
+	}
+	if !(geo.Point{Latitude: l.Latitude, Longitude: l.Longitude}).Valid() {
+		return apperrors.NewErrNotValid("latitude must be between -90 and 90 and longitude between -180 and 180")
+	}
+	return nil
+}
 
-	shop, err := u.rep.GetCoffeeShop(ctx, shopID)
-	if err != nil {
-		var errNotFound *apperrors.ErrNotFound
-		if errors.As(err, &errNotFound) {
-			logger.Info("coffee shop not found for creator check")
-			return nil, err
+// toOpeningHours validates the opening intervals of a request.
+func toOpeningHours(hours []dto.OpeningHours) ([]models.OpeningHours, error) {
+	if len(hours) > maxOpeningHours {
+		return nil, apperrors.NewErrNotValid(fmt.Sprintf("a coffee shop can have at most %d opening intervals", maxOpeningHours))
+	}
+	res := make([]models.OpeningHours, len(hours))
+	for i, h := range hours {
+		if h.Weekday < 0 || h.Weekday > 6 {
+			return nil, apperrors.NewErrNotValid("weekday must be between 0 (Sunday) and 6 (Saturday)")
 		}
-		logger.Error("failed to get coffee shop for creator check", "error", err.Error())
-		return nil, err
+		for _, t := range []string{h.Opens, h.Closes} {
+			if _, err := time.Parse("15:04", t); err != nil || len(t) != len("15:04") {
+				return nil, apperrors.NewErrNotValid(fmt.Sprintf("invalid time %q, expected HH:MM", t))
+			}
+		}
+		res[i] = models.OpeningHours{Weekday: h.Weekday, Opens: h.Opens, Closes: h.Closes}
 	}
-	if userID != shop.CreatorID {
-		logger.Info("user is not creator of the coffee shop", "creatorID", shop.CreatorID)
-		return nil, apperrors.NewErrUnauthorized("access denied")
+	return res, nil
+}
+
+// toOpeningHoursResponse lists the opening intervals by weekday and opening time.
+func toOpeningHoursResponse(hours []models.OpeningHours) []dto.OpeningHours {
+	res := make([]dto.OpeningHours, len(hours))
+	for i, h := range hours {
+		res[i] = dto.OpeningHours{Weekday: h.Weekday, Opens: h.Opens, Closes: h.Closes}
 	}
-	logger.Debug("user is confirmed as creator")
-	return shop, nil
+	slices.SortFunc(res, func(a, b dto.OpeningHours) int {
+		if a.Weekday != b.Weekday {
+			return a.Weekday - b.Weekday
+		}
+		return strings.Compare(a.Opens, b.Opens)
+	})
+	return res
 }
@@ -8,28 +8,32 @@ import (
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
 
 type commentUsecase struct {
-	commentRepo          repository.CommentRepository
-	ideaRepo             repository.IdeaRepository
-	workerCoffeeShopRepo repository.WorkerCoffeeShopRepository
-	logger               *slog.Logger
+	commentRepo   repository.CommentRepository
+	ideaRepo      repository.IdeaRepository
+	accessControl AccessControlUsecase
+	banRepo       repository.BannedUserRepository
+	logger        *slog.Logger
 }
 
 func NewCommentUsecase(
 	commentRepo repository.CommentRepository,
 	ideaRepo repository.IdeaRepository,
-	workerCoffeeShopRepo repository.WorkerCoffeeShopRepository,
+	accessControl AccessControlUsecase,
+	banRepo repository.BannedUserRepository,
 	logger *slog.Logger,
 ) CommentUsecase {
 	return &commentUsecase{
-		commentRepo:          commentRepo,
-		ideaRepo:             ideaRepo,
-		workerCoffeeShopRepo: workerCoffeeShopRepo,
-		logger:               logger,
+		commentRepo:   commentRepo,
+		ideaRepo:      ideaRepo,
+		accessControl: accessControl,
+		banRepo:       banRepo,
+		logger:        logger,
 	}
 }
 
@@ -50,15 +54,12 @@ func (uc *commentUsecase) CreateComment(ctx context.Context, actorID, ideaID uui
 		return nil, errors.New("idea is not associated with a coffee shop")
 	}
 
+	if err := CheckNotBanned(ctx, l, uc.banRepo, actorID, *idea.CoffeeShopID); err != nil {
+		return nil, err
+	}
+
 	// Check if the actor is a worker in the coffee shop associated with the idea
-	_, err = uc.workerCoffeeShopRepo.GetByUserIDAndShopID(ctx, actorID, *idea.CoffeeShopID)
-	if err != nil {
-		var errNotFound *apperrors.ErrNotFound
-		if errors.As(err, &errNotFound) {
-			l.Warn("access denied: user is not worker for this coffee shop", slog.String("error", err.Error()))
-			return nil, apperrors.NewErrAccessDenied("user is not a worker for this coffee shop")
-		}
-		l.Error("failed to check worker status", slog.String("error", err.Error()))
+	if err := uc.accessControl.CanAccessAsWorker(ctx, actorID, *idea.CoffeeShopID); err != nil {
 		return nil, err
 	}
 
@@ -83,7 +84,7 @@ func (uc *commentUsecase) CreateComment(ctx context.Context, actorID, ideaID uui
 	}, nil
 }
 
-func (uc *commentUsecase) GetCommentsByIdeaID(ctx context.Context, actorID, ideaID uuid.UUID, params dto.GetCommentsRequest) ([]dto.CommentResponse, error) {
+func (uc *commentUsecase) GetCommentsByIdeaID(ctx context.Context, actorID, ideaID uuid.UUID, page pagination.Request) (pagination.Page[dto.CommentResponse], error) {
 	l := uc.logger.With("method", "GetCommentsByIdeaID", "actorID", actorID, "ideaID", ideaID)
 
 	idea, err := uc.ideaRepo.GetIdea(ctx, ideaID)
@@ -91,45 +92,40 @@ func (uc *commentUsecase) GetCommentsByIdeaID(ctx context.Context, actorID, idea
 		l.Error("failed to get idea", slog.String("error", err.Error()))
 		var errNotFound *apperrors.ErrNotFound
 		if errors.As(err, &errNotFound) {
-			return nil, apperrors.NewErrNotFound("idea", ideaID.String())
+			return pagination.Page[dto.CommentResponse]{}, apperrors.NewErrNotFound("idea", ideaID.String())
 		}
-		return nil, err
+		return pagination.Page[dto.CommentResponse]{}, err
 	}
 	if idea.CoffeeShopID == nil {
 		l.Error("idea has no associated coffee shop ID", slog.Any("idea", idea))
-		return nil, errors.New("idea is not associated with a coffee shop")
+		return pagination.Page[dto.CommentResponse]{}, errors.New("idea is not associated with a coffee shop")
 	}
 
 	// Check if the actor is a worker in the coffee shop associated with the idea
-	_, err = uc.workerCoffeeShopRepo.GetByUserIDAndShopID(ctx, actorID, *idea.CoffeeShopID)
-	if err != nil {
-		var errNotFound *apperrors.ErrNotFound
-		if errors.As(err, &errNotFound) {
-			l.Warn("access denied: user is not worker for this coffee shop", slog.String("error", err.Error()))
-			return nil, apperrors.NewErrAccessDenied("user is not a worker for this coffee shop")
-		}
-		l.Error("failed to check worker status", slog.String("error", err.Error()))
-		return nil, err
+	if err := uc.accessControl.CanAccessAsWorker(ctx, actorID, *idea.CoffeeShopID); err != nil {
+		return pagination.Page[dto.CommentResponse]{}, err
 	}
 
-	limit, offset := calculatePagination(params.Page, params.Limit)
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		l.Info("invalid page request", slog.String("error", err.Error()))
+		return pagination.Page[dto.CommentResponse]{}, err
+	}
 
-	comments, err := uc.commentRepo.GetByIdeaID(ctx, ideaID, limit, offset)
+	comments, err := uc.commentRepo.GetByIdeaID(ctx, ideaID, q)
 	if err != nil {
 		l.Error("failed to get comments by idea ID", slog.String("error", err.Error()))
-		return nil, err
+		return pagination.Page[dto.CommentResponse]{}, err
 	}
 
-	var responses []dto.CommentResponse
-	for _, comment := range comments {
-		responses = append(responses, dto.CommentResponse{
+	return pagination.Map(comments, func(comment models.IdeaComment) dto.CommentResponse {
+		return dto.CommentResponse{
 			ID:         comment.ID,
 			Text:       comment.Text,
 			AuthorName: comment.AuthorName,
 			CreatedAt:  comment.CreatedAt,
-		})
-	}
-	return responses, nil
+		}
+	}), nil
 }
 
 func (uc *commentUsecase) DeleteComment(ctx context.Context, actorID, ideaID, commentID uuid.UUID) error {
@@ -150,14 +146,7 @@ func (uc *commentUsecase) DeleteComment(ctx context.Context, actorID, ideaID, co
 	}
 
 	// Check if the actor is a worker in the coffee shop associated with the idea
-	_, err = uc.workerCoffeeShopRepo.GetByUserIDAndShopID(ctx, actorID, *idea.CoffeeShopID)
-	if err != nil {
-		var errNotFound *apperrors.ErrNotFound
-		if errors.As(err, &errNotFound) {
-			l.Warn("access denied: user is not worker for this coffee shop", slog.String("error", err.Error()))
-			return apperrors.NewErrAccessDenied("user is not a worker for this coffee shop")
-		}
-		l.Error("failed to check worker status", slog.String("error", err.Error()))
+	if err := uc.accessControl.CanAccessAsWorker(ctx, actorID, *idea.CoffeeShopID); err != nil {
 		return err
 	}
 
@@ -172,7 +161,14 @@ func (uc *commentUsecase) DeleteComment(ctx context.Context, actorID, ideaID, co
 		return apperrors.NewErrNotValid("comment does not belong to this idea")
 	}
 
-	// Any worker of the coffee shop can delete a comment
+	// Workers can delete their own comments, others need the comment.delete permission
+	isCreator := comment.CreatorID != nil && *comment.CreatorID == actorID
+	if !isCreator {
+		if err := uc.accessControl.Can(ctx, actorID, *idea.CoffeeShopID, models.PermCommentDelete); err != nil {
+			return err
+		}
+	}
+
 	if err := uc.commentRepo.Delete(ctx, commentID); err != nil {
 		l.Error("failed to delete comment", slog.String("error", err.Error()))
 		return err
@@ -180,15 +176,3 @@ func (uc *commentUsecase) DeleteComment(ctx context.Context, actorID, ideaID, co
 
 	return nil
 }
-
-// calculatePagination helper function
-func calculatePagination(page, limit int) (int, int) {
-	if limit <= 0 {
-		limit = 10 // Default limit
-	}
-	if page <= 0 {
-		page = 1 // Default page
-	}
-	offset := (page - 1) * limit
-	return limit, offset
-}
@@ -3,14 +3,19 @@ package usecase
 import (
 	"context"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
 type IdeaUsecase interface {
-	GetIdea(ctx context.Context, ideaID uuid.UUID) (*dto.IdeaResponse, error)
-	GetAllIdeasByShop(ctx context.Context, shopID uuid.UUID, params dto.GetIdeasRequest) ([]dto.IdeaResponse, error)
-	GetAllIdeasByUser(ctx context.Context, userID uuid.UUID, params dto.GetIdeasRequest) ([]dto.IdeaResponse, error)
+	GetIdea(ctx context.Context, ideaID uuid.UUID, viewerID *uuid.UUID) (*dto.IdeaResponse, error)
+	GetAllIdeasByShop(ctx context.Context, shopID uuid.UUID, params dto.GetIdeasRequest) (pagination.Page[dto.IdeaResponse], error)
+	GetAllIdeasByUser(ctx context.Context, userID uuid.UUID, params dto.GetIdeasRequest) (pagination.Page[dto.IdeaResponse], error)
+	SearchIdeas(ctx context.Context, shopID uuid.UUID, params dto.SearchIdeasRequest) (pagination.Page[dto.IdeaResponse], error)
+	// SearchIdeasInOrganization searches ideas across all coffee shops of the organization.
+	SearchIdeasInOrganization(ctx context.Context, organizationID uuid.UUID, params dto.SearchIdeasRequest) (pagination.Page[dto.IdeaResponse], error)
 	CreateIdea(ctx context.Context, userID uuid.UUID, req *dto.CreateIdeaRequest, imageURL *string) (*dto.IdeaResponse, error)
 	UpdateIdea(ctx context.Context, userID, ideaID uuid.UUID, req *dto.UpdateIdeaRequest) error
 	DeleteIdea(ctx context.Context, userID, ideaID uuid.UUID) error
+	GetStatusHistory(ctx context.Context, ideaID uuid.UUID) ([]dto.IdeaStatusHistoryResponse, error)
 }
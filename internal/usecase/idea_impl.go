@@ -3,30 +3,40 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/imageproc"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
 
 type IdeaUsecaseImpl struct {
-	ideaRepo     repository.IdeaRepository
-	workerCsRepo repository.WorkerCoffeeShopRepository
-	likeRepo     repository.LikeRepository
-	statusRepo   repository.IdeaStatusRepository
+	ideaRepo      repository.IdeaRepository
+	accessControl AccessControlUsecase
+	likeRepo      repository.LikeRepository
+	statusRepo    repository.IdeaStatusRepository
+	banRepo       repository.BannedUserRepository
+	// imageUsecase finalizes the direct uploads attached to ideas and checks the images
+	// attached by URL.
+	imageUsecase ImageUsecase
 	logger       *slog.Logger
 }
 
-func NewIdeaUsecase(ideaRepo repository.IdeaRepository, workerCsRepo repository.WorkerCoffeeShopRepository, likeRepo repository.LikeRepository, statusRepo repository.IdeaStatusRepository, logger *slog.Logger) IdeaUsecase {
+func NewIdeaUsecase(ideaRepo repository.IdeaRepository, accessControl AccessControlUsecase, likeRepo repository.LikeRepository, statusRepo repository.IdeaStatusRepository, banRepo repository.BannedUserRepository, imageUsecase ImageUsecase, logger *slog.Logger) IdeaUsecase {
 	return &IdeaUsecaseImpl{
-		ideaRepo:     ideaRepo,
-		workerCsRepo: workerCsRepo,
-		likeRepo:     likeRepo,
-		statusRepo:   statusRepo,
-		logger:       logger,
+		ideaRepo:      ideaRepo,
+		accessControl: accessControl,
+		likeRepo:      likeRepo,
+		statusRepo:    statusRepo,
+		banRepo:       banRepo,
+		imageUsecase:  imageUsecase,
+		logger:        logger,
 	}
 }
 
@@ -37,24 +47,21 @@ func (u *IdeaUsecaseImpl) CreateIdea(ctx context.Context, userID uuid.UUID, req
 	csID := uuid.UUID(req.CoffeeShopID)
 	catID := uuid.UUID(req.CategoryID)
 
-	// Fetch default status "Создана"
-	status, err := u.statusRepo.GetByTitle(ctx, "Создана")
-	var statusID *uuid.UUID
+	if err := CheckNotBanned(ctx, logger, u.banRepo, userID, csID); err != nil {
+		return nil, err
+	}
+
+	status, err := u.initialStatus(ctx, csID)
 	if err != nil {
-		logger.Error("failed to get default status 'Создана'", "error", err.Error())
-		// Decide if we should fail or proceed with nil status. 
-		// Proceeding with nil might be safer if the DB wasn't initialized correctly, 
-		// but ideally "Created" should exist.
-		// For now, let's proceed with nil but log the error.
-	} else {
-		statusID = &status.ID
+		logger.Error("failed to get initial status", "error", err.Error())
+		return nil, err
 	}
 
 	idea := &models.Idea{
 		CreatorID:    &userID,
 		CoffeeShopID: &csID,
 		CategoryID:   &catID,
-		StatusID:     statusID,
+		StatusID:     &status.ID,
 		Title:        req.Title,
 		Description:  req.Description,
 		ImageURL:     imageURL,
@@ -65,12 +72,13 @@ func (u *IdeaUsecaseImpl) CreateIdea(ctx context.Context, userID uuid.UUID, req
 		logger.Error("failed to create idea", "error", err.Error())
 		return nil, err
 	}
+	createdIdea.Status = status
 
 	logger.Info("idea created successfully", "ideaID", createdIdea.ID.String())
 	return toIdeaResponse(createdIdea, 0), nil
 }
 
-func (u *IdeaUsecaseImpl) GetIdea(ctx context.Context, ideaID uuid.UUID) (*dto.IdeaResponse, error) {
+func (u *IdeaUsecaseImpl) GetIdea(ctx context.Context, ideaID uuid.UUID, viewerID *uuid.UUID) (*dto.IdeaResponse, error) {
 	logger := u.logger.With("method", "GetIdea", "ideaID", ideaID.String())
 	logger.Debug("starting get idea")
 
@@ -91,56 +99,118 @@ func (u *IdeaUsecaseImpl) GetIdea(ctx context.Context, ideaID uuid.UUID) (*dto.I
 		return nil, err
 	}
 
+	res := toIdeaResponse(idea, int(likes))
+	if viewerID != nil {
+		res.LikedByMe, err = u.likeRepo.HasUserLiked(ctx, *viewerID, idea.ID)
+		if err != nil {
+			logger.Error("failed to check if viewer liked idea", "error", err.Error())
+			return nil, err
+		}
+	}
+
 	logger.Info("idea fetched successfully")
-	return toIdeaResponse(idea, int(likes)), nil
+	return res, nil
 }
 
-func (u *IdeaUsecaseImpl) GetAllIdeasByShop(ctx context.Context, shopID uuid.UUID, params dto.GetIdeasRequest) ([]dto.IdeaResponse, error) {
-	logger := u.logger.With("method", "GetAllIdeasByShop", "shopID", shopID.String(), "page", params.Page, "limit", params.Limit, "sort", params.Sort)
+func (u *IdeaUsecaseImpl) GetAllIdeasByShop(ctx context.Context, shopID uuid.UUID, params dto.GetIdeasRequest) (pagination.Page[dto.IdeaResponse], error) {
+	logger := u.logger.With("method", "GetAllIdeasByShop", "shopID", shopID.String(), "limit", params.Limit, "sort", params.Sort)
 	logger.Debug("starting get all ideas by shop")
 
-	if params.Limit <= 0 || params.Limit > 50 {
-		params.Limit = 25
-	}
-	if params.Page < 0 {
-		params.Page = 0
+	q, err := pagination.NewQuery(params.Request)
+	if err != nil {
+		logger.Info("invalid page request", "error", err.Error())
+		return pagination.Page[dto.IdeaResponse]{}, err
 	}
 
-	ideas, err := u.ideaRepo.GetAllIdeasByShop(ctx, shopID, params.Limit, params.Page*params.Limit, params.Sort)
+	ideas, err := u.ideaRepo.GetAllIdeasByShop(ctx, shopID, q, params.Sort)
 	if err != nil {
 		logger.Error("failed to get all ideas by shop", "error", err.Error())
-		return nil, err
+		return pagination.Page[dto.IdeaResponse]{}, err
 	}
 
-	logger.Info("ideas by shop fetched successfully", "count", len(ideas))
-	return toIdeaResponses(ctx, ideas, u.likeRepo), nil
+	logger.Info("ideas by shop fetched successfully", "count", len(ideas.Items))
+	return u.toIdeaPage(ctx, ideas, params.ViewerID)
 }
 
-func (u *IdeaUsecaseImpl) GetAllIdeasByUser(ctx context.Context, userID uuid.UUID, params dto.GetIdeasRequest) ([]dto.IdeaResponse, error) {
-	logger := u.logger.With("method", "GetAllIdeasByUser", "userID", userID.String(), "page", params.Page, "limit", params.Limit, "sort", params.Sort)
+func (u *IdeaUsecaseImpl) GetAllIdeasByUser(ctx context.Context, userID uuid.UUID, params dto.GetIdeasRequest) (pagination.Page[dto.IdeaResponse], error) {
+	logger := u.logger.With("method", "GetAllIdeasByUser", "userID", userID.String(), "limit", params.Limit, "sort", params.Sort)
 	logger.Debug("starting get all ideas by user")
 
-	if params.Limit <= 0 || params.Limit > 50 {
-		params.Limit = 25
-	}
-	if params.Page < 0 {
-		params.Page = 0
+	q, err := pagination.NewQuery(params.Request)
+	if err != nil {
+		logger.Info("invalid page request", "error", err.Error())
+		return pagination.Page[dto.IdeaResponse]{}, err
 	}
 
-	ideas, err := u.ideaRepo.GetAllIdeasByUser(ctx, userID, params.Limit, params.Page*params.Limit, params.Sort)
+	ideas, err := u.ideaRepo.GetAllIdeasByUser(ctx, userID, q, params.Sort)
 	if err != nil {
 		logger.Error("failed to get all ideas by user", "error", err.Error())
-		return nil, err
+		return pagination.Page[dto.IdeaResponse]{}, err
 	}
 
-	logger.Info("ideas by user fetched successfully", "count", len(ideas))
-	return toIdeaResponses(ctx, ideas, u.likeRepo), nil
+	logger.Info("ideas by user fetched successfully", "count", len(ideas.Items))
+	return u.toIdeaPage(ctx, ideas, params.ViewerID)
+}
+
+func (u *IdeaUsecaseImpl) SearchIdeas(ctx context.Context, shopID uuid.UUID, params dto.SearchIdeasRequest) (pagination.Page[dto.IdeaResponse], error) {
+	logger := u.logger.With("method", "SearchIdeas", "shopID", shopID.String(), "query", params.Query, "limit", params.Limit, "sort", params.Sort)
+	return u.searchIdeas(ctx, logger, repository.IdeaQuery{CoffeeShopID: &shopID}, params)
+}
+
+func (u *IdeaUsecaseImpl) SearchIdeasInOrganization(ctx context.Context, organizationID uuid.UUID, params dto.SearchIdeasRequest) (pagination.Page[dto.IdeaResponse], error) {
+	logger := u.logger.With("method", "SearchIdeasInOrganization", "organizationID", organizationID.String(), "query", params.Query, "limit", params.Limit, "sort", params.Sort)
+	return u.searchIdeas(ctx, logger, repository.IdeaQuery{OrganizationID: &organizationID}, params)
+}
+
+// searchIdeas applies the search parameters to the scope of the query and runs it.
+func (u *IdeaUsecaseImpl) searchIdeas(ctx context.Context, logger *slog.Logger, scope repository.IdeaQuery, params dto.SearchIdeasRequest) (pagination.Page[dto.IdeaResponse], error) {
+	logger.Debug("starting search ideas")
+
+	if params.CreatedFrom != nil && params.CreatedTo != nil && params.CreatedFrom.After(*params.CreatedTo) {
+		logger.Info("invalid date range")
+		return pagination.Page[dto.IdeaResponse]{}, apperrors.NewErrNotValid("created_from must not be after created_to")
+	}
+	if len(params.Query) > 200 {
+		return pagination.Page[dto.IdeaResponse]{}, apperrors.NewErrNotValid("search query is too long")
+	}
+
+	q, err := pagination.NewQuery(params.Request)
+	if err != nil {
+		logger.Info("invalid page request", "error", err.Error())
+		return pagination.Page[dto.IdeaResponse]{}, err
+	}
+
+	ideas, err := u.ideaRepo.SearchIdeas(ctx, repository.IdeaQuery{
+		CoffeeShopID:   scope.CoffeeShopID,
+		OrganizationID: scope.OrganizationID,
+		Text:           strings.TrimSpace(params.Query),
+		StatusIDs:      params.StatusIDs,
+		CategoryIDs:    params.CategoryIDs,
+		CreatorID:      params.CreatorID,
+		CreatedFrom:    params.CreatedFrom,
+		CreatedTo:      params.CreatedTo,
+		HasImage:       params.HasImage,
+		Sort:           params.Sort,
+		Page:           q,
+	})
+	if err != nil {
+		logger.Error("failed to search ideas", "error", err.Error())
+		return pagination.Page[dto.IdeaResponse]{}, err
+	}
+
+	logger.Info("ideas searched successfully", "count", len(ideas.Items))
+	return u.toIdeaPage(ctx, ideas, params.ViewerID)
 }
 
 func (u *IdeaUsecaseImpl) UpdateIdea(ctx context.Context, userID, ideaID uuid.UUID, req *dto.UpdateIdeaRequest) error {
 	logger := u.logger.With("method", "UpdateIdea", "userID", userID.String(), "ideaID", ideaID.String())
 	logger.Debug("starting update idea")
 
+	if req.ImageURL != nil && req.UploadID != nil {
+		logger.Info("both image url and upload id given")
+		return apperrors.NewErrNotValid("image_url and upload_id are mutually exclusive")
+	}
+
 	idea, err := u.ideaRepo.GetIdea(ctx, ideaID)
 	if err != nil {
 		var errNotFound *apperrors.ErrNotFound
@@ -158,9 +228,9 @@ func (u *IdeaUsecaseImpl) UpdateIdea(ctx context.Context, userID, ideaID uuid.UU
 			logger.Info("access denied: idea has no coffee shop and user is not creator")
 			return apperrors.NewErrAccessDenied("access denied")
 		}
-		err := CheckShopAdminAccess(ctx, u.logger, u.workerCsRepo, userID, *idea.CoffeeShopID)
+		err := u.accessControl.Can(ctx, userID, *idea.CoffeeShopID, models.PermIdeaModerate)
 		if err != nil {
-			logger.Info("access denied: user is not creator or shop admin")
+			logger.Info("access denied: user is not creator or shop moderator")
 			return err
 		}
 	}
@@ -168,14 +238,15 @@ func (u *IdeaUsecaseImpl) UpdateIdea(ctx context.Context, userID, ideaID uuid.UU
 	if req.CategoryID != nil {
 		idea.CategoryID = req.CategoryID
 	}
-	if req.StatusID != nil {
+	var statusChange *models.IdeaStatusHistory
+	if req.StatusID != nil && (idea.StatusID == nil || *idea.StatusID != *req.StatusID) {
 		if idea.CoffeeShopID == nil {
 			logger.Info("access denied: idea has no coffee shop for status update")
 			return apperrors.NewErrAccessDenied("access denied")
 		}
-		err := CheckShopAdminAccess(ctx, u.logger, u.workerCsRepo, userID, *idea.CoffeeShopID)
+		err := u.accessControl.Can(ctx, userID, *idea.CoffeeShopID, models.PermIdeaStatusChange)
 		if err != nil {
-			logger.Info("access denied: only admin can change status")
+			logger.Info("access denied: user cannot change status")
 			return err
 		}
 		status, err := u.statusRepo.GetByID(ctx, *req.StatusID)
@@ -183,6 +254,16 @@ func (u *IdeaUsecaseImpl) UpdateIdea(ctx context.Context, userID, ideaID uuid.UU
 			logger.Error("failed to get status", "error", err.Error())
 			return err
 		}
+		if err := u.checkStatusTransition(ctx, idea, status); err != nil {
+			logger.Info("status transition rejected", "from", idea.StatusID, "to", status.ID, "error", err.Error())
+			return err
+		}
+		statusChange = &models.IdeaStatusHistory{
+			FromStatusID: idea.StatusID,
+			ToStatusID:   req.StatusID,
+			ActorID:      &userID,
+			Note:         req.StatusNote,
+		}
 		idea.StatusID = req.StatusID
 		idea.Status = status
 	}
@@ -193,10 +274,23 @@ func (u *IdeaUsecaseImpl) UpdateIdea(ctx context.Context, userID, ideaID uuid.UU
 		idea.Description = *req.Description
 	}
 	if req.ImageURL != nil {
+		if err := u.imageUsecase.CheckStored(ctx, *req.ImageURL); err != nil {
+			logger.Info("image is not stored", "imageURL", *req.ImageURL, "error", err.Error())
+			return err
+		}
 		idea.ImageURL = req.ImageURL
 	}
+	if req.UploadID != nil {
+		// Finalized last, so that an update that is rejected stores no image.
+		imageURL, err := u.imageUsecase.FinalizeUpload(ctx, userID, *req.UploadID)
+		if err != nil {
+			logger.Info("failed to finalize upload", "uploadID", req.UploadID.String(), "error", err.Error())
+			return err
+		}
+		idea.ImageURL = &imageURL
+	}
 
-	err = u.ideaRepo.UpdateIdea(ctx, idea)
+	err = u.ideaRepo.UpdateIdea(ctx, idea, statusChange)
 	if err != nil {
 		logger.Error("failed to update idea", "error", err.Error())
 		return err
@@ -206,6 +300,35 @@ func (u *IdeaUsecaseImpl) UpdateIdea(ctx context.Context, userID, ideaID uuid.UU
 	return nil
 }
 
+func (u *IdeaUsecaseImpl) GetStatusHistory(ctx context.Context, ideaID uuid.UUID) ([]dto.IdeaStatusHistoryResponse, error) {
+	logger := u.logger.With("method", "GetStatusHistory", "ideaID", ideaID.String())
+	logger.Debug("starting get status history")
+
+	if _, err := u.ideaRepo.GetIdea(ctx, ideaID); err != nil {
+		var errNotFound *apperrors.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			logger.Info("idea not found")
+			return nil, err
+		}
+		logger.Error("failed to get idea", "error", err.Error())
+		return nil, err
+	}
+
+	history, err := u.ideaRepo.GetStatusHistory(ctx, ideaID)
+	if err != nil {
+		logger.Error("failed to get status history", "error", err.Error())
+		return nil, err
+	}
+
+	res := make([]dto.IdeaStatusHistoryResponse, len(history))
+	for i, h := range history {
+		res[i] = toIdeaStatusHistoryResponse(h)
+	}
+
+	logger.Info("status history fetched successfully", "count", len(res))
+	return res, nil
+}
+
 func (u *IdeaUsecaseImpl) DeleteIdea(ctx context.Context, userID, ideaID uuid.UUID) error {
 	logger := u.logger.With("method", "DeleteIdea", "userID", userID.String(), "ideaID", ideaID.String())
 	logger.Debug("starting delete idea")
@@ -224,15 +347,15 @@ func (u *IdeaUsecaseImpl) DeleteIdea(ctx context.Context, userID, ideaID uuid.UU
 	// Check if user is the creator
 	isCreator := idea.CreatorID != nil && userID == *idea.CreatorID
 
-	// If not the creator, check if they are an admin of the coffee shop
+	// If not the creator, check if they can moderate ideas of the coffee shop
 	if !isCreator {
 		if idea.CoffeeShopID == nil {
 			logger.Info("access denied: idea has no coffee shop and user is not creator")
 			return apperrors.NewErrAccessDenied("access denied")
 		}
-		err := CheckShopAdminAccess(ctx, u.logger, u.workerCsRepo, userID, *idea.CoffeeShopID)
+		err := u.accessControl.Can(ctx, userID, *idea.CoffeeShopID, models.PermIdeaModerate)
 		if err != nil {
-			logger.Info("access denied: user is not creator or shop admin")
+			logger.Info("access denied: user is not creator or shop moderator")
 			return err
 		}
 	}
@@ -247,6 +370,48 @@ func (u *IdeaUsecaseImpl) DeleteIdea(ctx context.Context, userID, ideaID uuid.UU
 	return nil
 }
 
+// initialStatus returns the status new ideas of the coffee shop start in.
+// Shops that have not configured a workflow get the default one.
+func (u *IdeaUsecaseImpl) initialStatus(ctx context.Context, shopID uuid.UUID) (models.IdeaStatus, error) {
+	status, err := u.statusRepo.GetInitial(ctx, shopID)
+	var errNotFound *apperrors.ErrNotFound
+	if err == nil || !errors.As(err, &errNotFound) {
+		return status, err
+	}
+
+	status, err = u.statusRepo.EnsureDefaultWorkflow(ctx, shopID)
+	if errors.As(err, &errNotFound) {
+		return status, apperrors.NewErrNotValid("coffee shop has no initial idea status")
+	}
+	return status, err
+}
+
+// checkStatusTransition verifies that the coffee shop workflow allows moving the idea to the status.
+// Ideas without a status or in a status outside of the workflow may be moved to any status of the shop.
+func (u *IdeaUsecaseImpl) checkStatusTransition(ctx context.Context, idea *models.Idea, to models.IdeaStatus) error {
+	if to.CoffeeShopID == nil || *to.CoffeeShopID != *idea.CoffeeShopID {
+		return apperrors.NewErrNotValid("status does not belong to the coffee shop of the idea")
+	}
+
+	from := idea.Status
+	inWorkflow := idea.StatusID != nil && !from.IsDeleted && from.CoffeeShopID != nil && *from.CoffeeShopID == *idea.CoffeeShopID
+	if !inWorkflow {
+		return nil
+	}
+	if from.IsTerminal {
+		return apperrors.NewErrNotValid(fmt.Sprintf("idea is in terminal status %q", from.Title))
+	}
+
+	allowed, err := u.statusRepo.IsTransitionAllowed(ctx, from.ID, to.ID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperrors.NewErrNotValid(fmt.Sprintf("transition from %q to %q is not allowed", from.Title, to.Title))
+	}
+	return nil
+}
+
 func (u *IdeaUsecaseImpl) getIfCreator(ctx context.Context, userID, ideaID uuid.UUID) (*models.Idea, error) {
 	logger := u.logger.With("method", "getIfCreator", "userID", userID.String(), "ideaID", ideaID.String())
 	logger.Debug("checking if user is creator of idea")
@@ -273,32 +438,67 @@ func (u *IdeaUsecaseImpl) getIfCreator(ctx context.Context, userID, ideaID uuid.
 
 func toIdeaResponse(idea *models.Idea, likes int) *dto.IdeaResponse {
 	return &dto.IdeaResponse{
-		ID:           idea.ID,
-		CreatorID:    idea.CreatorID,
-		CoffeeShopID: idea.CoffeeShopID,
-		CategoryID:   idea.CategoryID,
-		StatusID:     idea.StatusID,
-		StatusName:   idea.Status.Title,
-		Title:        idea.Title,
-		Description:  idea.Description,
-		ImageURL:     idea.ImageURL,
-		Likes:        likes,
-		CreatedAt:    idea.CreatedAt,
+		ID:                idea.ID,
+		CreatorID:         idea.CreatorID,
+		CoffeeShopID:      idea.CoffeeShopID,
+		CategoryID:        idea.CategoryID,
+		StatusID:          idea.StatusID,
+		StatusName:        idea.Status.Title,
+		Title:             idea.Title,
+		Description:       idea.Description,
+		ImageURL:          idea.ImageURL,
+		ImageMediumURL:    ImageVariantURL(idea.ImageURL, imageproc.Medium),
+		ImageThumbnailURL: ImageVariantURL(idea.ImageURL, imageproc.Thumbnail),
+		Likes:             likes,
+		CreatedAt:         idea.CreatedAt,
+	}
+}
+
+func toIdeaStatusHistoryResponse(h models.IdeaStatusHistory) dto.IdeaStatusHistoryResponse {
+	res := dto.IdeaStatusHistoryResponse{
+		ID:           h.ID,
+		FromStatusID: h.FromStatusID,
+		ToStatusID:   h.ToStatusID,
+		ActorID:      h.ActorID,
+		Note:         h.Note,
+		CreatedAt:    h.CreatedAt,
+	}
+	if h.FromStatus != nil {
+		res.FromStatusName = h.FromStatus.Title
+	}
+	if h.ToStatus != nil {
+		res.ToStatusName = h.ToStatus.Title
 	}
+	if h.Actor != nil && h.Actor.Name != nil {
+		res.ActorName = *h.Actor.Name
+	}
+	return res
 }
 
-func toIdeaResponses(ctx context.Context, ideas []models.Idea, likeRepo repository.LikeRepository) []dto.IdeaResponse {
-	res := make([]dto.IdeaResponse, len(ideas))
-	for i, idea := range ideas {
-		likes, err := likeRepo.GetLikesCount(ctx, idea.ID)
+// toIdeaPage maps a page of ideas and fills their like counts and the viewer's likes with one query each.
+func (u *IdeaUsecaseImpl) toIdeaPage(ctx context.Context, ideas pagination.Page[models.Idea], viewerID *uuid.UUID) (pagination.Page[dto.IdeaResponse], error) {
+	ids := make([]uuid.UUID, len(ideas.Items))
+	for i, idea := range ideas.Items {
+		ids[i] = idea.ID
+	}
+
+	counts, err := u.likeRepo.GetLikesCounts(ctx, ids)
+	if err != nil {
+		u.logger.Error("failed to get likes counts", "error", err.Error())
+		return pagination.Page[dto.IdeaResponse]{}, err
+	}
+	liked := map[uuid.UUID]bool{}
+	if viewerID != nil {
+		liked, err = u.likeRepo.GetLikedIdeaIDs(ctx, *viewerID, ids)
 		if err != nil {
-			// In case of an error, we can log it and set likes to 0
-			// Or we can return the error, but that would complicate the calling functions
-			// For now, let's log and default to 0
-			// logger.Error("failed to get likes count for idea", "ideaID", idea.ID, "error", err)
-			likes = 0
+			u.logger.Error("failed to get ideas liked by viewer", "error", err.Error())
+			return pagination.Page[dto.IdeaResponse]{}, err
 		}
-		res[i] = *toIdeaResponse(&idea, int(likes))
 	}
-	return res
+
+	return pagination.Map(ideas, func(idea models.Idea) dto.IdeaResponse {
+		res := toIdeaResponse(&idea, int(counts[idea.ID]))
+		res.LikedByMe = liked[idea.ID]
+		return *res
+	}), nil
 }
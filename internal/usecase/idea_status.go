@@ -8,9 +8,22 @@ import (
 )
 
 type IdeaStatusUsecase interface {
-	Create(ctx context.Context, status dto.CreateIdeaStatusRequest) (uuid.UUID, error)
-	Update(ctx context.Context, id uuid.UUID, status dto.UpdateIdeaStatusRequest) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Create adds a status to the coffee shop workflow. Requires the shop.manage permission in the coffee shop.
+	Create(ctx context.Context, userID, coffeeShopID uuid.UUID, status dto.CreateIdeaStatusRequest) (uuid.UUID, error)
+	// Update changes a status of the coffee shop workflow. Requires the shop.manage permission in the coffee shop.
+	Update(ctx context.Context, userID, coffeeShopID, statusID uuid.UUID, status dto.UpdateIdeaStatusRequest) error
+	// Delete removes a status and its transitions. The initial status cannot be deleted.
+	// Requires the shop.manage permission in the coffee shop.
+	Delete(ctx context.Context, userID, coffeeShopID, statusID uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (dto.IdeaStatusResponse, error)
+	// GetAll returns legacy statuses that do not belong to any coffee shop.
 	GetAll(ctx context.Context) ([]dto.IdeaStatusResponse, error)
+	GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID) ([]dto.IdeaStatusResponse, error)
+
+	// CreateTransition allows ideas of the coffee shop to move between two of its statuses.
+	// Requires the shop.manage permission in the coffee shop.
+	CreateTransition(ctx context.Context, userID, coffeeShopID uuid.UUID, req dto.CreateIdeaStatusTransitionRequest) (dto.IdeaStatusTransitionResponse, error)
+	// DeleteTransition forbids a previously allowed transition. Requires the shop.manage permission in the coffee shop.
+	DeleteTransition(ctx context.Context, userID, coffeeShopID, transitionID uuid.UUID) error
+	GetTransitions(ctx context.Context, coffeeShopID uuid.UUID) ([]dto.IdeaStatusTransitionResponse, error)
 }
@@ -13,37 +13,54 @@ import (
 )
 
 type IdeaStatusUsecaseImpl struct {
-	statusRepo repository.IdeaStatusRepository
-	logger     *slog.Logger
+	statusRepo    repository.IdeaStatusRepository
+	accessControl AccessControlUsecase
+	logger        *slog.Logger
 }
 
-func NewIdeaStatusUsecase(statusRepo repository.IdeaStatusRepository, logger *slog.Logger) IdeaStatusUsecase {
+func NewIdeaStatusUsecase(statusRepo repository.IdeaStatusRepository, accessControl AccessControlUsecase, logger *slog.Logger) IdeaStatusUsecase {
 	return &IdeaStatusUsecaseImpl{
-		statusRepo: statusRepo,
-		logger:     logger,
+		statusRepo:    statusRepo,
+		accessControl: accessControl,
+		logger:        logger,
 	}
 }
 
-func (u *IdeaStatusUsecaseImpl) Create(ctx context.Context, req dto.CreateIdeaStatusRequest) (uuid.UUID, error) {
-	logger := u.logger.With("method", "CreateStatus", "title", req.Title)
-	
-	// Check if title already exists
-	existing, err := u.statusRepo.GetByTitle(ctx, req.Title)
-	if err == nil {
-		// If found (no error), then it's a conflict
-		logger.Warn("idea status already exists", "id", existing.ID)
-		return uuid.Nil, apperrors.NewErrConflict("idea status with this title already exists")
+func (u *IdeaStatusUsecaseImpl) Create(ctx context.Context, userID, coffeeShopID uuid.UUID, req dto.CreateIdeaStatusRequest) (uuid.UUID, error) {
+	logger := u.logger.With("method", "CreateStatus", "userID", userID, "coffeeShopID", coffeeShopID, "title", req.Title)
+
+	if err := u.accessControl.Can(ctx, userID, coffeeShopID, models.PermShopManage); err != nil {
+		return uuid.Nil, err
+	}
+	if req.IsInitial && req.IsTerminal {
+		return uuid.Nil, apperrors.NewErrNotValid("status cannot be both initial and terminal")
 	}
 
-	// Expecting NotFound error here
-	var errNotFound *apperrors.ErrNotFound
-	if !errors.As(err, &errNotFound) {
-		logger.Error("failed to check existing status", "error", err.Error())
+	if err := u.checkTitleIsFree(ctx, coffeeShopID, req.Title); err != nil {
 		return uuid.Nil, err
 	}
 
+	// The first status of a shop becomes initial so that new ideas always have a status to start in.
+	isInitial := req.IsInitial
+	if !isInitial {
+		_, err := u.statusRepo.GetInitial(ctx, coffeeShopID)
+		var errNotFound *apperrors.ErrNotFound
+		if errors.As(err, &errNotFound) {
+			if req.IsTerminal {
+				return uuid.Nil, apperrors.NewErrNotValid("the first status of a coffee shop is initial and cannot be terminal")
+			}
+			isInitial = true
+		} else if err != nil {
+			logger.Error("failed to get initial status", "error", err.Error())
+			return uuid.Nil, err
+		}
+	}
+
 	newStatus := &models.IdeaStatus{
-		Title: req.Title,
+		CoffeeShopID: &coffeeShopID,
+		Title:        req.Title,
+		IsInitial:    isInitial,
+		IsTerminal:   req.IsTerminal,
 	}
 
 	id, err := u.statusRepo.Create(ctx, newStatus)
@@ -56,33 +73,48 @@ func (u *IdeaStatusUsecaseImpl) Create(ctx context.Context, req dto.CreateIdeaSt
 	return id, nil
 }
 
-func (u *IdeaStatusUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateIdeaStatusRequest) error {
-	logger := u.logger.With("method", "UpdateStatus", "id", id)
+func (u *IdeaStatusUsecaseImpl) Update(ctx context.Context, userID, coffeeShopID, statusID uuid.UUID, req dto.UpdateIdeaStatusRequest) error {
+	logger := u.logger.With("method", "UpdateStatus", "userID", userID, "coffeeShopID", coffeeShopID, "id", statusID)
+
+	if err := u.accessControl.Can(ctx, userID, coffeeShopID, models.PermShopManage); err != nil {
+		return err
+	}
+	if req.IsInitial && req.IsTerminal {
+		return apperrors.NewErrNotValid("status cannot be both initial and terminal")
+	}
 
-	existing, err := u.statusRepo.GetByID(ctx, id)
+	existing, err := u.getShopStatus(ctx, coffeeShopID, statusID)
 	if err != nil {
-		var errNotFound *apperrors.ErrNotFound
-		if errors.As(err, &errNotFound) {
-			logger.Warn("idea status not found for update")
-			return err
-		}
-		logger.Error("failed to get idea status", "error", err.Error())
 		return err
 	}
 
-	// Check title uniqueness if changed
+	if existing.IsInitial && !req.IsInitial {
+		logger.Info("attempt to unset the initial status")
+		return apperrors.NewErrNotValid("mark another status as initial instead")
+	}
+
 	if existing.Title != req.Title {
-		_, err := u.statusRepo.GetByTitle(ctx, req.Title)
-		if err == nil {
-			return apperrors.NewErrConflict("idea status with this title already exists")
+		if err := u.checkTitleIsFree(ctx, coffeeShopID, req.Title); err != nil {
+			return err
 		}
-		var errNotFound *apperrors.ErrNotFound
-		if !errors.As(err, &errNotFound) {
+	}
+
+	if req.IsTerminal && !existing.IsTerminal {
+		transitions, err := u.statusRepo.GetTransitionsByCoffeeShop(ctx, coffeeShopID)
+		if err != nil {
+			logger.Error("failed to get transitions", "error", err.Error())
 			return err
 		}
+		for _, t := range transitions {
+			if t.FromStatusID != nil && *t.FromStatusID == statusID {
+				return apperrors.NewErrNotValid("terminal status cannot have outgoing transitions")
+			}
+		}
 	}
 
 	existing.Title = req.Title
+	existing.IsInitial = req.IsInitial
+	existing.IsTerminal = req.IsTerminal
 	if err := u.statusRepo.Update(ctx, &existing); err != nil {
 		logger.Error("failed to update idea status", "error", err.Error())
 		return err
@@ -92,15 +124,23 @@ func (u *IdeaStatusUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dt
 	return nil
 }
 
-func (u *IdeaStatusUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
-	logger := u.logger.With("method", "DeleteStatus", "id", id)
+func (u *IdeaStatusUsecaseImpl) Delete(ctx context.Context, userID, coffeeShopID, statusID uuid.UUID) error {
+	logger := u.logger.With("method", "DeleteStatus", "userID", userID, "coffeeShopID", coffeeShopID, "id", statusID)
 
-	if err := u.statusRepo.Delete(ctx, id); err != nil {
-		var errNotFound *apperrors.ErrNotFound
-		if errors.As(err, &errNotFound) {
-			logger.Warn("idea status not found for deletion")
-			return err
-		}
+	if err := u.accessControl.Can(ctx, userID, coffeeShopID, models.PermShopManage); err != nil {
+		return err
+	}
+
+	existing, err := u.getShopStatus(ctx, coffeeShopID, statusID)
+	if err != nil {
+		return err
+	}
+	if existing.IsInitial {
+		logger.Info("attempt to delete the initial status")
+		return apperrors.NewErrNotValid("initial status cannot be deleted, mark another status as initial first")
+	}
+
+	if err := u.statusRepo.Delete(ctx, statusID); err != nil {
 		logger.Error("failed to delete idea status", "error", err.Error())
 		return err
 	}
@@ -116,10 +156,7 @@ func (u *IdeaStatusUsecaseImpl) GetByID(ctx context.Context, id uuid.UUID) (dto.
 		return dto.IdeaStatusResponse{}, err
 	}
 
-	return dto.IdeaStatusResponse{
-		ID:    status.ID,
-		Title: status.Title,
-	}, nil
+	return toIdeaStatusResponse(status), nil
 }
 
 func (u *IdeaStatusUsecaseImpl) GetAll(ctx context.Context) ([]dto.IdeaStatusResponse, error) {
@@ -129,13 +166,142 @@ func (u *IdeaStatusUsecaseImpl) GetAll(ctx context.Context) ([]dto.IdeaStatusRes
 		return nil, err
 	}
 
-	var responses []dto.IdeaStatusResponse
-	for _, s := range statuses {
-		responses = append(responses, dto.IdeaStatusResponse{
-			ID:    s.ID,
-			Title: s.Title,
-		})
+	return toIdeaStatusResponses(statuses), nil
+}
+
+func (u *IdeaStatusUsecaseImpl) GetByCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID) ([]dto.IdeaStatusResponse, error) {
+	statuses, err := u.statusRepo.GetByCoffeeShop(ctx, coffeeShopID)
+	if err != nil {
+		u.logger.Error("failed to get statuses by coffee shop", "coffeeShopID", coffeeShopID, "error", err.Error())
+		return nil, err
 	}
 
+	return toIdeaStatusResponses(statuses), nil
+}
+
+func (u *IdeaStatusUsecaseImpl) CreateTransition(ctx context.Context, userID, coffeeShopID uuid.UUID, req dto.CreateIdeaStatusTransitionRequest) (dto.IdeaStatusTransitionResponse, error) {
+	logger := u.logger.With("method", "CreateTransition", "userID", userID, "coffeeShopID", coffeeShopID, "from", req.FromStatusID, "to", req.ToStatusID)
+
+	if err := u.accessControl.Can(ctx, userID, coffeeShopID, models.PermShopManage); err != nil {
+		return dto.IdeaStatusTransitionResponse{}, err
+	}
+	if req.FromStatusID == req.ToStatusID {
+		return dto.IdeaStatusTransitionResponse{}, apperrors.NewErrNotValid("transition must connect two different statuses")
+	}
+
+	from, err := u.getShopStatus(ctx, coffeeShopID, req.FromStatusID)
+	if err != nil {
+		return dto.IdeaStatusTransitionResponse{}, err
+	}
+	if _, err := u.getShopStatus(ctx, coffeeShopID, req.ToStatusID); err != nil {
+		return dto.IdeaStatusTransitionResponse{}, err
+	}
+	if from.IsTerminal {
+		return dto.IdeaStatusTransitionResponse{}, apperrors.NewErrNotValid("terminal status cannot have outgoing transitions")
+	}
+
+	allowed, err := u.statusRepo.IsTransitionAllowed(ctx, req.FromStatusID, req.ToStatusID)
+	if err != nil {
+		logger.Error("failed to check transition", "error", err.Error())
+		return dto.IdeaStatusTransitionResponse{}, err
+	}
+	if allowed {
+		return dto.IdeaStatusTransitionResponse{}, apperrors.NewErrConflict("transition already exists")
+	}
+
+	transition, err := u.statusRepo.CreateTransition(ctx, &models.IdeaStatusTransition{
+		CoffeeShopID: &coffeeShopID,
+		FromStatusID: &req.FromStatusID,
+		ToStatusID:   &req.ToStatusID,
+	})
+	if err != nil {
+		logger.Error("failed to create transition", "error", err.Error())
+		return dto.IdeaStatusTransitionResponse{}, err
+	}
+
+	logger.Info("status transition created successfully", "id", transition.ID)
+	return toIdeaStatusTransitionResponse(*transition), nil
+}
+
+func (u *IdeaStatusUsecaseImpl) DeleteTransition(ctx context.Context, userID, coffeeShopID, transitionID uuid.UUID) error {
+	logger := u.logger.With("method", "DeleteTransition", "userID", userID, "coffeeShopID", coffeeShopID, "id", transitionID)
+
+	if err := u.accessControl.Can(ctx, userID, coffeeShopID, models.PermShopManage); err != nil {
+		return err
+	}
+
+	if err := u.statusRepo.DeleteTransition(ctx, transitionID, coffeeShopID); err != nil {
+		logger.Error("failed to delete transition", "error", err.Error())
+		return err
+	}
+
+	logger.Info("status transition deleted successfully")
+	return nil
+}
+
+func (u *IdeaStatusUsecaseImpl) GetTransitions(ctx context.Context, coffeeShopID uuid.UUID) ([]dto.IdeaStatusTransitionResponse, error) {
+	transitions, err := u.statusRepo.GetTransitionsByCoffeeShop(ctx, coffeeShopID)
+	if err != nil {
+		u.logger.Error("failed to get transitions", "coffeeShopID", coffeeShopID, "error", err.Error())
+		return nil, err
+	}
+
+	responses := make([]dto.IdeaStatusTransitionResponse, len(transitions))
+	for i, t := range transitions {
+		responses[i] = toIdeaStatusTransitionResponse(t)
+	}
 	return responses, nil
 }
+
+// getShopStatus returns the status only if it belongs to the coffee shop.
+func (u *IdeaStatusUsecaseImpl) getShopStatus(ctx context.Context, coffeeShopID, statusID uuid.UUID) (models.IdeaStatus, error) {
+	status, err := u.statusRepo.GetByID(ctx, statusID)
+	if err != nil {
+		return status, err
+	}
+	if status.CoffeeShopID == nil || *status.CoffeeShopID != coffeeShopID {
+		return status, apperrors.NewErrNotFound("idea status", statusID.String())
+	}
+	return status, nil
+}
+
+func (u *IdeaStatusUsecaseImpl) checkTitleIsFree(ctx context.Context, coffeeShopID uuid.UUID, title string) error {
+	existing, err := u.statusRepo.GetByTitle(ctx, coffeeShopID, title)
+	if err == nil {
+		u.logger.Warn("idea status already exists", "id", existing.ID)
+		return apperrors.NewErrConflict("idea status with this title already exists")
+	}
+
+	var errNotFound *apperrors.ErrNotFound
+	if !errors.As(err, &errNotFound) {
+		u.logger.Error("failed to check existing status", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+func toIdeaStatusResponse(s models.IdeaStatus) dto.IdeaStatusResponse {
+	return dto.IdeaStatusResponse{
+		ID:           s.ID,
+		CoffeeShopID: s.CoffeeShopID,
+		Title:        s.Title,
+		IsInitial:    s.IsInitial,
+		IsTerminal:   s.IsTerminal,
+	}
+}
+
+func toIdeaStatusResponses(statuses []models.IdeaStatus) []dto.IdeaStatusResponse {
+	responses := make([]dto.IdeaStatusResponse, len(statuses))
+	for i, s := range statuses {
+		responses[i] = toIdeaStatusResponse(s)
+	}
+	return responses
+}
+
+func toIdeaStatusTransitionResponse(t models.IdeaStatusTransition) dto.IdeaStatusTransitionResponse {
+	return dto.IdeaStatusTransitionResponse{
+		ID:           t.ID,
+		FromStatusID: t.FromStatusID,
+		ToStatusID:   t.ToStatusID,
+	}
+}
@@ -2,12 +2,32 @@ package usecase
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
-	"github.com/minio/minio-go/v7"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/google/uuid"
 )
 
 type ImageUsecase interface {
 	UploadImage(ctx context.Context, file *multipart.FileHeader) (string, error)
-	CreateBucket(ctx context.Context) error
-	GetImage(ctx context.Context, objectName string) (*minio.Object, minio.ObjectInfo, error)
+	// GetImage opens the image at imageURL, as returned by UploadImage, or only the part
+	// selected by rng when it is not nil.
+	GetImage(ctx context.Context, imageURL string, rng *blobstore.Range) (io.ReadCloser, blobstore.ObjectInfo, error)
+	// StatImage describes the image at imageURL without opening it.
+	StatImage(ctx context.Context, imageURL string) (blobstore.ObjectInfo, error)
+	// CheckStored returns a validation error unless imageURL is an image stored by the API
+	// that the garbage collection has not deleted.
+	CheckStored(ctx context.Context, imageURL string) error
+	// CreateUpload returns a presigned request for uploading an image directly to the storage.
+	CreateUpload(ctx context.Context, userID uuid.UUID, req *dto.CreateUploadRequest) (*dto.UploadResponse, error)
+	// FinalizeUpload checks that the file of the upload was stored and processes it like
+	// UploadImage. Finalizing an upload again returns the same URL.
+	FinalizeUpload(ctx context.Context, userID, uploadID uuid.UUID) (string, error)
+	// CleanupUploads deletes expired uploads together with their files.
+	CleanupUploads(ctx context.Context) error
+	// CollectGarbage deletes the images that no idea or upload refers to and that were stored
+	// longer than the grace period ago. With dryRun it only reports them.
+	CollectGarbage(ctx context.Context, dryRun bool) (*dto.ImageGCReport, error)
 }
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunImageGC collects unreferenced images right away and then every interval until ctx is
+// done. In a dry run the images are only logged.
+func RunImageGC(ctx context.Context, uc ImageUsecase, interval time.Duration, dryRun bool, logger *slog.Logger) {
+	logger = logger.With("component", "ImageGC", "dryRun", dryRun)
+	runPeriodically(ctx, "image garbage collection", interval, func(ctx context.Context) {
+		report, err := uc.CollectGarbage(ctx, dryRun)
+		if err != nil || !dryRun {
+			return
+		}
+		for _, image := range report.Images {
+			logger.Info("unreferenced image would be deleted", "imageURL", image.ImageURL, "size", image.Size, "createdAt", image.CreatedAt)
+		}
+	}, logger)
+}
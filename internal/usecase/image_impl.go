@@ -1,72 +1,418 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"mime/multipart"
-	"path/filepath"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/imageproc"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
+)
+
+// uploadExts maps the content types accepted for direct uploads to the extensions of the stored files.
+var uploadExts = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+const (
+	// uploadCleanupBatch is how many expired uploads CleanupUploads loads at once.
+	uploadCleanupBatch = 100
+	// gcBatch is how many unreferenced images CollectGarbage loads at once.
+	gcBatch = 100
+	// uploadsDir holds the files of direct uploads until they are finalized. They are stored
+	// as the client sent them, so they are never served as images.
+	uploadsDir = "uploads"
 )
 
 type ImageUsecaseImpl struct {
-	minioClient *minio.Client
-	bucketName  string
+	store      blobstore.BlobStore
+	uploadRepo repository.ImageUploadRepository
+	// storedImageRepo tracks every stored image for the garbage collection.
+	storedImageRepo repository.StoredImageRepository
+	// urlPrefix starts every image URL. It is the bucket name, as in the URLs stored before
+	// other storage drivers existed.
+	urlPrefix string
+	cfg       *config.ImageConfig
+	logger    *slog.Logger
 }
 
-func NewImageUsecase(minioClient *minio.Client, bucketName string) *ImageUsecaseImpl {
+func NewImageUsecase(store blobstore.BlobStore, uploadRepo repository.ImageUploadRepository, storedImageRepo repository.StoredImageRepository,
+	urlPrefix string, cfg *config.ImageConfig, logger *slog.Logger) *ImageUsecaseImpl {
 	return &ImageUsecaseImpl{
-		minioClient: minioClient,
-		bucketName:  bucketName,
+		store:           store,
+		uploadRepo:      uploadRepo,
+		storedImageRepo: storedImageRepo,
+		urlPrefix:       urlPrefix,
+		cfg:             cfg,
+		logger:          logger,
 	}
 }
 
+// UploadImage validates the image, strips its metadata and stores it together with its
+// variants under <uuid>/<variant><ext>. It returns the URL of the original.
 func (uc *ImageUsecaseImpl) UploadImage(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	if file.Size > uc.cfg.MaxBytes {
+		return "", apperrors.NewErrNotValid(imageproc.ErrTooLarge.Error())
+	}
 	src, err := file.Open()
 	if err != nil {
 		return "", err
 	}
 	defer src.Close()
 
-	fileName := uuid.New().String() + filepath.Ext(file.Filename)
+	imageURL, _, err := uc.storeImage(ctx, src)
+	return imageURL, err
+}
 
-	_, err = uc.minioClient.PutObject(ctx, uc.bucketName, fileName, src, file.Size, minio.PutObjectOptions{
-		ContentType: file.Header.Get("Content-Type"),
-	})
+// storeImage processes the image read from r and stores it with its variants. It returns
+// the URL of the original and the keys of all stored files.
+func (uc *ImageUsecaseImpl) storeImage(ctx context.Context, r io.Reader) (string, []string, error) {
+	processed, err := imageproc.Process(r, uc.cfg)
 	if err != nil {
-		return "", err
+		if errors.Is(err, imageproc.ErrInvalidImage) {
+			return "", nil, apperrors.NewErrNotValid(err.Error())
+		}
+		return "", nil, err
+	}
+
+	id := uuid.New().String()
+	imageURL := fmt.Sprintf("%s/%s", uc.urlPrefix, path.Join(id, imageproc.Original+processed.Ext))
+	// Recorded before the files are stored, so that the garbage collection finds them even
+	// when the image is never referenced or storing it fails halfway.
+	if err := uc.storedImageRepo.Create(ctx, &models.StoredImage{ImageURL: imageURL}); err != nil {
+		return "", nil, err
+	}
+
+	var stored []string
+	for _, variant := range processed.Variants {
+		key := path.Join(id, variant.Name+processed.Ext)
+		err = uc.store.Put(ctx, key, bytes.NewReader(variant.Data), int64(len(variant.Data)), processed.ContentType)
+		if err != nil {
+			// Best effort: an image without all its variants is never referenced.
+			uc.deleteObjects(ctx, stored)
+			return "", nil, err
+		}
+		stored = append(stored, key)
 	}
 
-	return fmt.Sprintf("%s/%s", uc.bucketName, fileName), nil
+	return imageURL, stored, nil
 }
 
-func (uc *ImageUsecaseImpl) CreateBucket(ctx context.Context) error {
-	found, err := uc.minioClient.BucketExists(ctx, uc.bucketName)
+// deleteObjects deletes the files stored under keys and returns the errors of the ones that
+// could not be deleted. Keys that can not hold a file are skipped.
+func (uc *ImageUsecaseImpl) deleteObjects(ctx context.Context, keys []string) error {
+	var errs []error
+	for _, key := range keys {
+		err := uc.store.Delete(ctx, key)
+		if err != nil && !errors.Is(err, blobstore.ErrInvalidKey) {
+			uc.logger.Warn("failed to delete stored file", "key", key, "error", err.Error())
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ImageVariantURL returns the URL of a variant of the image with the original at imageURL.
+// It returns nil for images stored before variants were generated.
+func ImageVariantURL(imageURL *string, variant string) *string {
+	if imageURL == nil {
+		return nil
+	}
+	dir, file := path.Split(*imageURL)
+	if !strings.HasPrefix(file, imageproc.Original+".") {
+		return nil
+	}
+	url := dir + variant + path.Ext(file)
+	return &url
+}
+
+func (uc *ImageUsecaseImpl) GetImage(ctx context.Context, imageURL string, rng *blobstore.Range) (io.ReadCloser, blobstore.ObjectInfo, error) {
+	key, ok := uc.imageKey(imageURL)
+	if !ok {
+		return nil, blobstore.ObjectInfo{}, apperrors.NewErrNotFound("image", imageURL)
+	}
+	object, info, err := uc.store.Get(ctx, key, rng)
 	if err != nil {
-		return err
+		return nil, blobstore.ObjectInfo{}, imageError(imageURL, err)
 	}
-	if !found {
-		err = uc.minioClient.MakeBucket(ctx, uc.bucketName, minio.MakeBucketOptions{})
-		if err != nil {
-			return err
+	return object, info, nil
+}
+
+func (uc *ImageUsecaseImpl) StatImage(ctx context.Context, imageURL string) (blobstore.ObjectInfo, error) {
+	key, ok := uc.imageKey(imageURL)
+	if !ok {
+		return blobstore.ObjectInfo{}, apperrors.NewErrNotFound("image", imageURL)
+	}
+	info, err := uc.store.Stat(ctx, key)
+	if err != nil {
+		return blobstore.ObjectInfo{}, imageError(imageURL, err)
+	}
+	return info, nil
+}
+
+// imageKeys returns the keys of the files of the image at imageURL: the original and, for
+// images stored with variants, the variants.
+func (uc *ImageUsecaseImpl) imageKeys(imageURL string) []string {
+	var keys []string
+	urls := []string{imageURL}
+	for _, variant := range []string{imageproc.Medium, imageproc.Thumbnail} {
+		if variantURL := ImageVariantURL(&imageURL, variant); variantURL != nil {
+			urls = append(urls, *variantURL)
 		}
 	}
+	for _, url := range urls {
+		if key, ok := uc.imageKey(url); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// imageKey returns the key under which the image at imageURL is stored. It reports false for
+// URLs without the prefix and for the files of pending uploads, which are not images yet.
+func (uc *ImageUsecaseImpl) imageKey(imageURL string) (string, bool) {
+	key, ok := strings.CutPrefix(imageURL, uc.urlPrefix+"/")
+	if !ok || strings.HasPrefix(key, uploadsDir+"/") {
+		return "", false
+	}
+	return key, true
+}
+
+func (uc *ImageUsecaseImpl) CheckStored(ctx context.Context, imageURL string) error {
+	stored, err := uc.storedImageRepo.Exists(ctx, imageURL)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		return apperrors.NewErrNotValid("image_url does not refer to a stored image")
+	}
 	return nil
 }
 
-func (uc *ImageUsecaseImpl) GetImage(ctx context.Context, objectName string) (*minio.Object, minio.ObjectInfo, error) {
-	object, err := uc.minioClient.GetObject(ctx, uc.bucketName, objectName, minio.GetObjectOptions{})
+func imageError(imageURL string, err error) error {
+	if errors.Is(err, blobstore.ErrNotFound) || errors.Is(err, blobstore.ErrInvalidKey) {
+		return apperrors.NewErrNotFound("image", imageURL)
+	}
+	return err
+}
+
+func (uc *ImageUsecaseImpl) CreateUpload(ctx context.Context, userID uuid.UUID, req *dto.CreateUploadRequest) (*dto.UploadResponse, error) {
+	logger := uc.logger.With("method", "CreateUpload", "userID", userID.String())
+	logger.Debug("starting create upload")
+
+	ext, ok := uploadExts[req.ContentType]
+	if !ok {
+		logger.Info("unsupported content type", "contentType", req.ContentType)
+		return nil, apperrors.NewErrNotValid(imageproc.ErrUnsupportedFormat.Error())
+	}
+	if req.Size <= 0 || req.Size > uc.cfg.MaxBytes {
+		logger.Info("invalid upload size", "size", req.Size)
+		return nil, apperrors.NewErrNotValid(fmt.Sprintf("size must be between 1 and %d bytes", uc.cfg.MaxBytes))
+	}
+
+	id := uuid.New()
+	key := path.Join(uploadsDir, id.String()+ext)
+	presigned, err := uc.store.PresignPut(ctx, key, req.ContentType, req.Size, uc.cfg.UploadURLExpiry)
 	if err != nil {
-		return nil, minio.ObjectInfo{}, err
+		if errors.Is(err, blobstore.ErrPresignNotSupported) {
+			logger.Info("storage does not accept direct uploads")
+			return nil, apperrors.NewErrNotValid("direct uploads are not available, send the image with the idea instead")
+		}
+		logger.Error("failed to presign upload", "error", err.Error())
+		return nil, err
 	}
 
-	objectInfo, err := object.Stat()
+	upload := &models.ImageUpload{
+		ID:          id,
+		UserID:      userID,
+		ObjectKey:   key,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		ExpiresAt:   time.Now().Add(uc.cfg.UploadTTL),
+	}
+	if err := uc.uploadRepo.Create(ctx, upload); err != nil {
+		logger.Error("failed to create upload", "error", err.Error())
+		return nil, err
+	}
+
+	logger.Info("upload created", "uploadID", id.String())
+	return &dto.UploadResponse{
+		UploadID:  id,
+		Method:    presigned.Method,
+		URL:       presigned.URL,
+		Headers:   presigned.Headers,
+		ExpiresAt: presigned.ExpiresAt,
+	}, nil
+}
+
+func (uc *ImageUsecaseImpl) FinalizeUpload(ctx context.Context, userID, uploadID uuid.UUID) (string, error) {
+	logger := uc.logger.With("method", "FinalizeUpload", "userID", userID.String(), "uploadID", uploadID.String())
+	logger.Debug("starting finalize upload")
+
+	upload, err := uc.uploadRepo.GetByID(ctx, uploadID)
+	if err != nil {
+		return "", err
+	}
+	if upload.UserID != userID {
+		logger.Info("upload belongs to another user")
+		return "", apperrors.NewErrNotFound("upload", uploadID.String())
+	}
+	if upload.ImageURL != nil {
+		return *upload.ImageURL, nil
+	}
+	if !time.Now().Before(upload.ExpiresAt) {
+		logger.Info("upload has expired")
+		return "", apperrors.NewErrNotValid("upload has expired")
+	}
+
+	info, err := uc.store.Stat(ctx, upload.ObjectKey)
+	if errors.Is(err, blobstore.ErrNotFound) {
+		logger.Info("file has not been uploaded")
+		return "", apperrors.NewErrNotValid("file has not been uploaded")
+	}
 	if err != nil {
-		// Ensure object is closed on error
-		object.Close()
-		return nil, minio.ObjectInfo{}, err
+		logger.Error("failed to stat uploaded file", "error", err.Error())
+		return "", err
+	}
+	if info.Size != upload.Size {
+		logger.Info("uploaded file has unexpected size", "size", info.Size, "expected", upload.Size)
+		return "", apperrors.NewErrNotValid("uploaded file does not match the size of the upload")
 	}
 
-	return object, objectInfo, nil
-}
\ No newline at end of file
+	object, _, err := uc.store.Get(ctx, upload.ObjectKey, nil)
+	if err != nil {
+		logger.Error("failed to open uploaded file", "error", err.Error())
+		return "", err
+	}
+	imageURL, keys, err := uc.storeImage(ctx, object)
+	object.Close()
+	if err != nil {
+		logger.Info("failed to process uploaded image", "error", err.Error())
+		return "", err
+	}
+
+	if err := uc.uploadRepo.Finalize(ctx, upload.ID, imageURL, time.Now()); err != nil {
+		uc.deleteObjects(ctx, keys)
+		var errConflict *apperrors.ErrConflict
+		if errors.As(err, &errConflict) {
+			// Finalized by a concurrent request, which made its own copy of the image.
+			logger.Info("upload was finalized concurrently")
+			return uc.FinalizeUpload(ctx, userID, uploadID)
+		}
+		logger.Error("failed to finalize upload", "error", err.Error())
+		return "", err
+	}
+	// The processed copy is all that is kept; the cleanup retries when this fails.
+	uc.deleteObjects(ctx, []string{upload.ObjectKey})
+
+	logger.Info("upload finalized", "imageURL", imageURL)
+	return imageURL, nil
+}
+
+func (uc *ImageUsecaseImpl) CleanupUploads(ctx context.Context) error {
+	logger := uc.logger.With("method", "CleanupUploads")
+
+	deleted := 0
+	for {
+		uploads, err := uc.uploadRepo.ListExpired(ctx, time.Now(), uploadCleanupBatch)
+		if err != nil {
+			logger.Error("failed to list expired uploads", "error", err.Error())
+			return err
+		}
+		for _, upload := range uploads {
+			if err := uc.store.Delete(ctx, upload.ObjectKey); err != nil {
+				logger.Error("failed to delete uploaded file", "uploadID", upload.ID.String(), "error", err.Error())
+				return err
+			}
+			if err := uc.uploadRepo.Delete(ctx, upload.ID); err != nil {
+				logger.Error("failed to delete upload", "uploadID", upload.ID.String(), "error", err.Error())
+				return err
+			}
+			deleted++
+		}
+		if len(uploads) < uploadCleanupBatch {
+			break
+		}
+	}
+
+	if deleted > 0 {
+		logger.Info("expired uploads deleted", "count", deleted)
+	}
+	return nil
+}
+
+func (uc *ImageUsecaseImpl) CollectGarbage(ctx context.Context, dryRun bool) (*dto.ImageGCReport, error) {
+	logger := uc.logger.With("method", "CollectGarbage", "dryRun", dryRun)
+	logger.Debug("starting image garbage collection")
+
+	report := &dto.ImageGCReport{DryRun: dryRun, Images: []dto.OrphanedImage{}}
+	createdBefore := time.Now().Add(-uc.cfg.GCGracePeriod)
+	afterURL := ""
+	for {
+		images, err := uc.storedImageRepo.ListUnreferenced(ctx, createdBefore, afterURL, gcBatch)
+		if err != nil {
+			logger.Error("failed to list unreferenced images", "error", err.Error())
+			return nil, err
+		}
+		for _, image := range images {
+			afterURL = image.ImageURL
+			keys := uc.imageKeys(image.ImageURL)
+
+			orphan := dto.OrphanedImage{ImageURL: image.ImageURL, CreatedAt: image.CreatedAt}
+			for _, key := range keys {
+				info, err := uc.store.Stat(ctx, key)
+				if errors.Is(err, blobstore.ErrNotFound) || errors.Is(err, blobstore.ErrInvalidKey) {
+					continue
+				}
+				if err != nil {
+					logger.Error("failed to stat image file", "key", key, "error", err.Error())
+					return nil, err
+				}
+				orphan.Size += info.Size
+			}
+
+			if !dryRun {
+				deleted, err := uc.storedImageRepo.DeleteUnreferenced(ctx, image.ImageURL)
+				if err != nil {
+					logger.Error("failed to delete image record", "imageURL", image.ImageURL, "error", err.Error())
+					return nil, err
+				}
+				if !deleted {
+					// Referenced again since it was listed.
+					continue
+				}
+				if err := uc.deleteObjects(ctx, keys); err != nil {
+					// Tracked again, so that the next run retries.
+					if err := uc.storedImageRepo.Create(ctx, &image); err != nil {
+						logger.Error("failed to restore image record", "imageURL", image.ImageURL, "error", err.Error())
+					}
+					report.Failed++
+					continue
+				}
+			}
+			report.Images = append(report.Images, orphan)
+			report.Bytes += orphan.Size
+		}
+		if len(images) < gcBatch {
+			break
+		}
+	}
+
+	logger.Info("image garbage collection finished", "images", len(report.Images), "bytes", report.Bytes, "failed", report.Failed)
+	return report, nil
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
@@ -10,24 +11,51 @@ import (
 
 type likeUsecase struct {
 	likeRepo repository.LikeRepository
+	ideaRepo repository.IdeaRepository
+	banRepo  repository.BannedUserRepository
 	logger   *slog.Logger
 }
 
-func NewLikeUsecase(likeRepo repository.LikeRepository, logger *slog.Logger) LikeUsecase {
+func NewLikeUsecase(likeRepo repository.LikeRepository, ideaRepo repository.IdeaRepository, banRepo repository.BannedUserRepository, logger *slog.Logger) LikeUsecase {
 	return &likeUsecase{
 		likeRepo: likeRepo,
+		ideaRepo: ideaRepo,
+		banRepo:  banRepo,
 		logger:   logger,
 	}
 }
 
 func (u *likeUsecase) LikeIdea(ctx context.Context, userID, ideaID uuid.UUID) error {
+	if err := u.checkNotBanned(ctx, userID, ideaID); err != nil {
+		return err
+	}
 	return u.likeRepo.LikeIdea(ctx, userID, ideaID)
 }
 
 func (u *likeUsecase) UnlikeIdea(ctx context.Context, userID, ideaID uuid.UUID) error {
+	if err := u.checkNotBanned(ctx, userID, ideaID); err != nil {
+		return err
+	}
 	return u.likeRepo.UnlikeIdea(ctx, userID, ideaID)
 }
 
 func (u *likeUsecase) HasUserLiked(ctx context.Context, userID, ideaID uuid.UUID) (bool, error) {
 	return u.likeRepo.HasUserLiked(ctx, userID, ideaID)
 }
+
+// checkNotBanned rejects users banned in the coffee shop the idea belongs to.
+func (u *likeUsecase) checkNotBanned(ctx context.Context, userID, ideaID uuid.UUID) error {
+	logger := u.logger.With("method", "checkNotBanned", "userID", userID, "ideaID", ideaID)
+
+	idea, err := u.ideaRepo.GetIdea(ctx, ideaID)
+	if err != nil {
+		logger.Error("failed to get idea", "error", err)
+		return err
+	}
+	if idea.CoffeeShopID == nil {
+		logger.Error("idea has no associated coffee shop ID")
+		return errors.New("idea is not associated with a coffee shop")
+	}
+
+	return CheckNotBanned(ctx, logger, u.banRepo, userID, *idea.CoffeeShopID)
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+type OrganizationUsecase interface {
+	// CreateOrganization creates an organization owned by the actor.
+	CreateOrganization(ctx context.Context, actorID uuid.UUID, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error)
+	GetOrganization(ctx context.Context, organizationID uuid.UUID) (*dto.OrganizationResponse, error)
+	// UpdateOrganization requires the shop.manage permission in the organization.
+	UpdateOrganization(ctx context.Context, actorID, organizationID uuid.UUID, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error)
+	// DeleteOrganization requires the shop.delete permission in the organization.
+	// The coffee shops of the organization stay and become standalone.
+	DeleteOrganization(ctx context.Context, actorID, organizationID uuid.UUID) error
+	// ListMyOrganizations retrieves organizations in which the user is a member.
+	ListMyOrganizations(ctx context.Context, userID uuid.UUID, page pagination.Request) (pagination.Page[dto.OrganizationResponse], error)
+
+	// AddMember gives a user a role in every coffee shop of the organization.
+	// Requires the worker.manage permission in the organization.
+	AddMember(ctx context.Context, actorID, organizationID uuid.UUID, req *dto.AddOrganizationMemberRequest) (*dto.OrganizationMemberResponse, error)
+	// ListMembers requires the worker.manage permission in the organization.
+	ListMembers(ctx context.Context, actorID, organizationID uuid.UUID, page pagination.Request) (pagination.Page[dto.OrganizationMemberResponse], error)
+	// RemoveMember requires the worker.manage permission in the organization. Owners cannot be removed.
+	RemoveMember(ctx context.Context, actorID, organizationID, userID uuid.UUID) error
+
+	// AttachCoffeeShop adds a standalone coffee shop to the organization. Requires the shop.manage permission
+	// in the organization and the shop.delete permission in the coffee shop.
+	AttachCoffeeShop(ctx context.Context, actorID, organizationID uuid.UUID, req *dto.AttachCoffeeShopRequest) error
+	// DetachCoffeeShop makes a coffee shop of the organization standalone again.
+	// Requires the shop.manage permission in the organization.
+	DetachCoffeeShop(ctx context.Context, actorID, organizationID, shopID uuid.UUID) error
+	ListCoffeeShops(ctx context.Context, organizationID uuid.UUID, page pagination.Request) (pagination.Page[dto.CoffeeShopResponse], error)
+
+	// GetStats returns activity counters of the organization and each of its coffee shops.
+	// Available to any member of the organization.
+	GetStats(ctx context.Context, actorID, organizationID uuid.UUID) (*dto.OrganizationStatsResponse, error)
+}
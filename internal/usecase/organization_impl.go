@@ -0,0 +1,347 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
+	"github.com/google/uuid"
+)
+
+type OrganizationUsecaseImpl struct {
+	orgRepo       repository.OrganizationRepository
+	userRepo      repository.UserRep
+	roleRepo      repository.RoleRepository
+	accessControl AccessControlUsecase
+	ownerRoleID   uuid.UUID
+	logger        *slog.Logger
+}
+
+func NewOrganizationUsecase(
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRep,
+	roleRepo repository.RoleRepository,
+	accessControl AccessControlUsecase,
+	ownerRoleID uuid.UUID,
+	logger *slog.Logger,
+) OrganizationUsecase {
+	return &OrganizationUsecaseImpl{
+		orgRepo:       orgRepo,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		accessControl: accessControl,
+		ownerRoleID:   ownerRoleID,
+		logger:        logger,
+	}
+}
+
+func (u *OrganizationUsecaseImpl) CreateOrganization(ctx context.Context, actorID uuid.UUID, req *dto.CreateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	logger := u.logger.With("method", "CreateOrganization", "actorID", actorID)
+	logger.Debug("starting create organization")
+
+	org, err := u.orgRepo.Create(ctx, &models.Organization{
+		CreatorID:   &actorID,
+		Name:        req.Name,
+		Description: req.Description,
+	}, &models.OrganizationMember{
+		UserID: actorID,
+		RoleID: u.ownerRoleID, // the creator owns the organization
+	})
+	if err != nil {
+		logger.Error("failed to create organization", "error", err)
+		return nil, err
+	}
+
+	logger.Info("organization created successfully", "organizationID", org.ID)
+	return toOrganizationResponse(org), nil
+}
+
+func (u *OrganizationUsecaseImpl) GetOrganization(ctx context.Context, organizationID uuid.UUID) (*dto.OrganizationResponse, error) {
+	logger := u.logger.With("method", "GetOrganization", "organizationID", organizationID)
+	logger.Debug("starting get organization")
+
+	org, err := u.orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		logger.Info("failed to get organization", "error", err)
+		return nil, err
+	}
+	return toOrganizationResponse(org), nil
+}
+
+func (u *OrganizationUsecaseImpl) UpdateOrganization(ctx context.Context, actorID, organizationID uuid.UUID, req *dto.UpdateOrganizationRequest) (*dto.OrganizationResponse, error) {
+	logger := u.logger.With("method", "UpdateOrganization", "actorID", actorID, "organizationID", organizationID)
+	logger.Debug("starting update organization")
+
+	if err := u.accessControl.CanInOrganization(ctx, actorID, organizationID, models.PermShopManage); err != nil {
+		return nil, err
+	}
+
+	org, err := u.orgRepo.GetByID(ctx, organizationID)
+	if err != nil {
+		logger.Info("failed to get organization", "error", err)
+		return nil, err
+	}
+	org.Name = req.Name
+	org.Description = req.Description
+	if err := u.orgRepo.Update(ctx, org); err != nil {
+		logger.Error("failed to update organization", "error", err)
+		return nil, err
+	}
+
+	logger.Info("organization updated successfully")
+	return toOrganizationResponse(org), nil
+}
+
+func (u *OrganizationUsecaseImpl) DeleteOrganization(ctx context.Context, actorID, organizationID uuid.UUID) error {
+	logger := u.logger.With("method", "DeleteOrganization", "actorID", actorID, "organizationID", organizationID)
+	logger.Debug("starting delete organization")
+
+	if err := u.accessControl.CanInOrganization(ctx, actorID, organizationID, models.PermShopDelete); err != nil {
+		return err
+	}
+	if err := u.orgRepo.Delete(ctx, organizationID); err != nil {
+		logger.Error("failed to delete organization", "error", err)
+		return err
+	}
+
+	logger.Info("organization deleted successfully")
+	return nil
+}
+
+func (u *OrganizationUsecaseImpl) ListMyOrganizations(ctx context.Context, userID uuid.UUID, page pagination.Request) (pagination.Page[dto.OrganizationResponse], error) {
+	logger := u.logger.With("method", "ListMyOrganizations", "userID", userID, "limit", page.Limit)
+	logger.Debug("starting list organizations of user")
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.OrganizationResponse]{}, err
+	}
+
+	orgs, err := u.orgRepo.ListByMember(ctx, userID, q)
+	if err != nil {
+		logger.Error("failed to list organizations", "error", err)
+		return pagination.Page[dto.OrganizationResponse]{}, err
+	}
+
+	logger.Info("organizations listed successfully", "count", len(orgs.Items))
+	return pagination.Map(orgs, func(o models.Organization) dto.OrganizationResponse {
+		return *toOrganizationResponse(&o)
+	}), nil
+}
+
+func (u *OrganizationUsecaseImpl) AddMember(ctx context.Context, actorID, organizationID uuid.UUID, req *dto.AddOrganizationMemberRequest) (*dto.OrganizationMemberResponse, error) {
+	logger := u.logger.With("method", "AddMember", "actorID", actorID, "organizationID", organizationID, "userID", req.UserID)
+	logger.Debug("starting add organization member")
+
+	if err := u.accessControl.CanInOrganization(ctx, actorID, organizationID, models.PermWorkerManage); err != nil {
+		return nil, err
+	}
+
+	roleName := req.Role
+	if roleName == "" {
+		roleName = models.RoleAdmin
+	}
+	role, err := resolveAssignableRole(ctx, logger, u.roleRepo, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.userRepo.GetUser(ctx, req.UserID); err != nil {
+		logger.Info("failed to get user", "error", err)
+		return nil, err
+	}
+
+	member, err := u.orgRepo.AddMember(ctx, &models.OrganizationMember{
+		OrganizationID: organizationID,
+		UserID:         req.UserID,
+		RoleID:         role.ID,
+	})
+	if err != nil {
+		logger.Info("failed to add organization member", "error", err)
+		return nil, err
+	}
+
+	logger.Info("organization member added successfully", "role", role.Name)
+	return toOrganizationMemberResponse(member), nil
+}
+
+func (u *OrganizationUsecaseImpl) ListMembers(ctx context.Context, actorID, organizationID uuid.UUID, page pagination.Request) (pagination.Page[dto.OrganizationMemberResponse], error) {
+	logger := u.logger.With("method", "ListMembers", "actorID", actorID, "organizationID", organizationID, "limit", page.Limit)
+	logger.Debug("starting list organization members")
+
+	if err := u.accessControl.CanInOrganization(ctx, actorID, organizationID, models.PermWorkerManage); err != nil {
+		return pagination.Page[dto.OrganizationMemberResponse]{}, err
+	}
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.OrganizationMemberResponse]{}, err
+	}
+
+	members, err := u.orgRepo.ListMembers(ctx, organizationID, q)
+	if err != nil {
+		logger.Error("failed to list organization members", "error", err)
+		return pagination.Page[dto.OrganizationMemberResponse]{}, err
+	}
+
+	logger.Info("organization members listed successfully", "count", len(members.Items))
+	return pagination.Map(members, func(m models.OrganizationMember) dto.OrganizationMemberResponse {
+		return *toOrganizationMemberResponse(&m)
+	}), nil
+}
+
+func (u *OrganizationUsecaseImpl) RemoveMember(ctx context.Context, actorID, organizationID, userID uuid.UUID) error {
+	logger := u.logger.With("method", "RemoveMember", "actorID", actorID, "organizationID", organizationID, "userID", userID)
+	logger.Debug("starting remove organization member")
+
+	if err := u.accessControl.CanInOrganization(ctx, actorID, organizationID, models.PermWorkerManage); err != nil {
+		return err
+	}
+
+	member, err := u.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		logger.Info("failed to get organization member", "error", err)
+		return err
+	}
+	if member.Role.Name == models.RoleOwner {
+		logger.Info("attempt to remove an owner of the organization")
+		return apperrors.NewErrAccessDenied("owners cannot be removed from the organization")
+	}
+
+	if err := u.orgRepo.RemoveMember(ctx, organizationID, userID); err != nil {
+		logger.Error("failed to remove organization member", "error", err)
+		return err
+	}
+
+	logger.Info("organization member removed successfully")
+	return nil
+}
+
+func (u *OrganizationUsecaseImpl) AttachCoffeeShop(ctx context.Context, actorID, organizationID uuid.UUID, req *dto.AttachCoffeeShopRequest) error {
+	logger := u.logger.With("method", "AttachCoffeeShop", "actorID", actorID, "organizationID", organizationID, "shopID", req.CoffeeShopID)
+	logger.Debug("starting attach coffee shop to organization")
+
+	if err := u.accessControl.CanInOrganization(ctx, actorID, organizationID, models.PermShopManage); err != nil {
+		return err
+	}
+	// Only an owner of the coffee shop can hand it over to an organization.
+	if err := u.accessControl.Can(ctx, actorID, req.CoffeeShopID, models.PermShopDelete); err != nil {
+		return err
+	}
+
+	if err := u.orgRepo.AttachCoffeeShop(ctx, organizationID, req.CoffeeShopID); err != nil {
+		logger.Info("failed to attach coffee shop", "error", err)
+		return err
+	}
+
+	logger.Info("coffee shop attached to organization successfully")
+	return nil
+}
+
+func (u *OrganizationUsecaseImpl) DetachCoffeeShop(ctx context.Context, actorID, organizationID, shopID uuid.UUID) error {
+	logger := u.logger.With("method", "DetachCoffeeShop", "actorID", actorID, "organizationID", organizationID, "shopID", shopID)
+	logger.Debug("starting detach coffee shop from organization")
+
+	if err := u.accessControl.CanInOrganization(ctx, actorID, organizationID, models.PermShopManage); err != nil {
+		return err
+	}
+
+	if err := u.orgRepo.DetachCoffeeShop(ctx, organizationID, shopID); err != nil {
+		logger.Info("failed to detach coffee shop", "error", err)
+		return err
+	}
+
+	logger.Info("coffee shop detached from organization successfully")
+	return nil
+}
+
+func (u *OrganizationUsecaseImpl) ListCoffeeShops(ctx context.Context, organizationID uuid.UUID, page pagination.Request) (pagination.Page[dto.CoffeeShopResponse], error) {
+	logger := u.logger.With("method", "ListCoffeeShops", "organizationID", organizationID, "limit", page.Limit)
+	logger.Debug("starting list coffee shops of organization")
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.CoffeeShopResponse]{}, err
+	}
+
+	shops, err := u.orgRepo.ListCoffeeShops(ctx, organizationID, q)
+	if err != nil {
+		logger.Error("failed to list coffee shops of organization", "error", err)
+		return pagination.Page[dto.CoffeeShopResponse]{}, err
+	}
+
+	logger.Info("coffee shops of organization listed successfully", "count", len(shops.Items))
+	return pagination.WithItems(shops, toCoffeeShopResponses(shops.Items)), nil
+}
+
+func (u *OrganizationUsecaseImpl) GetStats(ctx context.Context, actorID, organizationID uuid.UUID) (*dto.OrganizationStatsResponse, error) {
+	logger := u.logger.With("method", "GetStats", "actorID", actorID, "organizationID", organizationID)
+	logger.Debug("starting get organization stats")
+
+	if err := u.accessControl.CanAccessOrganization(ctx, actorID, organizationID); err != nil {
+		return nil, err
+	}
+
+	stats, err := u.orgRepo.GetStats(ctx, organizationID)
+	if err != nil {
+		logger.Error("failed to get organization stats", "error", err)
+		return nil, err
+	}
+
+	resp := &dto.OrganizationStatsResponse{
+		OrganizationID: organizationID,
+		CoffeeShops:    make([]dto.CoffeeShopStatsResponse, len(stats)),
+	}
+	for i, s := range stats {
+		resp.CoffeeShops[i] = dto.CoffeeShopStatsResponse{
+			CoffeeShopID:    s.CoffeeShopID,
+			Name:            s.Name,
+			Ideas:           s.Ideas,
+			Likes:           s.Likes,
+			Comments:        s.Comments,
+			RewardsGiven:    s.RewardsGiven,
+			RewardsRedeemed: s.RewardsRedeemed,
+		}
+		resp.Total.Ideas += s.Ideas
+		resp.Total.Likes += s.Likes
+		resp.Total.Comments += s.Comments
+		resp.Total.RewardsGiven += s.RewardsGiven
+		resp.Total.RewardsRedeemed += s.RewardsRedeemed
+	}
+
+	logger.Info("organization stats retrieved successfully", "shops", len(stats))
+	return resp, nil
+}
+
+func toOrganizationResponse(o *models.Organization) *dto.OrganizationResponse {
+	return &dto.OrganizationResponse{
+		ID:          o.ID,
+		Name:        o.Name,
+		Description: o.Description,
+		CreatorID:   o.CreatorID,
+		CreatedAt:   o.CreatedAt,
+	}
+}
+
+func toOrganizationMemberResponse(m *models.OrganizationMember) *dto.OrganizationMemberResponse {
+	var name, phone string
+	if m.User.Name != nil {
+		name = *m.User.Name
+	}
+	if m.User.Phone != nil {
+		phone = *m.User.Phone
+	}
+	return &dto.OrganizationMemberResponse{
+		ID:        m.ID,
+		User:      dto.UserResponse{ID: m.User.ID, Name: name, Phone: phone},
+		Role:      m.Role.Name,
+		CreatedAt: m.CreatedAt,
+	}
+}
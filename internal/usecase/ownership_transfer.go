@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+type OwnershipTransferUsecase interface {
+	// CreateTransfer offers ownership of the coffee shop to one of its admins.
+	// Requires the ownership.manage permission in the coffee shop.
+	CreateTransfer(ctx context.Context, actorID, shopID uuid.UUID, req *dto.CreateOwnershipTransferRequest) (*dto.OwnershipTransferResponse, error)
+
+	// ListTransfers retrieves a paginated list of ownership transfers of the coffee shop in any status.
+	// Requires the ownership.manage permission in the coffee shop.
+	ListTransfers(ctx context.Context, actorID, shopID uuid.UUID, page pagination.Request) (pagination.Page[dto.OwnershipTransferResponse], error)
+
+	// CancelTransfer cancels a pending ownership transfer of the coffee shop.
+	// Requires the ownership.manage permission in the coffee shop.
+	CancelTransfer(ctx context.Context, actorID, shopID, transferID uuid.UUID) (*dto.OwnershipTransferResponse, error)
+
+	// ListMyTransfers retrieves pending ownership transfers offered to the user.
+	ListMyTransfers(ctx context.Context, userID uuid.UUID, page pagination.Request) (pagination.Page[dto.OwnershipTransferResponse], error)
+
+	// AcceptTransfer accepts an ownership transfer offered to the user and makes them an owner.
+	AcceptTransfer(ctx context.Context, userID, transferID uuid.UUID) (*dto.OwnershipTransferResponse, error)
+
+	// DeclineTransfer declines an ownership transfer offered to the user.
+	DeclineTransfer(ctx context.Context, userID, transferID uuid.UUID) (*dto.OwnershipTransferResponse, error)
+}
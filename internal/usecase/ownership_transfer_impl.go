@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ownershipTransferTTL is how long the recipient has to answer an ownership transfer.
+const ownershipTransferTTL = 72 * time.Hour
+
+type OwnershipTransferUsecaseImpl struct {
+	transferRepo   repository.OwnershipTransferRepository
+	workerShopRepo repository.WorkerCoffeeShopRepository
+	accessControl  AccessControlUsecase
+	logger         *slog.Logger
+}
+
+func NewOwnershipTransferUsecase(
+	transferRepo repository.OwnershipTransferRepository,
+	workerShopRepo repository.WorkerCoffeeShopRepository,
+	accessControl AccessControlUsecase,
+	logger *slog.Logger,
+) OwnershipTransferUsecase {
+	return &OwnershipTransferUsecaseImpl{
+		transferRepo:   transferRepo,
+		workerShopRepo: workerShopRepo,
+		accessControl:  accessControl,
+		logger:         logger,
+	}
+}
+
+func (u *OwnershipTransferUsecaseImpl) CreateTransfer(ctx context.Context, actorID, shopID uuid.UUID, req *dto.CreateOwnershipTransferRequest) (*dto.OwnershipTransferResponse, error) {
+	logger := u.logger.With("method", "CreateTransfer", "actorID", actorID, "shopID", shopID, "toUserID", req.ToUserID)
+	logger.Debug("starting to create ownership transfer")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermOwnershipManage); err != nil {
+		return nil, err
+	}
+	if req.ToUserID == actorID {
+		logger.Info("attempt to transfer ownership to self")
+		return nil, apperrors.NewErrNotValid("ownership cannot be transferred to yourself")
+	}
+
+	recipient, err := u.workerShopRepo.GetByUserIDAndShopID(ctx, req.ToUserID, shopID)
+	if err != nil {
+		var errNotFound *apperrors.ErrNotFound
+		if !errors.As(err, &errNotFound) {
+			logger.Error("failed to get recipient relation", "error", err)
+			return nil, err
+		}
+		logger.Info("recipient is not a worker of the coffee shop")
+		return nil, apperrors.NewErrNotValid("ownership can only be transferred to an admin of the coffee shop")
+	}
+	if recipient.Role.Name != models.RoleAdmin {
+		logger.Info("recipient is not an admin", "role", recipient.Role.Name)
+		return nil, apperrors.NewErrNotValid("ownership can only be transferred to an admin of the coffee shop")
+	}
+
+	created, err := u.transferRepo.Create(ctx, &models.OwnershipTransfer{
+		CoffeeShopID:  shopID,
+		FromUserID:    &actorID,
+		ToUserID:      req.ToUserID,
+		KeepOwnership: req.KeepOwnership,
+		Status:        models.TransferPending,
+		ExpiresAt:     time.Now().UTC().Add(ownershipTransferTTL),
+	})
+	if err != nil {
+		logger.Error("failed to create ownership transfer", "error", err)
+		return nil, err
+	}
+
+	logger.Info("ownership transfer created successfully", "transferID", created.ID)
+	return toOwnershipTransferResponse(created, time.Now()), nil
+}
+
+func (u *OwnershipTransferUsecaseImpl) ListTransfers(ctx context.Context, actorID, shopID uuid.UUID, page pagination.Request) (pagination.Page[dto.OwnershipTransferResponse], error) {
+	logger := u.logger.With("method", "ListTransfers", "actorID", actorID, "shopID", shopID, "limit", page.Limit)
+	logger.Debug("starting to list ownership transfers")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermOwnershipManage); err != nil {
+		return pagination.Page[dto.OwnershipTransferResponse]{}, err
+	}
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.OwnershipTransferResponse]{}, err
+	}
+
+	transfers, err := u.transferRepo.ListByCoffeeShopID(ctx, shopID, q)
+	if err != nil {
+		logger.Error("failed to list ownership transfers", "error", err)
+		return pagination.Page[dto.OwnershipTransferResponse]{}, err
+	}
+
+	logger.Info("ownership transfers listed successfully", "count", len(transfers.Items))
+	return toOwnershipTransferResponses(transfers), nil
+}
+
+func (u *OwnershipTransferUsecaseImpl) CancelTransfer(ctx context.Context, actorID, shopID, transferID uuid.UUID) (*dto.OwnershipTransferResponse, error) {
+	logger := u.logger.With("method", "CancelTransfer", "actorID", actorID, "shopID", shopID, "transferID", transferID)
+	logger.Debug("starting to cancel ownership transfer")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermOwnershipManage); err != nil {
+		return nil, err
+	}
+
+	transfer, err := u.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.CoffeeShopID != shopID {
+		logger.Info("ownership transfer belongs to another coffee shop")
+		return nil, apperrors.NewErrNotFound("ownership transfer", transferID.String())
+	}
+
+	if _, err := u.transferRepo.Cancel(ctx, transferID, time.Now().UTC()); err != nil {
+		logger.Info("failed to cancel ownership transfer", "error", err)
+		return nil, err
+	}
+
+	logger.Info("ownership transfer cancelled successfully")
+	return u.getTransferResponse(ctx, transferID)
+}
+
+func (u *OwnershipTransferUsecaseImpl) ListMyTransfers(ctx context.Context, userID uuid.UUID, page pagination.Request) (pagination.Page[dto.OwnershipTransferResponse], error) {
+	logger := u.logger.With("method", "ListMyTransfers", "userID", userID, "limit", page.Limit)
+	logger.Debug("starting to list ownership transfers of the user")
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.OwnershipTransferResponse]{}, err
+	}
+
+	transfers, err := u.transferRepo.ListPendingForUser(ctx, userID, time.Now().UTC(), q)
+	if err != nil {
+		logger.Error("failed to list ownership transfers", "error", err)
+		return pagination.Page[dto.OwnershipTransferResponse]{}, err
+	}
+
+	logger.Info("ownership transfers of the user listed successfully", "count", len(transfers.Items))
+	return toOwnershipTransferResponses(transfers), nil
+}
+
+func (u *OwnershipTransferUsecaseImpl) AcceptTransfer(ctx context.Context, userID, transferID uuid.UUID) (*dto.OwnershipTransferResponse, error) {
+	logger := u.logger.With("method", "AcceptTransfer", "userID", userID, "transferID", transferID)
+	logger.Debug("starting to accept ownership transfer")
+
+	if err := u.checkRecipient(ctx, logger, userID, transferID); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.transferRepo.Accept(ctx, transferID, time.Now().UTC()); err != nil {
+		logger.Info("failed to accept ownership transfer", "error", err)
+		return nil, err
+	}
+
+	logger.Info("ownership transfer accepted successfully")
+	return u.getTransferResponse(ctx, transferID)
+}
+
+func (u *OwnershipTransferUsecaseImpl) DeclineTransfer(ctx context.Context, userID, transferID uuid.UUID) (*dto.OwnershipTransferResponse, error) {
+	logger := u.logger.With("method", "DeclineTransfer", "userID", userID, "transferID", transferID)
+	logger.Debug("starting to decline ownership transfer")
+
+	if err := u.checkRecipient(ctx, logger, userID, transferID); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.transferRepo.Decline(ctx, transferID, time.Now().UTC()); err != nil {
+		logger.Info("failed to decline ownership transfer", "error", err)
+		return nil, err
+	}
+
+	logger.Info("ownership transfer declined successfully")
+	return u.getTransferResponse(ctx, transferID)
+}
+
+// checkRecipient reports transfers offered to other users as not found so that their existence is not revealed.
+func (u *OwnershipTransferUsecaseImpl) checkRecipient(ctx context.Context, logger *slog.Logger, userID, transferID uuid.UUID) error {
+	transfer, err := u.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		return err
+	}
+	if transfer.ToUserID != userID {
+		logger.Info("ownership transfer is not offered to the user")
+		return apperrors.NewErrNotFound("ownership transfer", transferID.String())
+	}
+	return nil
+}
+
+func (u *OwnershipTransferUsecaseImpl) getTransferResponse(ctx context.Context, transferID uuid.UUID) (*dto.OwnershipTransferResponse, error) {
+	transfer, err := u.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return toOwnershipTransferResponse(transfer, time.Now()), nil
+}
+
+func toOwnershipTransferResponses(p pagination.Page[models.OwnershipTransfer]) pagination.Page[dto.OwnershipTransferResponse] {
+	now := time.Now()
+	return pagination.Map(p, func(t models.OwnershipTransfer) dto.OwnershipTransferResponse {
+		return *toOwnershipTransferResponse(&t, now)
+	})
+}
+
+func toOwnershipTransferResponse(t *models.OwnershipTransfer, now time.Time) *dto.OwnershipTransferResponse {
+	return &dto.OwnershipTransferResponse{
+		ID:             t.ID,
+		CoffeeShopID:   t.CoffeeShopID,
+		CoffeeShopName: t.CoffeeShop.Name,
+		FromUserID:     t.FromUserID,
+		ToUserID:       t.ToUserID,
+		KeepOwnership:  t.KeepOwnership,
+		Status:         t.StatusAt(now),
+		ExpiresAt:      t.ExpiresAt,
+		RespondedAt:    t.RespondedAt,
+		CreatedAt:      t.CreatedAt,
+	}
+}
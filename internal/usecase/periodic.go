@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// runPeriodically calls fn right away and then every interval until ctx is done. fn logs its
+// own errors; a failed run is simply retried on the next tick.
+func runPeriodically(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context), logger *slog.Logger) {
+	logger.Info("starting "+name, "interval", interval.String())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fn(ctx)
+
+		select {
+		case <-ctx.Done():
+			logger.Info(name + " stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
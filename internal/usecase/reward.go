@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
@@ -10,6 +11,9 @@ type RewardUsecase interface {
 	GiveReward(ctx context.Context, actorID uuid.UUID, req *dto.GiveRewardRequest) (*dto.RewardResponse, error)
 	RevokeReward(ctx context.Context, actorID, rewardID uuid.UUID) error
 	GetReward(ctx context.Context, rewardID uuid.UUID) (*dto.RewardResponse, error)
-	GetRewardsForCoffeeShop(ctx context.Context, actorID, coffeeShopID uuid.UUID, page, limit int) ([]dto.RewardResponse, error)
-	GetMyRewards(ctx context.Context, userID uuid.UUID, page, limit int) ([]dto.RewardResponse, error)
+	GetRewardsForCoffeeShop(ctx context.Context, actorID, coffeeShopID uuid.UUID, page pagination.Request) (pagination.Page[dto.RewardResponse], error)
+	GetMyRewards(ctx context.Context, userID uuid.UUID, page pagination.Request) (pagination.Page[dto.RewardResponse], error)
+	RedeemReward(ctx context.Context, actorID uuid.UUID, req *dto.RedeemRewardRequest) (*dto.RewardResponse, error)
+	// ExpireRewards marks rewards that were not redeemed in time as expired.
+	ExpireRewards(ctx context.Context) error
 }
@@ -2,26 +2,44 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"log/slog"
+	"math/big"
+	"strings"
 	"time"
 
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
 
+const (
+	// redemptionCodeTTL is how long a redemption code stays valid after it is issued.
+	redemptionCodeTTL = 10 * time.Minute
+	// redemptionCodeAlphabet leaves out characters that are easy to confuse when read aloud or typed (0/O, 1/I).
+	redemptionCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	redemptionCodeLength   = 8
+)
+
 type RewardUsecaseImpl struct {
-	rewardRepo repository.RewardRepository
-	ideaRepo   repository.IdeaRepository
-	logger     *slog.Logger
+	rewardRepo     repository.RewardRepository
+	rewardTypeRepo repository.RewardTypeRepository
+	ideaRepo       repository.IdeaRepository
+	accessControl  AccessControlUsecase
+	logger         *slog.Logger
 }
 
-func NewRewardUsecase(rewardRepo repository.RewardRepository, ideaRepo repository.IdeaRepository, logger *slog.Logger) RewardUsecase {
+func NewRewardUsecase(rewardRepo repository.RewardRepository, rewardTypeRepo repository.RewardTypeRepository, ideaRepo repository.IdeaRepository, accessControl AccessControlUsecase, logger *slog.Logger) RewardUsecase {
 	return &RewardUsecaseImpl{
-		rewardRepo: rewardRepo,
-		ideaRepo:   ideaRepo,
-		logger:     logger,
+		rewardRepo:     rewardRepo,
+		rewardTypeRepo: rewardTypeRepo,
+		ideaRepo:       ideaRepo,
+		accessControl:  accessControl,
+		logger:         logger,
 	}
 }
 
@@ -34,8 +52,31 @@ func (u *RewardUsecaseImpl) GiveReward(ctx context.Context, actorID uuid.UUID, r
 		logger.Error("failed to get idea", "error", err)
 		return nil, err
 	}
+	if idea.CoffeeShopID == nil {
+		logger.Error("idea has no associated coffee shop ID")
+		return nil, errors.New("idea is not associated with a coffee shop")
+	}
 
-	now := time.Now()
+	if err := u.accessControl.Can(ctx, actorID, *idea.CoffeeShopID, models.PermRewardGive); err != nil {
+		return nil, err
+	}
+
+	rewardType, err := u.rewardTypeRepo.GetRewardType(ctx, req.RewardTypeID)
+	if err != nil {
+		logger.Info("failed to get reward type", "error", err)
+		return nil, err
+	}
+	available, err := u.rewardTypeRepo.IsAvailableInShop(ctx, rewardType.ID, *idea.CoffeeShopID)
+	if err != nil {
+		logger.Error("failed to check reward type availability", "error", err)
+		return nil, err
+	}
+	if !available {
+		logger.Warn("reward type belongs to another coffee shop", "rewardTypeID", rewardType.ID.String())
+		return nil, apperrors.NewErrNotValid("reward type belongs to another coffee shop")
+	}
+
+	now := time.Now().UTC()
 	reward := &models.Reward{
 		ReceiverID:   idea.CreatorID,
 		CoffeeShopID: idea.CoffeeShopID,
@@ -44,10 +85,15 @@ func (u *RewardUsecaseImpl) GiveReward(ctx context.Context, actorID uuid.UUID, r
 		IsActivated:  false,
 		GivenAt:      &now,
 	}
+	if rewardType.ValidityDays != nil {
+		expiresAt := now.AddDate(0, 0, *rewardType.ValidityDays)
+		reward.ExpiresAt = &expiresAt
+	}
 
-	createdReward, err := u.rewardRepo.CreateReward(ctx, reward)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	createdReward, err := u.rewardRepo.CreateRewardWithinLimits(ctx, reward, monthStart)
 	if err != nil {
-		logger.Error("failed to create reward in repository", "error", err)
+		logger.Info("failed to create reward in repository", "error", err)
 		return nil, err
 	}
 
@@ -60,10 +106,19 @@ func (u *RewardUsecaseImpl) RevokeReward(ctx context.Context, actorID, rewardID
 	logger.Debug("starting to revoke a reward by admin")
 
 	// Check if reward exists before deleting
-	if _, err := u.rewardRepo.GetReward(ctx, rewardID); err != nil {
+	reward, err := u.rewardRepo.GetReward(ctx, rewardID)
+	if err != nil {
 		logger.Error("failed to get reward for deletion", "error", err)
 		return err
 	}
+	if reward.CoffeeShopID == nil {
+		logger.Error("reward has no associated coffee shop ID")
+		return errors.New("reward is not associated with a coffee shop")
+	}
+
+	if err := u.accessControl.Can(ctx, actorID, *reward.CoffeeShopID, models.PermRewardGive); err != nil {
+		return err
+	}
 
 	if err := u.rewardRepo.DeleteReward(ctx, rewardID); err != nil {
 		logger.Error("failed to delete reward from repository", "error", err)
@@ -88,46 +143,140 @@ func (u *RewardUsecaseImpl) GetReward(ctx context.Context, rewardID uuid.UUID) (
 	return toRewardResponse(reward), nil
 }
 
-func (u *RewardUsecaseImpl) GetRewardsForCoffeeShop(ctx context.Context, actorID, coffeeShopID uuid.UUID, page, limit int) ([]dto.RewardResponse, error) {
+func (u *RewardUsecaseImpl) GetRewardsForCoffeeShop(ctx context.Context, actorID, coffeeShopID uuid.UUID, page pagination.Request) (pagination.Page[dto.RewardResponse], error) {
 	logger := u.logger.With("method", "GetRewardsForCoffeeShop", "actorID", actorID.String(), "coffeeShopID", coffeeShopID.String())
 	logger.Debug("starting to get rewards for coffee shop")
 
-	if limit <= 0 || limit > 50 {
-		limit = 25
+	if err := u.accessControl.CanAccessAsWorker(ctx, actorID, coffeeShopID); err != nil {
+		return pagination.Page[dto.RewardResponse]{}, err
 	}
-	if page < 0 {
-		page = 0
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.RewardResponse]{}, err
 	}
 
-	rewards, err := u.rewardRepo.GetRewardsByCoffeeShopID(ctx, coffeeShopID, page*limit, limit)
+	rewards, err := u.rewardRepo.GetRewardsByCoffeeShopID(ctx, coffeeShopID, q)
 	if err != nil {
 		logger.Error("failed to get rewards for coffee shop", "error", err)
-		return nil, err
+		return pagination.Page[dto.RewardResponse]{}, err
 	}
 
-	logger.Info("rewards for coffee shop fetched successfully", "count", len(rewards))
-	return toRewardResponses(rewards), nil
+	logger.Info("rewards for coffee shop fetched successfully", "count", len(rewards.Items))
+	return pagination.WithItems(rewards, toRewardResponses(rewards.Items)), nil
 }
 
-func (u *RewardUsecaseImpl) GetMyRewards(ctx context.Context, userID uuid.UUID, page, limit int) ([]dto.RewardResponse, error) {
+func (u *RewardUsecaseImpl) GetMyRewards(ctx context.Context, userID uuid.UUID, page pagination.Request) (pagination.Page[dto.RewardResponse], error) {
 	logger := u.logger.With("method", "GetMyRewards", "userID", userID.String())
 	logger.Debug("starting to get my rewards")
 
-	if limit <= 0 || limit > 50 {
-		limit = 25
-	}
-	if page < 0 {
-		page = 0
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.RewardResponse]{}, err
 	}
 
-	rewards, err := u.rewardRepo.GetRewardsByUserID(ctx, userID, page*limit, limit)
+	rewards, err := u.rewardRepo.GetRewardsByUserID(ctx, userID, q)
 	if err != nil {
 		logger.Error("failed to get rewards for user", "error", err)
+		return pagination.Page[dto.RewardResponse]{}, err
+	}
+
+	if err := u.issueRedemptionCodes(ctx, rewards.Items); err != nil {
+		logger.Error("failed to issue redemption codes", "error", err)
+		return pagination.Page[dto.RewardResponse]{}, err
+	}
+
+	logger.Info("user rewards fetched successfully", "count", len(rewards.Items))
+	return pagination.Map(rewards, func(r models.Reward) dto.RewardResponse {
+		return *toReceiverRewardResponse(&r)
+	}), nil
+}
+
+func (u *RewardUsecaseImpl) ExpireRewards(ctx context.Context) error {
+	logger := u.logger.With("method", "ExpireRewards")
+
+	expired, err := u.rewardRepo.ExpireRewards(ctx, time.Now())
+	if err != nil {
+		logger.Error("failed to expire rewards", "error", err)
+		return err
+	}
+
+	if expired > 0 {
+		logger.Info("rewards expired", "count", expired)
+	}
+	return nil
+}
+
+func (u *RewardUsecaseImpl) RedeemReward(ctx context.Context, actorID uuid.UUID, req *dto.RedeemRewardRequest) (*dto.RewardResponse, error) {
+	logger := u.logger.With("method", "RedeemReward", "actorID", actorID.String(), "coffeeShopID", req.CoffeeShopID.String())
+	logger.Debug("starting to redeem a reward")
+
+	if err := u.accessControl.Can(ctx, actorID, req.CoffeeShopID, models.PermRewardRedeem); err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+	reward, err := u.rewardRepo.GetRewardByRedemptionCode(ctx, code)
+	if err != nil {
+		logger.Info("failed to get reward by redemption code", "error", err)
+		return nil, err
+	}
+	logger = logger.With("rewardID", reward.ID.String())
+
+	if reward.CoffeeShopID == nil || *reward.CoffeeShopID != req.CoffeeShopID {
+		logger.Warn("redemption code belongs to another coffee shop")
+		return nil, apperrors.NewErrAccessDenied("reward was issued by another coffee shop")
+	}
+
+	redeemed, err := u.rewardRepo.ActivateReward(ctx, reward.ID, code, actorID, time.Now())
+	if err != nil {
+		logger.Info("failed to activate reward", "error", err)
 		return nil, err
 	}
 
-	logger.Info("user rewards fetched successfully", "count", len(rewards))
-	return toRewardResponses(rewards), nil
+	logger.Info("reward redeemed successfully")
+	return toRewardResponse(redeemed), nil
+}
+
+// issueRedemptionCodes gives every reward that is not activated yet a fresh code
+// once its current one is missing or has less than half of its lifetime left.
+func (u *RewardUsecaseImpl) issueRedemptionCodes(ctx context.Context, rewards []models.Reward) error {
+	now := time.Now()
+	for i := range rewards {
+		r := &rewards[i]
+		if r.IsActivated || r.IsExpired || (r.ExpiresAt != nil && !r.ExpiresAt.After(now)) {
+			continue
+		}
+		if r.RedemptionCode != nil && r.RedemptionCodeExpiresAt != nil && r.RedemptionCodeExpiresAt.Sub(now) > redemptionCodeTTL/2 {
+			continue
+		}
+
+		code, err := generateRedemptionCode()
+		if err != nil {
+			return err
+		}
+		expiresAt := now.Add(redemptionCodeTTL)
+		if err := u.rewardRepo.SetRedemptionCode(ctx, r.ID, code, expiresAt); err != nil {
+			return err
+		}
+		r.RedemptionCode = &code
+		r.RedemptionCodeExpiresAt = &expiresAt
+	}
+	return nil
+}
+
+func generateRedemptionCode() (string, error) {
+	result := make([]byte, redemptionCodeLength)
+	for i := range result {
+		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(redemptionCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		result[i] = redemptionCodeAlphabet[num.Int64()]
+	}
+	return string(result), nil
 }
 
 func toRewardResponse(r *models.Reward) *dto.RewardResponse {
@@ -138,11 +287,24 @@ func toRewardResponse(r *models.Reward) *dto.RewardResponse {
 		IdeaID:       r.IdeaID,
 		RewardTypeID: r.RewardTypeID,
 		IsActivated:  r.IsActivated,
+		ActivatedAt:  r.ActivatedAt,
 		GivenAt:      r.GivenAt,
+		ExpiresAt:    r.ExpiresAt,
+		IsExpired:    r.IsExpired,
 		CreatedAt:    r.CreatedAt,
 	}
 }
 
+// toReceiverRewardResponse also exposes the redemption code, so it must only be used for the reward's receiver.
+func toReceiverRewardResponse(r *models.Reward) *dto.RewardResponse {
+	resp := toRewardResponse(r)
+	if !r.IsActivated && !r.IsExpired {
+		resp.RedemptionCode = r.RedemptionCode
+		resp.RedemptionCodeExpiresAt = r.RedemptionCodeExpiresAt
+	}
+	return resp
+}
+
 func toRewardResponses(rewards []models.Reward) []dto.RewardResponse {
 	res := make([]dto.RewardResponse, len(rewards))
 	for i, r := range rewards {
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunRewardExpirySweeper marks expired rewards right away and then every interval until ctx is done.
+func RunRewardExpirySweeper(ctx context.Context, uc RewardUsecase, interval time.Duration, logger *slog.Logger) {
+	logger = logger.With("component", "RewardExpirySweeper")
+	runPeriodically(ctx, "reward expiry sweeper", interval, func(ctx context.Context) {
+		_ = uc.ExpireRewards(ctx)
+	}, logger)
+}
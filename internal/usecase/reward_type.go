@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
@@ -11,7 +12,10 @@ type RewardTypeUsecase interface {
 	GetRewardType(ctx context.Context, rewardTypeID uuid.UUID) (*dto.RewardTypeResponse, error)
 
 	// GetRewardsTypeFromCoffeeShop Получить все типы нарграды по uuid Кофейни на которую они зарегестрированны. Возвращает ошибку, если есть проблемы с БД.
-	GetRewardsTypesFromCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page, limit int) ([]dto.RewardTypeResponse, error)
+	GetRewardsTypesFromCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Request) (pagination.Page[dto.RewardTypeResponse], error)
+
+	// GetRewardsTypesFromOrganization returns reward types shared by all coffee shops of the organization.
+	GetRewardsTypesFromOrganization(ctx context.Context, organizationID uuid.UUID, page pagination.Request) (pagination.Page[dto.RewardTypeResponse], error)
 
 	// CreateRewardType Создать тип награды. Проверяется принадлежность создателя к кофейне и роль создателя.
 	// Возвращает ошибку если этот пользователь не обладает правами чтобы создать тип награды в этой кофене
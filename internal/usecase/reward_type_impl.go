@@ -4,29 +4,31 @@ import (
 	"context"
 	"log/slog"
 
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
 
 type RewardTypeUsecaseImpl struct {
-	rep         repository.RewardTypeRepository
-	csRep       repository.CoffeeShopRep
-	workerCsRep repository.WorkerCoffeeShopRepository
-	logger      *slog.Logger
+	rep           repository.RewardTypeRepository
+	csRep         repository.CoffeeShopRep
+	accessControl AccessControlUsecase
+	logger        *slog.Logger
 }
 
 func NewRewardTypeUsecase(rep repository.RewardTypeRepository,
 	csRep repository.CoffeeShopRep,
-	workerCsRep repository.WorkerCoffeeShopRepository,
+	accessControl AccessControlUsecase,
 	logger *slog.Logger,
 ) RewardTypeUsecase {
 	return &RewardTypeUsecaseImpl{
-		rep:         rep,
-		csRep:       csRep,
-		workerCsRep: workerCsRep,
-		logger:      logger,
+		rep:           rep,
+		csRep:         csRep,
+		accessControl: accessControl,
+		logger:        logger,
 	}
 }
 
@@ -35,12 +37,19 @@ func (r *RewardTypeUsecaseImpl) CreateRewardType(ctx context.Context, creatorID
 	logger := r.logger.With("method", "CreateRewardType", "creator id", creatorID.String(), "coffee shop id", request.CoffeeShopID.String())
 	logger.Debug("starting create reward type")
 
-	err := CheckShopAdminAccess(ctx, logger, r.workerCsRep, creatorID, request.CoffeeShopID)
+	rewardType := toRewardType(request)
+	err := r.canManage(ctx, creatorID, rewardType)
 	if err != nil {
 		return nil, err
 	}
 
-	rewardType := toRewardType(request)
+	for _, limit := range []*int{request.ValidityDays, request.TotalStock, request.MonthlyBudget} {
+		if limit != nil && *limit <= 0 {
+			logger.Info("invalid reward type limit")
+			return nil, apperrors.NewErrNotValid("validity days, total stock and monthly budget must be positive")
+		}
+	}
+
 	savedRewardType, err := r.rep.CreateReward(ctx, rewardType)
 	if err != nil {
 		logger.Error("unexpected error when creating reward type", "error", err.Error())
@@ -60,7 +69,7 @@ func (r *RewardTypeUsecaseImpl) DeleteRewardType(ctx context.Context, deleterID
 		return err
 	}
 
-	err = CheckShopAdminAccess(ctx, logger, r.workerCsRep, deleterID, *rewardType.CoffeeShopID)
+	err = r.canManage(ctx, deleterID, rewardType)
 	if err != nil {
 		return err
 	}
@@ -87,15 +96,35 @@ func (r *RewardTypeUsecaseImpl) GetRewardType(ctx context.Context, rewardTypeID
 }
 
 // GetRewardsTypesFromCoffeeShop implements RewardTypeUsecase.
-func (r *RewardTypeUsecaseImpl) GetRewardsTypesFromCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page int, limit int) ([]dto.RewardTypeResponse, error) {
+func (r *RewardTypeUsecaseImpl) GetRewardsTypesFromCoffeeShop(ctx context.Context, coffeeShopID uuid.UUID, page pagination.Request) (pagination.Page[dto.RewardTypeResponse], error) {
 	logger := r.logger.With("method", "GetRewardsTypeFromCoffeeShopID", "coffee shop id", coffeeShopID.String())
 	logger.Debug("starting get reward type from coffee shop id")
-	rewardsTypes, err := r.rep.GetRewardsTypeByCoffeeShopID(ctx, coffeeShopID, limit*page, limit)
+	q, err := pagination.NewQuery(page)
 	if err != nil {
-		return nil, err
+		return pagination.Page[dto.RewardTypeResponse]{}, err
+	}
+	rewardsTypes, err := r.rep.GetRewardsTypeByCoffeeShopID(ctx, coffeeShopID, q)
+	if err != nil {
+		return pagination.Page[dto.RewardTypeResponse]{}, err
+	}
+
+	return pagination.WithItems(rewardsTypes, toRewardsTypesResponses(rewardsTypes.Items)), nil
+}
+
+// GetRewardsTypesFromOrganization implements RewardTypeUsecase.
+func (r *RewardTypeUsecaseImpl) GetRewardsTypesFromOrganization(ctx context.Context, organizationID uuid.UUID, page pagination.Request) (pagination.Page[dto.RewardTypeResponse], error) {
+	logger := r.logger.With("method", "GetRewardsTypesFromOrganization", "organization id", organizationID.String())
+	logger.Debug("starting get reward types from organization")
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		return pagination.Page[dto.RewardTypeResponse]{}, err
+	}
+	rewardsTypes, err := r.rep.GetRewardsTypeByOrganizationID(ctx, organizationID, q)
+	if err != nil {
+		return pagination.Page[dto.RewardTypeResponse]{}, err
 	}
 
-	return toRewardsTypesResponses(rewardsTypes), nil
+	return pagination.WithItems(rewardsTypes, toRewardsTypesResponses(rewardsTypes.Items)), nil
 }
 
 // UpdateRewardType implements RewardTypeUsecase.
@@ -107,14 +136,27 @@ func (r *RewardTypeUsecaseImpl) UpdateRewardType(ctx context.Context, updaterID
 		return err
 	}
 
-	err = CheckShopAdminAccess(ctx, logger, r.workerCsRep, updaterID, *rewardType.CoffeeShopID)
+	err = r.canManage(ctx, updaterID, rewardType)
 	if err != nil {
 		return err
 	}
 
+	for _, limit := range []*int{request.ValidityDays, request.TotalStock, request.MonthlyBudget} {
+		if limit != nil && *limit < 0 {
+			logger.Info("invalid reward type limit")
+			return apperrors.NewErrNotValid("validity days, total stock and monthly budget must not be negative")
+		}
+	}
+
+	if request.Title != nil {
+		rewardType.Title = *request.Title
+	}
 	if request.Description != nil {
 		rewardType.Description = *request.Description
 	}
+	updateLimit(&rewardType.ValidityDays, request.ValidityDays)
+	updateLimit(&rewardType.TotalStock, request.TotalStock)
+	updateLimit(&rewardType.MonthlyBudget, request.MonthlyBudget)
 
 	err = r.rep.UpdateReward(ctx, rewardType)
 	if err != nil {
@@ -125,29 +167,65 @@ func (r *RewardTypeUsecaseImpl) UpdateRewardType(ctx context.Context, updaterID
 	return nil
 }
 
+// canManage checks the reward_type.manage permission in the coffee shop or the organization of the reward type.
+func (r *RewardTypeUsecaseImpl) canManage(ctx context.Context, actorID uuid.UUID, rewardType *models.RewardType) error {
+	if rewardType.OrganizationID != nil {
+		return r.accessControl.CanInOrganization(ctx, actorID, *rewardType.OrganizationID, models.PermRewardTypeManage)
+	}
+	if rewardType.CoffeeShopID == nil {
+		return apperrors.NewErrNotValid("reward type has neither a coffee shop nor an organization")
+	}
+	return r.accessControl.Can(ctx, actorID, *rewardType.CoffeeShopID, models.PermRewardTypeManage)
+}
+
+// updateLimit applies an optional limit update, where 0 removes the limit.
+func updateLimit(limit **int, update *int) {
+	if update == nil {
+		return
+	}
+	if *update == 0 {
+		*limit = nil
+		return
+	}
+	*limit = update
+}
+
 func toRewardType(request *dto.CreateRewardTypeRequest) *models.RewardType {
-	return &models.RewardType{
-		CoffeeShopID: &request.CoffeeShopID,
-		Description:  request.Description,
+	rewardType := &models.RewardType{
+		Title:         request.Title,
+		Description:   request.Description,
+		ValidityDays:  request.ValidityDays,
+		TotalStock:    request.TotalStock,
+		MonthlyBudget: request.MonthlyBudget,
 	}
+	if request.OrganizationID != nil {
+		rewardType.OrganizationID = request.OrganizationID
+	} else {
+		rewardType.CoffeeShopID = &request.CoffeeShopID
+	}
+	return rewardType
 }
 
 func toRewardTypeResponse(rewardType *models.RewardType) *dto.RewardTypeResponse {
-	return &dto.RewardTypeResponse{
-		ID:           rewardType.ID,
-		CoffeeShopID: *rewardType.CoffeeShopID,
-		Description:  rewardType.Description,
+	resp := &dto.RewardTypeResponse{
+		ID:             rewardType.ID,
+		OrganizationID: rewardType.OrganizationID,
+		Title:          rewardType.Title,
+		Description:    rewardType.Description,
+		ValidityDays:   rewardType.ValidityDays,
+		TotalStock:     rewardType.TotalStock,
+		MonthlyBudget:  rewardType.MonthlyBudget,
+	}
+	if rewardType.CoffeeShopID != nil {
+		resp.CoffeeShopID = *rewardType.CoffeeShopID
 	}
+	return resp
 }
 
 func toRewardsTypesResponses(rewardsTypes []models.RewardType) []dto.RewardTypeResponse {
 	responses := make([]dto.RewardTypeResponse, len(rewardsTypes))
 	for i := range rewardsTypes {
-		responses[i] = dto.RewardTypeResponse{
-			ID:           rewardsTypes[i].ID,
-			CoffeeShopID: *rewardsTypes[i].CoffeeShopID,
-			Description:  rewardsTypes[i].Description,
-		}
+		responses[i] = *toRewardTypeResponse(&rewardsTypes[i])
 	}
 
 	return responses
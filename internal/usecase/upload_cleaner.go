@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunUploadCleaner deletes expired uploads right away and then every interval until ctx is done.
+func RunUploadCleaner(ctx context.Context, uc ImageUsecase, interval time.Duration, logger *slog.Logger) {
+	logger = logger.With("component", "UploadCleaner")
+	runPeriodically(ctx, "upload cleaner", interval, func(ctx context.Context) {
+		_ = uc.CleanupUploads(ctx)
+	}, logger)
+}
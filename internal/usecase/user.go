@@ -3,12 +3,15 @@ package usecase
 import (
 	"context"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 )
 
 type UserUsecase interface {
 	UpdateUser(ctx context.Context, actorID, ID uuid.UUID, req *dto.UpdateUserRequest) error
-	GetAllUsers(ctx context.Context, actorID uuid.UUID, page, limit int) ([]dto.UserResponse, error)
+	// GetAllUsers returns the users who work in the coffee shop or posted ideas in it.
+	GetAllUsers(ctx context.Context, actorID, coffeeShopID uuid.UUID, page pagination.Request) (pagination.Page[dto.UserResponse], error)
+	// GetUser returns the profile of the actor, or of a user of a coffee shop where the actor has PermUserView.
 	GetUser(ctx context.Context, actorID, ID uuid.UUID) (*dto.UserResponse, error)
 	DeleteUser(ctx context.Context, actorID, ID uuid.UUID) error
 }
@@ -8,24 +8,28 @@ import (
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
 
 type UserUsecaseImpl struct {
-	rep         repository.UserRep
-	workerCsRep repository.WorkerCoffeeShopRepository
-	logger      *slog.Logger
+	rep           repository.UserRep
+	workerCsRep   repository.WorkerCoffeeShopRepository
+	accessControl AccessControlUsecase
+	logger        *slog.Logger
 }
 
 func NewUserUsecase(rep repository.UserRep,
 	workerCsRep repository.WorkerCoffeeShopRepository,
+	accessControl AccessControlUsecase,
 	logger *slog.Logger,
 ) UserUsecase {
 	return &UserUsecaseImpl{
-		rep:         rep,
-		workerCsRep: workerCsRep,
-		logger:      logger,
+		rep:           rep,
+		workerCsRep:   workerCsRep,
+		accessControl: accessControl,
+		logger:        logger,
 	}
 }
 
@@ -38,7 +42,16 @@ func (u *UserUsecaseImpl) DeleteUser(ctx context.Context, requesterID, ID uuid.U
 		logger.Info("access denied")
 		return apperrors.NewErrAccessDenied("forbidden")
 	}
-	err := u.rep.DeleteUser(ctx, ID)
+	soleOwner, err := u.workerCsRep.HasSoleOwnedShop(ctx, ID)
+	if err != nil {
+		logger.Error("failed to check owned coffee shops", "error", err.Error())
+		return err
+	}
+	if soleOwner {
+		logger.Info("user is the only owner of a coffee shop")
+		return apperrors.NewErrConflict("user is the only owner of a coffee shop; transfer ownership first")
+	}
+	err = u.rep.DeleteUser(ctx, ID)
 	if err != nil {
 		var errNotFound *apperrors.ErrNotFound
 		if errors.As(err, &errNotFound) {
@@ -54,29 +67,27 @@ func (u *UserUsecaseImpl) DeleteUser(ctx context.Context, requesterID, ID uuid.U
 }
 
 // GetAllUsers implements IUserUsecase.
-func (u *UserUsecaseImpl) GetAllUsers(ctx context.Context, actorID uuid.UUID, page int, limit int) ([]dto.UserResponse, error) {
-	logger := u.logger.With("method", "GetAllUsers", "page", page, "limit", limit)
+func (u *UserUsecaseImpl) GetAllUsers(ctx context.Context, actorID, coffeeShopID uuid.UUID, page pagination.Request) (pagination.Page[dto.UserResponse], error) {
+	logger := u.logger.With("method", "GetAllUsers", "shopID", coffeeShopID, "limit", page.Limit)
 	logger.Debug("starting get all users")
 
-	err := CheckAnyShopAdminAccess(ctx, logger, u.workerCsRep, actorID)
-	if err != nil {
-		return nil, err
+	if err := u.accessControl.Can(ctx, actorID, coffeeShopID, models.PermUserView); err != nil {
+		return pagination.Page[dto.UserResponse]{}, err
 	}
 
-	if limit <= 0 || limit > 25 {
-		limit = 25
-	}
-	if page < 0 {
-		page = 0
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err.Error())
+		return pagination.Page[dto.UserResponse]{}, err
 	}
-	users, err := u.rep.GetAllUsers(ctx, limit, limit*page)
+	users, err := u.rep.GetCoffeeShopUsers(ctx, coffeeShopID, q)
 	if err != nil {
 		logger.Error("failed to get all users", "error", err.Error())
-		return nil, err
+		return pagination.Page[dto.UserResponse]{}, err
 	}
 
-	logger.Info("users fetched successfully", "count", len(users))
-	return toResponses(users), nil
+	logger.Info("users fetched successfully", "count", len(users.Items))
+	return pagination.WithItems(users, toResponses(users.Items)), nil
 }
 
 // GetUser implements IUserUsecase.
@@ -85,7 +96,7 @@ func (u *UserUsecaseImpl) GetUser(ctx context.Context, actorID, ID uuid.UUID) (*
 	logger.Debug("starting get user")
 
 	if !isOwner(actorID, ID) {
-		if err := CheckAnyShopAdminAccess(ctx, logger, u.workerCsRep, actorID); err != nil {
+		if err := u.canViewUser(ctx, logger, actorID, ID); err != nil {
 			return nil, err
 		}
 	}
@@ -105,6 +116,21 @@ func (u *UserUsecaseImpl) GetUser(ctx context.Context, actorID, ID uuid.UUID) (*
 	return toResponse(user), nil
 }
 
+// canViewUser returns ErrAccessDenied unless the actor has PermUserView in a coffee shop the user
+// works in or posted ideas in.
+func (u *UserUsecaseImpl) canViewUser(ctx context.Context, logger *slog.Logger, actorID, userID uuid.UUID) error {
+	allowed, err := u.rep.HasRoleInRelatedShop(ctx, actorID, userID, models.RolesWith(models.PermUserView))
+	if err != nil {
+		logger.Error("failed to check the roles of the actor", "error", err.Error())
+		return err
+	}
+	if !allowed {
+		logger.Info("access denied")
+		return apperrors.NewErrAccessDenied("forbidden")
+	}
+	return nil
+}
+
 // UpdateUser implements IUserUsecase.
 func (u *UserUsecaseImpl) UpdateUser(ctx context.Context, requesterID, ID uuid.UUID, req *dto.UpdateUserRequest) error {
 	logger := u.logger.With("method", "UpdateUser", "userID", ID.String())
@@ -9,6 +9,7 @@ import (
 	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/google/uuid"
 )
@@ -17,6 +18,8 @@ type WorkerCoffeeShopUsecaseImpl struct {
 	workerShopRepo repository.WorkerCoffeeShopRepository
 	coffeeShopRepo repository.CoffeeShopRep
 	userRepo       repository.UserRep
+	roleRepo       repository.RoleRepository
+	accessControl  AccessControlUsecase
 	logger         *slog.Logger
 }
 
@@ -24,12 +27,16 @@ func NewWorkerCoffeeShopUsecase(
 	workerShopRepo repository.WorkerCoffeeShopRepository,
 	coffeeShopRepo repository.CoffeeShopRep,
 	userRepo repository.UserRep,
+	roleRepo repository.RoleRepository,
+	accessControl AccessControlUsecase,
 	logger *slog.Logger,
 ) WorkerCoffeeShopUsecase {
 	return &WorkerCoffeeShopUsecaseImpl{
 		workerShopRepo: workerShopRepo,
 		coffeeShopRepo: coffeeShopRepo,
 		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		accessControl:  accessControl,
 		logger:         logger,
 	}
 }
@@ -38,7 +45,16 @@ func (u *WorkerCoffeeShopUsecaseImpl) AddWorker(ctx context.Context, actorID uui
 	logger := u.logger.With("method", "AddWorker", "actorID", actorID, "workerID", req.WorkerID, "shopID", req.CoffeeShopID)
 	logger.Debug("starting to add worker to shop")
 
-	if err := u.checkShopAdminAccess(ctx, actorID, req.CoffeeShopID); err != nil {
+	if err := u.accessControl.Can(ctx, actorID, req.CoffeeShopID, models.PermWorkerManage); err != nil {
+		return nil, err
+	}
+
+	roleName := req.Role
+	if roleName == "" {
+		roleName = models.RoleBarista
+	}
+	role, err := resolveAssignableRole(ctx, logger, u.roleRepo, roleName)
+	if err != nil {
 		return nil, err
 	}
 
@@ -67,6 +83,7 @@ func (u *WorkerCoffeeShopUsecaseImpl) AddWorker(ctx context.Context, actorID uui
 	relation := &models.WorkerCoffeeShop{
 		WorkerID:     &req.WorkerID,
 		CoffeeShopID: &req.CoffeeShopID,
+		RoleID:       &role.ID,
 	}
 
 	createdRelation, err := u.workerShopRepo.Create(ctx, relation)
@@ -88,11 +105,18 @@ func (u *WorkerCoffeeShopUsecaseImpl) RemoveWorker(ctx context.Context, actorID,
 		return err // Error already logged and classified by repository
 	}
 
-	if err := u.checkShopAdminAccess(ctx, actorID, *relation.CoffeeShopID); err != nil {
+	if err := u.accessControl.Can(ctx, actorID, *relation.CoffeeShopID, models.PermWorkerManage); err != nil {
 		return err
 	}
-
-	if err := u.workerShopRepo.Delete(ctx, workerShopRelationID); err != nil {
+	if relation.Role.Name == models.RoleOwner {
+		if err := checkOwnerCanStepDown(logger, actorID, relation); err != nil {
+			return err
+		}
+		err = u.workerShopRepo.DeleteOwner(ctx, relation)
+	} else {
+		err = u.workerShopRepo.Delete(ctx, workerShopRelationID)
+	}
+	if err != nil {
 		logger.Error("failed to delete worker-shop relation", "error", err)
 		return err
 	}
@@ -101,77 +125,119 @@ func (u *WorkerCoffeeShopUsecaseImpl) RemoveWorker(ctx context.Context, actorID,
 	return nil
 }
 
-func (u *WorkerCoffeeShopUsecaseImpl) ListWorkers(ctx context.Context, actorID, shopID uuid.UUID, page, limit int) ([]dto.UserResponse, error) {
-	logger := u.logger.With("method", "ListWorkers", "actorID", actorID, "shopID", shopID, "page", page, "limit", limit)
-	logger.Debug("starting to list workers in shop")
+func (u *WorkerCoffeeShopUsecaseImpl) ChangeWorkerRole(ctx context.Context, actorID, workerShopRelationID uuid.UUID, req *dto.ChangeWorkerRoleRequest) (*dto.WorkerCoffeeShopResponse, error) {
+	logger := u.logger.With("method", "ChangeWorkerRole", "actorID", actorID, "relationID", workerShopRelationID, "role", req.Role)
+	logger.Debug("starting to change worker role")
 
-	if err := u.checkShopAdminAccess(ctx, actorID, shopID); err != nil {
+	relation, err := u.workerShopRepo.GetByID(ctx, workerShopRelationID)
+	if err != nil {
 		return nil, err
 	}
 
-	if limit <= 0 || limit > 50 {
-		limit = 25
+	if err := u.accessControl.Can(ctx, actorID, *relation.CoffeeShopID, models.PermWorkerManage); err != nil {
+		return nil, err
 	}
-	if page < 0 {
-		page = 0
+	isOwner := relation.Role.Name == models.RoleOwner
+	if isOwner {
+		if err := checkOwnerCanStepDown(logger, actorID, relation); err != nil {
+			return nil, err
+		}
 	}
 
-	relations, err := u.workerShopRepo.ListByCoffeeShopID(ctx, shopID, limit, page*limit)
+	role, err := resolveAssignableRole(ctx, logger, u.roleRepo, req.Role)
 	if err != nil {
-		logger.Error("failed to list workers by coffee shop id", "error", err)
 		return nil, err
 	}
 
-	logger.Info("workers listed successfully", "count", len(relations))
-	return toUserResponsesFromRelations(relations), nil
+	relation.RoleID = &role.ID
+	relation.Role = *role
+	if isOwner {
+		err = u.workerShopRepo.UpdateOwner(ctx, relation)
+	} else {
+		err = u.workerShopRepo.Update(ctx, relation)
+	}
+	if err != nil {
+		logger.Error("failed to update worker role", "error", err)
+		return nil, err
+	}
+
+	logger.Info("worker role changed successfully")
+	return toWorkerCoffeeShopResponse(relation), nil
 }
 
-func (u *WorkerCoffeeShopUsecaseImpl) ListShopsForWorker(ctx context.Context, actorID, workerID uuid.UUID, page, limit int) ([]dto.CoffeeShopResponse, error) {
-	logger := u.logger.With("method", "ListShopsForWorker", "actorID", actorID, "workerID", workerID, "page", page, "limit", limit)
+func (u *WorkerCoffeeShopUsecaseImpl) ListWorkers(ctx context.Context, actorID, shopID uuid.UUID, page pagination.Request) (pagination.Page[dto.UserResponse], error) {
+	logger := u.logger.With("method", "ListWorkers", "actorID", actorID, "shopID", shopID, "limit", page.Limit)
+	logger.Debug("starting to list workers in shop")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermWorkerManage); err != nil {
+		return pagination.Page[dto.UserResponse]{}, err
+	}
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.UserResponse]{}, err
+	}
+
+	relations, err := u.workerShopRepo.ListByCoffeeShopID(ctx, shopID, q)
+	if err != nil {
+		logger.Error("failed to list workers by coffee shop id", "error", err)
+		return pagination.Page[dto.UserResponse]{}, err
+	}
+
+	logger.Info("workers listed successfully", "count", len(relations.Items))
+	return pagination.WithItems(relations, toUserResponsesFromRelations(relations.Items)), nil
+}
+
+func (u *WorkerCoffeeShopUsecaseImpl) ListShopsForWorker(ctx context.Context, actorID, workerID uuid.UUID, page pagination.Request) (pagination.Page[dto.CoffeeShopResponse], error) {
+	logger := u.logger.With("method", "ListShopsForWorker", "actorID", actorID, "workerID", workerID, "limit", page.Limit)
 	logger.Debug("starting to list shops for worker")
 
 	if actorID != workerID {
 		logger.Warn("access denied: user trying to access other user's data", "actorID", actorID, "targetWorkerID", workerID)
-		return nil, apperrors.NewErrAccessDenied("you can only view your own coffee shops")
+		return pagination.Page[dto.CoffeeShopResponse]{}, apperrors.NewErrAccessDenied("you can only view your own coffee shops")
 	}
 
-	if limit <= 0 || limit > 50 {
-		limit = 25
-	}
-	if page < 0 {
-		page = 0
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.CoffeeShopResponse]{}, err
 	}
 
-	relations, err := u.workerShopRepo.ListByWorkerID(ctx, workerID, limit, page*limit)
+	relations, err := u.workerShopRepo.ListByWorkerID(ctx, workerID, q)
 	if err != nil {
 		logger.Error("failed to list shops by worker id", "error", err)
-		return nil, err
+		return pagination.Page[dto.CoffeeShopResponse]{}, err
 	}
 
-	logger.Info("shops for worker listed successfully", "count", len(relations))
-	return toCoffeeShopResponsesFromRelations(relations), nil
+	logger.Info("shops for worker listed successfully", "count", len(relations.Items))
+	return pagination.WithItems(relations, toCoffeeShopResponsesFromRelations(relations.Items)), nil
 }
 
-// checkShopAdminAccess verifies if a user is either the creator of the shop,
-// or a worker in the shop with the 'admin' role.
-func (u *WorkerCoffeeShopUsecaseImpl) checkShopAdminAccess(ctx context.Context, actorID, shopID uuid.UUID) error {
-	logger := u.logger.With("method", "checkShopAdminAccess", "actorID", actorID, "shopID", shopID)
+// checkOwnerCanStepDown allows an owner to leave the coffee shop or give up ownership only by themselves.
+// DeleteOwner and UpdateOwner of the repository make sure that another owner remains, so that the
+// coffee shop is never left without an owner.
+func checkOwnerCanStepDown(logger *slog.Logger, actorID uuid.UUID, relation *models.WorkerCoffeeShop) error {
+	if relation.WorkerID == nil || *relation.WorkerID != actorID {
+		logger.Info("attempt to remove or demote another owner")
+		return apperrors.NewErrAccessDenied("only the owner themselves can step down")
+	}
+	return nil
+}
 
-	worker, err := u.workerShopRepo.GetByUserIDAndShopID(ctx, actorID, shopID)
-	if err != nil {
-		var errNotFound *apperrors.ErrNotFound
-		if errors.As(err, &errNotFound) {
-			return apperrors.NewErrAccessDenied("user is not worker for this coffee shop")
-		}
-		return err
+// resolveAssignableRole loads the role a worker can be given by another worker.
+// Ownership is set up with the coffee shop and cannot be handed out here.
+func resolveAssignableRole(ctx context.Context, logger *slog.Logger, roleRepo repository.RoleRepository, name string) (*models.Role, error) {
+	if !models.IsKnownRole(name) || name == models.RoleOwner {
+		logger.Info("invalid worker role", "role", name)
+		return nil, apperrors.NewErrNotValid(fmt.Sprintf("role %q cannot be assigned to a worker", name))
 	}
-	if worker.Role.Name == "admin" {
-		logger.Debug("access granted: user is a worker with admin role")
-		return nil
+	role, err := roleRepo.GetRoleByName(ctx, name)
+	if err != nil {
+		logger.Error("failed to get role", "role", name, "error", err)
+		return nil, err
 	}
-
-	logger.Warn("access denied: user is not shop creator or admin worker")
-	return apperrors.NewErrAccessDenied("user is not an admin for this coffee shop")
+	return role, nil
 }
 
 // --- DTO Mappers ---
@@ -186,7 +252,8 @@ func toWorkerCoffeeShopResponse(r *models.WorkerCoffeeShop) *dto.WorkerCoffeeSho
 		workerPhone = *r.Worker.Phone
 	}
 	return &dto.WorkerCoffeeShopResponse{
-		ID: r.ID,
+		ID:   r.ID,
+		Role: r.Role.Name,
 		Worker: dto.UserResponse{
 			ID:    r.Worker.ID,
 			Name:  workerName,
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+)
+
+type WorkerInvitationUsecase interface {
+	// CreateInvitation invites a worker to the coffee shop by phone, or as a link when no phone is given.
+	// Requires the worker.manage permission in the coffee shop.
+	CreateInvitation(ctx context.Context, actorID, shopID uuid.UUID, req *dto.CreateInvitationRequest) (*dto.InvitationResponse, error)
+
+	// ListInvitations retrieves a paginated list of invitations of the coffee shop in any status.
+	// Requires the worker.manage permission in the coffee shop.
+	ListInvitations(ctx context.Context, actorID, shopID uuid.UUID, page pagination.Request) (pagination.Page[dto.InvitationResponse], error)
+
+	// RevokeInvitation revokes a pending invitation of the coffee shop.
+	// Requires the worker.manage permission in the coffee shop.
+	RevokeInvitation(ctx context.Context, actorID, shopID, invitationID uuid.UUID) (*dto.InvitationResponse, error)
+
+	// ListMyInvitations retrieves pending invitations addressed to the phone of the user.
+	ListMyInvitations(ctx context.Context, userID uuid.UUID, page pagination.Request) (pagination.Page[dto.InvitationResponse], error)
+
+	// AcceptInvitation accepts an invitation addressed to the phone of the user and adds them to the coffee shop.
+	AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*dto.WorkerCoffeeShopResponse, error)
+
+	// AcceptInvitationByToken accepts a link invitation and adds the user to the coffee shop.
+	AcceptInvitationByToken(ctx context.Context, userID uuid.UUID, req *dto.AcceptInvitationByTokenRequest) (*dto.WorkerCoffeeShopResponse, error)
+
+	// DeclineInvitation declines an invitation addressed to the phone of the user.
+	DeclineInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*dto.InvitationResponse, error)
+}
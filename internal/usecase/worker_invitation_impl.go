@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
+	"github.com/google/uuid"
+)
+
+const invitationTokenBytes = 32
+
+type WorkerInvitationUsecaseImpl struct {
+	invitationRepo repository.WorkerInvitationRepository
+	workerShopRepo repository.WorkerCoffeeShopRepository
+	userRepo       repository.UserRep
+	roleRepo       repository.RoleRepository
+	accessControl  AccessControlUsecase
+	cfg            *config.InvitationConfig
+	logger         *slog.Logger
+}
+
+func NewWorkerInvitationUsecase(
+	invitationRepo repository.WorkerInvitationRepository,
+	workerShopRepo repository.WorkerCoffeeShopRepository,
+	userRepo repository.UserRep,
+	roleRepo repository.RoleRepository,
+	accessControl AccessControlUsecase,
+	cfg *config.InvitationConfig,
+	logger *slog.Logger,
+) WorkerInvitationUsecase {
+	return &WorkerInvitationUsecaseImpl{
+		invitationRepo: invitationRepo,
+		workerShopRepo: workerShopRepo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		accessControl:  accessControl,
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+func (u *WorkerInvitationUsecaseImpl) CreateInvitation(ctx context.Context, actorID, shopID uuid.UUID, req *dto.CreateInvitationRequest) (*dto.InvitationResponse, error) {
+	logger := u.logger.With("method", "CreateInvitation", "actorID", actorID, "shopID", shopID, "role", req.Role)
+	logger.Debug("starting to create invitation")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermWorkerManage); err != nil {
+		return nil, err
+	}
+
+	roleName := req.Role
+	if roleName == "" {
+		roleName = models.RoleBarista
+	}
+	role, err := resolveAssignableRole(ctx, logger, u.roleRepo, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := u.cfg.TTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if ttl > u.cfg.MaxTTL {
+		logger.Info("invitation lifetime is too long", "ttl", ttl)
+		return nil, apperrors.NewErrNotValid(fmt.Sprintf("invitation cannot be valid for more than %d hours", int(u.cfg.MaxTTL.Hours())))
+	}
+
+	invitation := &models.WorkerInvitation{
+		CoffeeShopID: shopID,
+		RoleID:       role.ID,
+		Status:       models.InvitationPending,
+		ExpiresAt:    time.Now().UTC().Add(ttl),
+		InvitedByID:  &actorID,
+	}
+
+	var token string
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		if !validatePhone(phone) {
+			logger.Info("invalid phone format")
+			return nil, apperrors.NewErrNotValid("invalid phone format")
+		}
+		// Stored like the phones of users, so that the invitee finds it.
+		phone = normalizePhone(phone)
+		invitation.Phone = &phone
+	} else {
+		token, err = generateInvitationToken()
+		if err != nil {
+			logger.Error("failed to generate invitation token", "error", err)
+			return nil, err
+		}
+		tokenHash := hashToken(token)
+		invitation.TokenHash = &tokenHash
+	}
+
+	created, err := u.invitationRepo.Create(ctx, invitation)
+	if err != nil {
+		logger.Error("failed to create invitation", "error", err)
+		return nil, err
+	}
+
+	logger.Info("invitation created successfully", "invitationID", created.ID)
+	resp := toInvitationResponse(created, time.Now())
+	resp.Token = token
+	return resp, nil
+}
+
+func (u *WorkerInvitationUsecaseImpl) ListInvitations(ctx context.Context, actorID, shopID uuid.UUID, page pagination.Request) (pagination.Page[dto.InvitationResponse], error) {
+	logger := u.logger.With("method", "ListInvitations", "actorID", actorID, "shopID", shopID, "limit", page.Limit)
+	logger.Debug("starting to list invitations")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermWorkerManage); err != nil {
+		return pagination.Page[dto.InvitationResponse]{}, err
+	}
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.InvitationResponse]{}, err
+	}
+
+	invitations, err := u.invitationRepo.ListByCoffeeShopID(ctx, shopID, q)
+	if err != nil {
+		logger.Error("failed to list invitations", "error", err)
+		return pagination.Page[dto.InvitationResponse]{}, err
+	}
+
+	logger.Info("invitations listed successfully", "count", len(invitations.Items))
+	return toInvitationResponses(invitations), nil
+}
+
+func (u *WorkerInvitationUsecaseImpl) RevokeInvitation(ctx context.Context, actorID, shopID, invitationID uuid.UUID) (*dto.InvitationResponse, error) {
+	logger := u.logger.With("method", "RevokeInvitation", "actorID", actorID, "shopID", shopID, "invitationID", invitationID)
+	logger.Debug("starting to revoke invitation")
+
+	if err := u.accessControl.Can(ctx, actorID, shopID, models.PermWorkerManage); err != nil {
+		return nil, err
+	}
+
+	invitation, err := u.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.CoffeeShopID != shopID {
+		logger.Info("invitation belongs to another coffee shop")
+		return nil, apperrors.NewErrNotFound("invitation", invitationID.String())
+	}
+
+	if _, err := u.invitationRepo.Revoke(ctx, invitationID, actorID, time.Now().UTC()); err != nil {
+		logger.Info("failed to revoke invitation", "error", err)
+		return nil, err
+	}
+
+	logger.Info("invitation revoked successfully")
+	return u.getInvitationResponse(ctx, invitationID)
+}
+
+func (u *WorkerInvitationUsecaseImpl) ListMyInvitations(ctx context.Context, userID uuid.UUID, page pagination.Request) (pagination.Page[dto.InvitationResponse], error) {
+	logger := u.logger.With("method", "ListMyInvitations", "userID", userID, "limit", page.Limit)
+	logger.Debug("starting to list invitations of the user")
+
+	q, err := pagination.NewQuery(page)
+	if err != nil {
+		logger.Info("invalid page request", "error", err)
+		return pagination.Page[dto.InvitationResponse]{}, err
+	}
+
+	user, err := u.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		logger.Error("failed to get user", "error", err)
+		return pagination.Page[dto.InvitationResponse]{}, err
+	}
+	if user.Phone == nil {
+		return pagination.Page[dto.InvitationResponse]{Items: []dto.InvitationResponse{}}, nil
+	}
+
+	invitations, err := u.invitationRepo.ListPendingByPhone(ctx, *user.Phone, time.Now().UTC(), q)
+	if err != nil {
+		logger.Error("failed to list invitations", "error", err)
+		return pagination.Page[dto.InvitationResponse]{}, err
+	}
+
+	logger.Info("invitations of the user listed successfully", "count", len(invitations.Items))
+	return toInvitationResponses(invitations), nil
+}
+
+func (u *WorkerInvitationUsecaseImpl) AcceptInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*dto.WorkerCoffeeShopResponse, error) {
+	logger := u.logger.With("method", "AcceptInvitation", "userID", userID, "invitationID", invitationID)
+	logger.Debug("starting to accept invitation")
+
+	if _, err := u.getAddressedInvitation(ctx, logger, userID, invitationID); err != nil {
+		return nil, err
+	}
+	return u.accept(ctx, logger, userID, invitationID)
+}
+
+func (u *WorkerInvitationUsecaseImpl) AcceptInvitationByToken(ctx context.Context, userID uuid.UUID, req *dto.AcceptInvitationByTokenRequest) (*dto.WorkerCoffeeShopResponse, error) {
+	logger := u.logger.With("method", "AcceptInvitationByToken", "userID", userID)
+	logger.Debug("starting to accept invitation by token")
+
+	invitation, err := u.invitationRepo.GetByTokenHash(ctx, hashToken(strings.TrimSpace(req.Token)))
+	if err != nil {
+		logger.Info("failed to find invitation by token", "error", err)
+		return nil, err
+	}
+	return u.accept(ctx, logger.With("invitationID", invitation.ID), userID, invitation.ID)
+}
+
+func (u *WorkerInvitationUsecaseImpl) DeclineInvitation(ctx context.Context, userID, invitationID uuid.UUID) (*dto.InvitationResponse, error) {
+	logger := u.logger.With("method", "DeclineInvitation", "userID", userID, "invitationID", invitationID)
+	logger.Debug("starting to decline invitation")
+
+	if _, err := u.getAddressedInvitation(ctx, logger, userID, invitationID); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.invitationRepo.Decline(ctx, invitationID, userID, time.Now().UTC()); err != nil {
+		logger.Info("failed to decline invitation", "error", err)
+		return nil, err
+	}
+
+	logger.Info("invitation declined successfully")
+	return u.getInvitationResponse(ctx, invitationID)
+}
+
+func (u *WorkerInvitationUsecaseImpl) accept(ctx context.Context, logger *slog.Logger, userID, invitationID uuid.UUID) (*dto.WorkerCoffeeShopResponse, error) {
+	relation, err := u.invitationRepo.Accept(ctx, invitationID, userID, time.Now().UTC())
+	if err != nil {
+		logger.Info("failed to accept invitation", "error", err)
+		return nil, err
+	}
+
+	relation, err = u.workerShopRepo.GetByID(ctx, relation.ID)
+	if err != nil {
+		logger.Error("failed to load worker relation", "error", err)
+		return nil, err
+	}
+
+	logger.Info("invitation accepted successfully", "relationID", relation.ID)
+	return toWorkerCoffeeShopResponse(relation), nil
+}
+
+// getAddressedInvitation returns the invitation if it is addressed to the phone of the user.
+// Invitations addressed to others are reported as not found so that their existence is not revealed.
+func (u *WorkerInvitationUsecaseImpl) getAddressedInvitation(ctx context.Context, logger *slog.Logger, userID, invitationID uuid.UUID) (*models.WorkerInvitation, error) {
+	invitation, err := u.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetUser(ctx, userID)
+	if err != nil {
+		logger.Error("failed to get user", "error", err)
+		return nil, err
+	}
+	if invitation.Phone == nil || user.Phone == nil || *invitation.Phone != *user.Phone {
+		logger.Info("invitation is not addressed to the user")
+		return nil, apperrors.NewErrNotFound("invitation", invitationID.String())
+	}
+	return invitation, nil
+}
+
+func (u *WorkerInvitationUsecaseImpl) getInvitationResponse(ctx context.Context, invitationID uuid.UUID) (*dto.InvitationResponse, error) {
+	invitation, err := u.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	return toInvitationResponse(invitation, time.Now()), nil
+}
+
+func generateInvitationToken() (string, error) {
+	b := make([]byte, invitationTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func toInvitationResponses(p pagination.Page[models.WorkerInvitation]) pagination.Page[dto.InvitationResponse] {
+	now := time.Now()
+	return pagination.Map(p, func(i models.WorkerInvitation) dto.InvitationResponse {
+		return *toInvitationResponse(&i, now)
+	})
+}
+
+func toInvitationResponse(i *models.WorkerInvitation, now time.Time) *dto.InvitationResponse {
+	return &dto.InvitationResponse{
+		ID:             i.ID,
+		CoffeeShopID:   i.CoffeeShopID,
+		CoffeeShopName: i.CoffeeShop.Name,
+		Role:           i.Role.Name,
+		Phone:          i.Phone,
+		Status:         i.StatusAt(now),
+		ExpiresAt:      i.ExpiresAt,
+		InvitedByID:    i.InvitedByID,
+		RespondedByID:  i.RespondedByID,
+		RespondedAt:    i.RespondedAt,
+		RevokedByID:    i.RevokedByID,
+		RevokedAt:      i.RevokedAt,
+		CreatedAt:      i.CreatedAt,
+	}
+}
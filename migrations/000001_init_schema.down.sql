@@ -0,0 +1,23 @@
+DROP TABLE IF EXISTS rotated_refresh_token;
+DROP TABLE IF EXISTS user_refresh_tokens;
+DROP TABLE IF EXISTS user_session;
+DROP TABLE IF EXISTS otps;
+DROP TABLE IF EXISTS reward;
+DROP TABLE IF EXISTS reward_type;
+DROP TABLE IF EXISTS idea_status_history;
+DROP TABLE IF EXISTS idea_comment;
+DROP TABLE IF EXISTS idea_like;
+DROP TABLE IF EXISTS idea;
+DROP TABLE IF EXISTS status_transition;
+DROP TABLE IF EXISTS status;
+DROP TABLE IF EXISTS category;
+DROP TABLE IF EXISTS ownership_transfer;
+DROP TABLE IF EXISTS worker_invitation;
+DROP TABLE IF EXISTS worker_coffee_shop;
+DROP TABLE IF EXISTS banned_user;
+DROP TABLE IF EXISTS coffee_shop_opening_hours;
+DROP TABLE IF EXISTS coffee_shop;
+DROP TABLE IF EXISTS organization_member;
+DROP TABLE IF EXISTS organization;
+DROP TABLE IF EXISTS role;
+DROP TABLE IF EXISTS users;
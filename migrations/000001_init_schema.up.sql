@@ -0,0 +1,339 @@
+-- Baseline schema, as created by GORM AutoMigrate before versioned migrations were introduced.
+-- Constraint and index names follow GORM's naming and every statement is guarded, so that
+-- databases created by AutoMigrate can be brought under version control with "migrate up".
+-- CREATE TABLE IF NOT EXISTS skips the tables such databases already have, so the columns
+-- added since are added separately and the constraints relaxed since are dropped.
+
+CREATE TABLE IF NOT EXISTS users (
+    id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name          varchar(100),
+    login         varchar(50) CONSTRAINT uni_users_login UNIQUE,
+    password_hash text,
+    phone         varchar(15) CONSTRAINT uni_users_phone UNIQUE,
+    is_deleted    boolean DEFAULT false,
+    updated_at    timestamptz,
+    created_at    timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS role (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       varchar(50) NOT NULL CONSTRAINT uni_role_name UNIQUE,
+    is_deleted boolean DEFAULT false,
+    updated_at timestamptz,
+    created_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS organization (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    creator_id  uuid CONSTRAINT fk_organization_creator REFERENCES users (id) ON DELETE SET NULL,
+    name        varchar(100) NOT NULL,
+    description text,
+    updated_at  timestamptz,
+    created_at  timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS organization_member (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id uuid NOT NULL CONSTRAINT fk_organization_member_organization REFERENCES organization (id) ON DELETE CASCADE,
+    user_id         uuid NOT NULL CONSTRAINT fk_organization_member_user REFERENCES users (id) ON DELETE CASCADE,
+    role_id         uuid NOT NULL CONSTRAINT fk_organization_member_role REFERENCES role (id),
+    created_at      timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_member ON organization_member (organization_id, user_id);
+
+CREATE TABLE IF NOT EXISTS coffee_shop (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    creator_id      uuid CONSTRAINT fk_coffee_shop_creator REFERENCES users (id) ON DELETE SET NULL,
+    organization_id uuid CONSTRAINT fk_coffee_shop_organization REFERENCES organization (id) ON DELETE SET NULL,
+    name            varchar(100) NOT NULL,
+    address         varchar(255) NOT NULL,
+    country         varchar(100),
+    city            varchar(100),
+    street          varchar(255),
+    postal_code     varchar(20),
+    latitude        decimal,
+    longitude       decimal,
+    contacts        varchar(100),
+    welcome_message text,
+    rules           text,
+    updated_at      timestamptz,
+    created_at      timestamptz
+);
+ALTER TABLE coffee_shop
+    ADD COLUMN IF NOT EXISTS organization_id uuid CONSTRAINT fk_coffee_shop_organization REFERENCES organization (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS country varchar(100),
+    ADD COLUMN IF NOT EXISTS city varchar(100),
+    ADD COLUMN IF NOT EXISTS street varchar(255),
+    ADD COLUMN IF NOT EXISTS postal_code varchar(20),
+    ADD COLUMN IF NOT EXISTS latitude decimal,
+    ADD COLUMN IF NOT EXISTS longitude decimal;
+-- Shops outlive the account of their creator.
+ALTER TABLE coffee_shop ALTER COLUMN creator_id DROP NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_coffee_shop_organization_id ON coffee_shop (organization_id);
+CREATE INDEX IF NOT EXISTS idx_coffee_shop_location ON coffee_shop (latitude, longitude);
+
+CREATE TABLE IF NOT EXISTS coffee_shop_opening_hours (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    coffee_shop_id uuid NOT NULL CONSTRAINT fk_coffee_shop_opening_hours REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    weekday        bigint NOT NULL,
+    opens          varchar(5) NOT NULL,
+    closes         varchar(5) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_coffee_shop_opening_hours_coffee_shop_id ON coffee_shop_opening_hours (coffee_shop_id);
+
+CREATE TABLE IF NOT EXISTS banned_user (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        uuid CONSTRAINT fk_banned_user_user REFERENCES users (id) ON DELETE CASCADE,
+    coffee_shop_id uuid CONSTRAINT fk_banned_user_coffee_shop REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    banned_by_id   uuid CONSTRAINT fk_banned_user_banned_by REFERENCES users (id) ON DELETE SET NULL,
+    reason         varchar(500),
+    expires_at     timestamptz,
+    hide_ideas     boolean DEFAULT false,
+    created_at     timestamptz
+);
+ALTER TABLE banned_user
+    ADD COLUMN IF NOT EXISTS banned_by_id uuid CONSTRAINT fk_banned_user_banned_by REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS reason varchar(500),
+    ADD COLUMN IF NOT EXISTS expires_at timestamptz,
+    ADD COLUMN IF NOT EXISTS hide_ideas boolean DEFAULT false;
+CREATE INDEX IF NOT EXISTS idx_banned_user_shop ON banned_user (user_id, coffee_shop_id);
+
+CREATE TABLE IF NOT EXISTS worker_coffee_shop (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    worker_id      uuid CONSTRAINT fk_worker_coffee_shop_worker REFERENCES users (id) ON DELETE CASCADE,
+    coffee_shop_id uuid CONSTRAINT fk_worker_coffee_shop_coffee_shop REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    role_id        uuid CONSTRAINT fk_worker_coffee_shop_role REFERENCES role (id) ON DELETE CASCADE,
+    is_deleted     boolean DEFAULT false,
+    created_at     timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS worker_invitation (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    coffee_shop_id  uuid NOT NULL CONSTRAINT fk_worker_invitation_coffee_shop REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    role_id         uuid NOT NULL CONSTRAINT fk_worker_invitation_role REFERENCES role (id) ON DELETE CASCADE,
+    phone           varchar(15),
+    token_hash      text,
+    status          varchar(20) NOT NULL DEFAULT 'pending',
+    expires_at      timestamptz NOT NULL,
+    invited_by_id   uuid CONSTRAINT fk_worker_invitation_invited_by REFERENCES users (id) ON DELETE SET NULL,
+    responded_by_id uuid CONSTRAINT fk_worker_invitation_responded_by REFERENCES users (id) ON DELETE SET NULL,
+    responded_at    timestamptz,
+    revoked_by_id   uuid CONSTRAINT fk_worker_invitation_revoked_by REFERENCES users (id) ON DELETE SET NULL,
+    revoked_at      timestamptz,
+    updated_at      timestamptz,
+    created_at      timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_worker_invitation_coffee_shop_id ON worker_invitation (coffee_shop_id);
+CREATE INDEX IF NOT EXISTS idx_worker_invitation_phone ON worker_invitation (phone);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_worker_invitation_token_hash ON worker_invitation (token_hash);
+
+CREATE TABLE IF NOT EXISTS ownership_transfer (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    coffee_shop_id uuid NOT NULL CONSTRAINT fk_ownership_transfer_coffee_shop REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    from_user_id   uuid CONSTRAINT fk_ownership_transfer_from_user REFERENCES users (id) ON DELETE SET NULL,
+    to_user_id     uuid NOT NULL CONSTRAINT fk_ownership_transfer_to_user REFERENCES users (id) ON DELETE CASCADE,
+    keep_ownership boolean DEFAULT false,
+    status         varchar(20) NOT NULL DEFAULT 'pending',
+    expires_at     timestamptz NOT NULL,
+    responded_at   timestamptz,
+    updated_at     timestamptz,
+    created_at     timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_ownership_transfer_coffee_shop_id ON ownership_transfer (coffee_shop_id);
+CREATE INDEX IF NOT EXISTS idx_ownership_transfer_to_user_id ON ownership_transfer (to_user_id);
+
+CREATE TABLE IF NOT EXISTS category (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    coffee_shop_id  uuid CONSTRAINT fk_category_coffee_shop REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    organization_id uuid CONSTRAINT fk_category_organization REFERENCES organization (id) ON DELETE CASCADE,
+    title           varchar(50) NOT NULL,
+    description     text,
+    is_deleted      boolean DEFAULT false,
+    updated_at      timestamptz,
+    created_at      timestamptz
+);
+ALTER TABLE category
+    ADD COLUMN IF NOT EXISTS organization_id uuid CONSTRAINT fk_category_organization REFERENCES organization (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_category_organization_id ON category (organization_id);
+
+CREATE TABLE IF NOT EXISTS status (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    coffee_shop_id uuid CONSTRAINT fk_status_coffee_shop REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    title          varchar(50) NOT NULL,
+    is_initial     boolean DEFAULT false,
+    is_terminal    boolean DEFAULT false,
+    is_deleted     boolean DEFAULT false,
+    created_at     timestamptz
+);
+ALTER TABLE status
+    ADD COLUMN IF NOT EXISTS coffee_shop_id uuid CONSTRAINT fk_status_coffee_shop REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS is_initial boolean DEFAULT false,
+    ADD COLUMN IF NOT EXISTS is_terminal boolean DEFAULT false;
+CREATE INDEX IF NOT EXISTS idx_status_coffee_shop_id ON status (coffee_shop_id);
+-- Status titles were globally unique before statuses became per coffee shop.
+ALTER TABLE status DROP CONSTRAINT IF EXISTS uni_status_title;
+
+CREATE TABLE IF NOT EXISTS status_transition (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    coffee_shop_id uuid CONSTRAINT fk_status_transition_coffee_shop REFERENCES coffee_shop (id) ON DELETE CASCADE,
+    from_status_id uuid CONSTRAINT fk_status_transition_from_status REFERENCES status (id) ON DELETE CASCADE,
+    to_status_id   uuid CONSTRAINT fk_status_transition_to_status REFERENCES status (id) ON DELETE CASCADE,
+    created_at     timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_status_transition_coffee_shop_id ON status_transition (coffee_shop_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_status_transition ON status_transition (from_status_id, to_status_id);
+
+CREATE TABLE IF NOT EXISTS idea (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    creator_id     uuid CONSTRAINT fk_idea_creator REFERENCES users (id) ON DELETE SET NULL,
+    coffee_shop_id uuid CONSTRAINT fk_idea_coffee_shop REFERENCES coffee_shop (id) ON DELETE SET NULL,
+    category_id    uuid CONSTRAINT fk_idea_category REFERENCES category (id) ON DELETE SET NULL,
+    status_id      uuid CONSTRAINT fk_idea_status REFERENCES status (id) ON DELETE SET NULL,
+    title          varchar(150) NOT NULL,
+    description    text NOT NULL,
+    image_url      varchar(255),
+    is_deleted     boolean DEFAULT false,
+    updated_at     timestamptz,
+    created_at     timestamptz
+);
+-- Must stay in sync with repository.IdeaSearchVector, otherwise searches do not use the index.
+CREATE INDEX IF NOT EXISTS idx_idea_search ON idea USING GIN (
+    (to_tsvector('russian', coalesce(idea.title, '') || ' ' || coalesce(idea.description, '')) ||
+     to_tsvector('english', coalesce(idea.title, '') || ' ' || coalesce(idea.description, '')))
+);
+
+CREATE TABLE IF NOT EXISTS idea_like (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    uuid CONSTRAINT fk_idea_like_user REFERENCES users (id) ON DELETE CASCADE,
+    idea_id    uuid CONSTRAINT fk_idea_like_idea REFERENCES idea (id) ON DELETE CASCADE,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_idea ON idea_like (user_id, idea_id);
+
+CREATE TABLE IF NOT EXISTS idea_comment (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    creator_id  uuid CONSTRAINT fk_idea_comment_creator REFERENCES users (id) ON DELETE CASCADE,
+    idea_id     uuid CONSTRAINT fk_idea_comment_idea REFERENCES idea (id) ON DELETE CASCADE,
+    text        text NOT NULL,
+    author_name varchar(100) NOT NULL,
+    is_deleted  boolean DEFAULT false,
+    updated_at  timestamptz,
+    created_at  timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS idea_status_history (
+    id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    idea_id        uuid CONSTRAINT fk_idea_status_history_idea REFERENCES idea (id) ON DELETE CASCADE,
+    from_status_id uuid CONSTRAINT fk_idea_status_history_from_status REFERENCES status (id) ON DELETE SET NULL,
+    to_status_id   uuid CONSTRAINT fk_idea_status_history_to_status REFERENCES status (id) ON DELETE SET NULL,
+    actor_id       uuid CONSTRAINT fk_idea_status_history_actor REFERENCES users (id) ON DELETE SET NULL,
+    note           varchar(500),
+    created_at     timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_idea_status_history_idea_id ON idea_status_history (idea_id);
+CREATE INDEX IF NOT EXISTS idx_idea_status_history_created_at ON idea_status_history (created_at);
+
+CREATE TABLE IF NOT EXISTS reward_type (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    coffee_shop_id  uuid CONSTRAINT fk_reward_type_coffee_shop REFERENCES coffee_shop (id),
+    organization_id uuid CONSTRAINT fk_reward_type_organization REFERENCES organization (id) ON DELETE CASCADE,
+    title           text NOT NULL DEFAULT '',
+    description     text NOT NULL,
+    validity_days   bigint,
+    total_stock     bigint,
+    monthly_budget  bigint,
+    created_at      timestamptz
+);
+ALTER TABLE reward_type
+    ADD COLUMN IF NOT EXISTS organization_id uuid CONSTRAINT fk_reward_type_organization REFERENCES organization (id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS title text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS validity_days bigint,
+    ADD COLUMN IF NOT EXISTS total_stock bigint,
+    ADD COLUMN IF NOT EXISTS monthly_budget bigint;
+CREATE INDEX IF NOT EXISTS idx_reward_type_organization_id ON reward_type (organization_id);
+
+CREATE TABLE IF NOT EXISTS reward (
+    id                         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    receiver_id                uuid CONSTRAINT fk_reward_receiver REFERENCES users (id),
+    coffee_shop_id             uuid CONSTRAINT fk_reward_coffee_shop REFERENCES coffee_shop (id),
+    idea_id                    uuid CONSTRAINT fk_reward_idea REFERENCES idea (id),
+    reward_type_id             uuid CONSTRAINT fk_reward_reward_type REFERENCES reward_type (id),
+    is_activated               boolean DEFAULT false,
+    given_at                   timestamptz,
+    expires_at                 timestamptz,
+    is_expired                 boolean DEFAULT false,
+    created_at                 timestamptz,
+    redemption_code            text,
+    redemption_code_expires_at timestamptz,
+    activated_at               timestamptz,
+    activated_by_id            uuid CONSTRAINT fk_reward_activated_by REFERENCES users (id)
+);
+ALTER TABLE reward
+    ADD COLUMN IF NOT EXISTS expires_at timestamptz,
+    ADD COLUMN IF NOT EXISTS is_expired boolean DEFAULT false,
+    ADD COLUMN IF NOT EXISTS redemption_code text,
+    ADD COLUMN IF NOT EXISTS redemption_code_expires_at timestamptz,
+    ADD COLUMN IF NOT EXISTS activated_at timestamptz,
+    ADD COLUMN IF NOT EXISTS activated_by_id uuid CONSTRAINT fk_reward_activated_by REFERENCES users (id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reward_redemption_code ON reward (redemption_code);
+
+CREATE TABLE IF NOT EXISTS otps (
+    id              bigserial PRIMARY KEY,
+    phone           varchar(20) NOT NULL,
+    code_hash       varchar(255) NOT NULL,
+    expires_at      timestamptz NOT NULL,
+    verified        boolean DEFAULT false,
+    attempts_left   bigint DEFAULT 3,
+    created_at      timestamptz,
+    resend_count    bigint DEFAULT 0,
+    next_allowed_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS user_session (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      uuid NOT NULL CONSTRAINT fk_user_session_user REFERENCES users (id) ON DELETE CASCADE,
+    device_name  varchar(100),
+    ip           varchar(45),
+    user_agent   varchar(512),
+    created_at   timestamptz,
+    last_used_at timestamptz NOT NULL,
+    expires_at   timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_user_session_user_id ON user_session (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_session_expires_at ON user_session (expires_at);
+
+CREATE TABLE IF NOT EXISTS user_refresh_tokens (
+    refresh_token text PRIMARY KEY,
+    user_id       uuid NOT NULL CONSTRAINT fk_user_refresh_tokens_user REFERENCES users (id) ON DELETE CASCADE,
+    session_id    uuid CONSTRAINT fk_user_refresh_tokens_session REFERENCES user_session (id) ON DELETE CASCADE,
+    expires_at    timestamptz NOT NULL
+);
+ALTER TABLE user_refresh_tokens
+    ADD COLUMN IF NOT EXISTS session_id uuid CONSTRAINT fk_user_refresh_tokens_session REFERENCES user_session (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_user_refresh_tokens_user_id ON user_refresh_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_refresh_tokens_session_id ON user_refresh_tokens (session_id);
+
+CREATE TABLE IF NOT EXISTS rotated_refresh_token (
+    refresh_token text PRIMARY KEY,
+    session_id    uuid NOT NULL CONSTRAINT fk_rotated_refresh_token_session REFERENCES user_session (id) ON DELETE CASCADE,
+    rotated_at    timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_rotated_refresh_token_session_id ON rotated_refresh_token (session_id);
+
+-- Shops were deleted together with the account of their creator. AutoMigrate never altered
+-- existing foreign keys, so older databases still carry the CASCADE action.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.referential_constraints
+        WHERE constraint_name = 'fk_coffee_shop_creator' AND delete_rule = 'CASCADE'
+    ) THEN
+        ALTER TABLE coffee_shop DROP CONSTRAINT fk_coffee_shop_creator;
+        ALTER TABLE coffee_shop ADD CONSTRAINT fk_coffee_shop_creator
+            FOREIGN KEY (creator_id) REFERENCES users (id) ON DELETE SET NULL;
+    END IF;
+END $$;
+
+INSERT INTO role (name, created_at, updated_at)
+VALUES ('owner', now(), now()), ('admin', now(), now()), ('moderator', now(), now()), ('barista', now(), now())
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,25 @@
+ALTER TABLE otps DROP CONSTRAINT IF EXISTS chk_otps_counters;
+ALTER TABLE reward_type DROP CONSTRAINT IF EXISTS chk_reward_type_limits;
+ALTER TABLE ownership_transfer DROP CONSTRAINT IF EXISTS chk_ownership_transfer_status;
+ALTER TABLE worker_invitation DROP CONSTRAINT IF EXISTS chk_worker_invitation_status;
+ALTER TABLE coffee_shop DROP CONSTRAINT IF EXISTS chk_coffee_shop_location;
+ALTER TABLE coffee_shop_opening_hours DROP CONSTRAINT IF EXISTS chk_coffee_shop_opening_hours_weekday;
+
+DROP INDEX IF EXISTS idx_otps_phone;
+DROP INDEX IF EXISTS idx_reward_pending_expiry;
+DROP INDEX IF EXISTS idx_reward_reward_type_id;
+DROP INDEX IF EXISTS idx_reward_idea_id;
+DROP INDEX IF EXISTS idx_reward_coffee_shop_id;
+DROP INDEX IF EXISTS idx_reward_receiver_id;
+DROP INDEX IF EXISTS idx_reward_type_coffee_shop_id;
+DROP INDEX IF EXISTS idx_banned_user_coffee_shop_id;
+DROP INDEX IF EXISTS idx_organization_member_user_id;
+DROP INDEX IF EXISTS idx_worker_coffee_shop_coffee_shop_id;
+DROP INDEX IF EXISTS idx_worker_coffee_shop_worker_id;
+DROP INDEX IF EXISTS idx_category_coffee_shop_id;
+DROP INDEX IF EXISTS idx_idea_comment_idea_id;
+DROP INDEX IF EXISTS idx_idea_like_idea_id;
+DROP INDEX IF EXISTS idx_idea_status_id;
+DROP INDEX IF EXISTS idx_idea_category_id;
+DROP INDEX IF EXISTS idx_idea_creator_id;
+DROP INDEX IF EXISTS idx_idea_coffee_shop_created_at;
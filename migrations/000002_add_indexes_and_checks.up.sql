@@ -0,0 +1,42 @@
+-- Indexes for the foreign keys and filters used by the repositories, and checks for the
+-- invariants that were only enforced by the usecases.
+
+CREATE INDEX IF NOT EXISTS idx_idea_coffee_shop_created_at ON idea (coffee_shop_id, created_at DESC) WHERE NOT is_deleted;
+CREATE INDEX IF NOT EXISTS idx_idea_creator_id ON idea (creator_id);
+CREATE INDEX IF NOT EXISTS idx_idea_category_id ON idea (category_id);
+CREATE INDEX IF NOT EXISTS idx_idea_status_id ON idea (status_id);
+CREATE INDEX IF NOT EXISTS idx_idea_like_idea_id ON idea_like (idea_id);
+CREATE INDEX IF NOT EXISTS idx_idea_comment_idea_id ON idea_comment (idea_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_category_coffee_shop_id ON category (coffee_shop_id);
+CREATE INDEX IF NOT EXISTS idx_worker_coffee_shop_worker_id ON worker_coffee_shop (worker_id);
+CREATE INDEX IF NOT EXISTS idx_worker_coffee_shop_coffee_shop_id ON worker_coffee_shop (coffee_shop_id);
+CREATE INDEX IF NOT EXISTS idx_organization_member_user_id ON organization_member (user_id);
+CREATE INDEX IF NOT EXISTS idx_banned_user_coffee_shop_id ON banned_user (coffee_shop_id);
+CREATE INDEX IF NOT EXISTS idx_reward_type_coffee_shop_id ON reward_type (coffee_shop_id);
+CREATE INDEX IF NOT EXISTS idx_reward_receiver_id ON reward (receiver_id);
+CREATE INDEX IF NOT EXISTS idx_reward_coffee_shop_id ON reward (coffee_shop_id);
+CREATE INDEX IF NOT EXISTS idx_reward_idea_id ON reward (idea_id);
+CREATE INDEX IF NOT EXISTS idx_reward_reward_type_id ON reward (reward_type_id);
+-- Rewards the expiry sweeper still has to look at.
+CREATE INDEX IF NOT EXISTS idx_reward_pending_expiry ON reward (expires_at) WHERE NOT is_activated AND NOT is_expired;
+CREATE INDEX IF NOT EXISTS idx_otps_phone ON otps (phone);
+
+ALTER TABLE coffee_shop_opening_hours
+    ADD CONSTRAINT chk_coffee_shop_opening_hours_weekday CHECK (weekday BETWEEN 0 AND 6);
+ALTER TABLE coffee_shop
+    ADD CONSTRAINT chk_coffee_shop_location CHECK (
+        (latitude IS NULL AND longitude IS NULL) OR
+        (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+    );
+ALTER TABLE worker_invitation
+    ADD CONSTRAINT chk_worker_invitation_status CHECK (status IN ('pending', 'accepted', 'declined', 'revoked'));
+ALTER TABLE ownership_transfer
+    ADD CONSTRAINT chk_ownership_transfer_status CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled'));
+ALTER TABLE reward_type
+    ADD CONSTRAINT chk_reward_type_limits CHECK (
+        (validity_days IS NULL OR validity_days > 0) AND
+        (total_stock IS NULL OR total_stock > 0) AND
+        (monthly_budget IS NULL OR monthly_budget > 0)
+    );
+ALTER TABLE otps
+    ADD CONSTRAINT chk_otps_counters CHECK (attempts_left >= 0 AND resend_count >= 0);
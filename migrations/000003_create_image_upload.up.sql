@@ -0,0 +1,17 @@
+-- Images uploaded by clients directly to the storage. A row lives from the moment the
+-- upload URL is issued until the upload expires; image_url is set once it is finalized.
+
+CREATE TABLE IF NOT EXISTS image_upload (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      uuid NOT NULL CONSTRAINT fk_image_upload_user REFERENCES users (id) ON DELETE CASCADE,
+    object_key   text NOT NULL,
+    content_type varchar(100) NOT NULL,
+    size         bigint NOT NULL CONSTRAINT chk_image_upload_size CHECK (size > 0),
+    image_url    text,
+    finalized_at timestamptz,
+    expires_at   timestamptz NOT NULL,
+    created_at   timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_image_upload_object_key ON image_upload (object_key);
+CREATE INDEX IF NOT EXISTS idx_image_upload_user_id ON image_upload (user_id);
+CREATE INDEX IF NOT EXISTS idx_image_upload_expires_at ON image_upload (expires_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_image_upload_image_url;
+DROP INDEX IF EXISTS idx_idea_image_url;
+DROP TABLE IF EXISTS stored_image;
@@ -0,0 +1,16 @@
+-- Images stored by the API, so that the ones no idea refers to any more can be found and
+-- deleted. Images already referenced by ideas or uploads are adopted with the current time.
+
+CREATE TABLE IF NOT EXISTS stored_image (
+    image_url  text PRIMARY KEY,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_stored_image_created_at ON stored_image (created_at);
+CREATE INDEX IF NOT EXISTS idx_idea_image_url ON idea (image_url);
+CREATE INDEX IF NOT EXISTS idx_image_upload_image_url ON image_upload (image_url);
+
+INSERT INTO stored_image (image_url)
+SELECT image_url FROM idea WHERE image_url IS NOT NULL
+UNION
+SELECT image_url FROM image_upload WHERE image_url IS NOT NULL
+ON CONFLICT (image_url) DO NOTHING;
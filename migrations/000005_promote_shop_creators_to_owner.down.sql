@@ -0,0 +1,2 @@
+-- The promoted creators are indistinguishable from owners appointed later, so they keep the
+-- owner role.
@@ -0,0 +1,15 @@
+-- Shops created before the owner role existed have their creator on the admin role, which can
+-- neither delete the shop nor transfer it, and no one can hand out the owner role. The creator
+-- of every shop without an owner becomes its owner.
+
+UPDATE worker_coffee_shop
+SET role_id = (SELECT id FROM role WHERE name = 'owner')
+FROM coffee_shop
+WHERE worker_coffee_shop.coffee_shop_id = coffee_shop.id
+  AND worker_coffee_shop.worker_id = coffee_shop.creator_id
+  AND NOT worker_coffee_shop.is_deleted
+  AND NOT EXISTS (
+      SELECT 1 FROM worker_coffee_shop shop_owner
+      JOIN role ON role.id = shop_owner.role_id
+      WHERE shop_owner.coffee_shop_id = coffee_shop.id AND role.name = 'owner' AND NOT shop_owner.is_deleted
+  );
@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL migrations of the database schema, so that
+// the binaries do not depend on the working directory to find them.
+//
+// Every change to the schema is a new pair of NNNNNN_name.up.sql and NNNNNN_name.down.sql
+// files next to this one, applied with cmd/migrate.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
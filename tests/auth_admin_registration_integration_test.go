@@ -56,14 +56,14 @@ func (suite *AdminRegistrationTestSuite) TestRegisterAdminAndCoffeeShop() {
 	err = suite.DB.First(&coffeeShop, "name = ?", testCoffeeShopName).Error
 	suite.NoError(err)
 	suite.NotEmpty(coffeeShop.ID)
-	suite.Equal(user.ID, coffeeShop.CreatorID)
+	suite.Equal(&user.ID, coffeeShop.CreatorID)
 	suite.Equal(testAddress, coffeeShop.Address)
 
 	// Verify worker_coffee_shop entry
 	var workerCoffeeShop models.WorkerCoffeeShop
 	err = suite.DB.Preload("Role").First(&workerCoffeeShop, "worker_id = ? AND coffee_shop_id = ?", user.ID, coffeeShop.ID).Error
 	suite.NoError(err)
-	suite.Equal("admin", workerCoffeeShop.Role.Name)
+	suite.Equal(models.RoleOwner, workerCoffeeShop.Role.Name)
 }
 
 func (suite *AdminRegistrationTestSuite) TestRegisterAdminAndCoffeeShop_LoginConflict() {
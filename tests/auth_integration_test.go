@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,7 +11,9 @@ import (
 
 	"github.com/GeorgiiMalishev/ideas-platform/config"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/jwtkeys"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -780,3 +783,157 @@ func (suite *AuthIntegrationTestSuite) TestLogoutEverywhere() {
 		})
 	}
 }
+
+func (suite *AuthIntegrationTestSuite) TestJWKSAndKeyRotation() {
+	user := suite.CreateUser("Rotation User", "89005550011")
+
+	suite.Run("JWKS publishes the signing and previous keys", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/.well-known/jwks.json"})
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var jwks dto.JWKSResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &jwks))
+		suite.Require().Len(jwks.Keys, 2)
+		suite.Equal(suite.JWTKeys.SigningKeyID(), jwks.Keys[0].Kid)
+		for _, key := range jwks.Keys {
+			suite.Equal("OKP", key.Kty)
+			suite.Equal("EdDSA", key.Alg)
+			suite.NotEmpty(key.X)
+		}
+	})
+
+	suite.Run("Issued tokens carry the signing key id", func() {
+		token := suite.RegisterUserAndGetToken(user)
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &dto.JWTClaims{})
+		suite.Require().NoError(err)
+		suite.Equal(suite.JWTKeys.SigningKeyID(), parsed.Header["kid"])
+		suite.Equal("EdDSA", parsed.Method.Alg())
+	})
+
+	signWith := func(key ed25519.PrivateKey) string {
+		keys, err := jwtkeys.New(key)
+		suite.Require().NoError(err)
+		token, err := keys.Sign(dto.JWTClaims{
+			UserID:           user.ID,
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+		})
+		suite.Require().NoError(err)
+		return token
+	}
+
+	suite.Run("Tokens signed with the previous key are accepted", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me", token: signWith(suite.PreviousJWTKey)})
+		suite.Equal(http.StatusOK, w.Code, w.Body.String())
+	})
+
+	suite.Run("Fail - Token signed with an unknown key", func() {
+		_, unknown, err := ed25519.GenerateKey(nil)
+		suite.Require().NoError(err)
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me", token: signWith(unknown)})
+		suite.Equal(http.StatusUnauthorized, w.Code)
+	})
+}
+
+func (suite *AuthIntegrationTestSuite) refresh(refreshToken string) (int, dto.AuthResponse) {
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/auth/refresh", contentType: "application/json",
+		body: dto.RefreshRequest{RefreshToken: refreshToken},
+	})
+	var resp dto.AuthResponse
+	if w.Code == http.StatusOK {
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func (suite *AuthIntegrationTestSuite) listSessions(token string) []dto.SessionResponse {
+	w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me/sessions", token: token})
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var sessions []dto.SessionResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &sessions))
+	return sessions
+}
+
+func (suite *AuthIntegrationTestSuite) TestRefreshTokenReuseRevokesSession() {
+	first := suite.GetAuthResponse("89005550022", "123456", "Reuse User")
+	other := suite.GetAuthResponse("89005550022", "123456", "Reuse User")
+
+	code, second := suite.refresh(first.RefreshToken)
+	suite.Require().Equal(http.StatusOK, code)
+	code, third := suite.refresh(second.RefreshToken)
+	suite.Require().Equal(http.StatusOK, code)
+
+	suite.Run("Replaying a rotated token revokes the family", func() {
+		code, _ := suite.refresh(first.RefreshToken)
+		suite.Equal(http.StatusUnauthorized, code)
+
+		code, _ = suite.refresh(third.RefreshToken)
+		suite.Equal(http.StatusUnauthorized, code, "the live token of the family is revoked too")
+
+		var count int64
+		suite.DB.Model(&models.RotatedRefreshToken{}).Count(&count)
+		suite.Zero(count)
+	})
+
+	suite.Run("Other sessions are not affected", func() {
+		code, _ := suite.refresh(other.RefreshToken)
+		suite.Equal(http.StatusOK, code)
+		suite.Len(suite.listSessions(other.AccessToken), 1)
+	})
+}
+
+func (suite *AuthIntegrationTestSuite) TestSessions() {
+	phone := "89005550033"
+	suite.GetAuthResponse(phone, "123456", "Session User")
+	otp := &models.OTP{Phone: phone, ExpiresAt: time.Now().Add(5 * time.Minute), AttemptsLeft: 3}
+	hashedCode, _ := bcrypt.GenerateFromPassword([]byte("654321"), bcrypt.DefaultCost)
+	otp.CodeHash = string(hashedCode)
+	suite.Require().NoError(suite.DB.Create(otp).Error)
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/auth", contentType: "application/json",
+		body:    dto.VerifyOTPRequest{Phone: phone, OTP: "654321", DeviceName: "Pixel 8"},
+		headers: map[string]string{"User-Agent": "ideas-android/1.4"},
+	})
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	var phoneAuth dto.AuthResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &phoneAuth))
+
+	sessions := suite.listSessions(phoneAuth.AccessToken)
+	suite.Require().Len(sessions, 2)
+	var current dto.SessionResponse
+	for _, session := range sessions {
+		if session.Current {
+			current = session
+		}
+	}
+	suite.Equal("Pixel 8", current.DeviceName)
+	suite.Equal("ideas-android/1.4", current.UserAgent)
+
+	suite.Run("Refresh keeps the session and updates its last use", func() {
+		code, refreshed := suite.refresh(phoneAuth.RefreshToken)
+		suite.Require().Equal(http.StatusOK, code)
+		phoneAuth = refreshed
+
+		after := suite.listSessions(phoneAuth.AccessToken)
+		suite.Require().Len(after, 2)
+		suite.Equal(current.ID, after[0].ID, "the refreshed session is the most recently used")
+		suite.True(after[0].Current)
+		suite.True(after[0].LastUsedAt.After(current.LastUsedAt))
+		suite.Equal("Pixel 8", after[0].DeviceName)
+	})
+
+	suite.Run("Fail - Revoke a session of another user", func() {
+		otherToken := suite.GetRandomAuthToken()
+		w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/users/me/sessions/%s", current.ID), token: otherToken})
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+
+	suite.Run("Revoked session can no longer refresh", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/users/me/sessions/%s", current.ID), token: phoneAuth.AccessToken})
+		suite.Require().Equal(http.StatusNoContent, w.Code, w.Body.String())
+
+		code, _ := suite.refresh(phoneAuth.RefreshToken)
+		suite.Equal(http.StatusUnauthorized, code)
+		suite.Len(suite.listSessions(phoneAuth.AccessToken), 1)
+	})
+}
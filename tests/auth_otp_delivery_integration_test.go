@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthOTPDeliveryTestSuite struct {
+	BaseTestSuite
+}
+
+func TestAuthOTPDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthOTPDeliveryTestSuite))
+}
+
+func (suite *AuthOTPDeliveryTestSuite) TearDownTest() {
+	suite.OTPSender.FailWith(nil)
+	suite.BaseTestSuite.TearDownTest()
+}
+
+func (suite *AuthOTPDeliveryTestSuite) TestDeliveredCodeCanBeVerified() {
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/api/v1/auth/%s", "89005553311"),
+	})
+	suite.Require().Equal(http.StatusNoContent, w.Code)
+
+	code, ok := suite.OTPSender.LastCode("+79005553311")
+	suite.Require().True(ok, "code should be delivered in international format")
+	suite.Len(code, 6)
+
+	w = suite.MakeRequest(TestRequest{
+		method:      http.MethodPost,
+		path:        "/api/v1/auth",
+		body:        dto.VerifyOTPRequest{Phone: "89005553311", OTP: code, Name: "Delivery User"},
+		contentType: "application/json",
+	})
+	suite.Equal(http.StatusOK, w.Code, w.Body.String())
+}
+
+func (suite *AuthOTPDeliveryTestSuite) TestDeliveryFailureIsReported() {
+	suite.OTPSender.FailWith(errors.New("gateway unavailable"))
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/api/v1/auth/%s", "89005553322"),
+	})
+	suite.Equal(http.StatusBadGateway, w.Code, w.Body.String())
+}
+
+func (suite *AuthOTPDeliveryTestSuite) TestRetryAfterDeliveryFailure() {
+	requestCode := func() int {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/auth/89005553333"})
+		return w.Code
+	}
+
+	suite.Run("First code", func() {
+		suite.OTPSender.FailWith(errors.New("gateway unavailable"))
+		suite.Require().Equal(http.StatusBadGateway, requestCode())
+		var count int64
+		suite.Require().NoError(suite.DB.Model(&models.OTP{}).Where("phone = ?", "9005553333").Count(&count).Error)
+		suite.Zero(count, "an undelivered code is not saved")
+
+		suite.OTPSender.FailWith(nil)
+		suite.Equal(http.StatusNoContent, requestCode(), "an immediate retry is not rate limited")
+	})
+
+	suite.Run("Resent code", func() {
+		suite.Require().NoError(suite.DB.Model(&models.OTP{}).Where("phone = ?", "9005553333").
+			Update("next_allowed_at", time.Now().Add(-time.Second)).Error)
+		var before models.OTP
+		suite.Require().NoError(suite.DB.First(&before, "phone = ?", "9005553333").Error)
+
+		suite.OTPSender.FailWith(errors.New("gateway unavailable"))
+		suite.Require().Equal(http.StatusBadGateway, requestCode())
+		var after models.OTP
+		suite.Require().NoError(suite.DB.First(&after, "phone = ?", "9005553333").Error)
+		suite.Equal(before.CodeHash, after.CodeHash, "the previous code stays valid")
+		suite.Equal(before.ResendCount, after.ResendCount)
+		suite.WithinDuration(before.NextAllowedAt, after.NextAllowedAt, time.Millisecond)
+
+		suite.OTPSender.FailWith(nil)
+		suite.Require().Equal(http.StatusNoContent, requestCode(), "an immediate retry is not rate limited")
+		code, ok := suite.OTPSender.LastCode("+79005553333")
+		suite.Require().True(ok)
+		w := suite.MakeRequest(TestRequest{
+			method:      http.MethodPost,
+			path:        "/api/v1/auth",
+			body:        dto.VerifyOTPRequest{Phone: "89005553333", OTP: code, Name: "Retry User"},
+			contentType: "application/json",
+		})
+		suite.Equal(http.StatusOK, w.Code, w.Body.String())
+	})
+}
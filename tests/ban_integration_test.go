@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/stretchr/testify/suite"
+)
+
+type BanIntegrationTestSuite struct {
+	BaseTestSuite
+}
+
+func (suite *BanIntegrationTestSuite) SetupSuite() {
+	suite.BaseTestSuite.SetupSuite()
+}
+
+func (suite *BanIntegrationTestSuite) TearDownTest() {
+	suite.BaseTestSuite.TearDownTest()
+}
+
+func TestBanIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(BanIntegrationTestSuite))
+}
+
+// createBanPrerequisites creates a shop with an admin, a regular worker with an idea in that shop, and returns their tokens.
+func (suite *BanIntegrationTestSuite) createBanPrerequisites() (string, string, *models.User, *models.CoffeeShop, *models.Category, *models.Idea) {
+	admin, coffeeShop := suite.CreateTestUser("ban-admin", "111111111", "Ban Test Shop", "1 Ban St", suite.AdminRoleID)
+	adminToken := suite.RegisterUserAndGetToken(admin)
+
+	worker := suite.CreateUser("ban-worker", "222222222")
+	suite.CreateWorkerForShop(worker, coffeeShop, suite.UserRoleID)
+	workerToken := suite.RegisterUserAndGetToken(worker)
+
+	category := &models.Category{
+		Title:        "Ban Category",
+		CoffeeShopID: &coffeeShop.ID,
+	}
+	err := suite.DB.Create(category).Error
+	suite.Require().NoError(err)
+
+	var ideaStatus models.IdeaStatus
+	suite.DB.FirstOrCreate(&ideaStatus, "title = ?", "new")
+	idea := &models.Idea{
+		Title:        "Idea before ban",
+		Description:  "Written before the ban.",
+		CreatorID:    &worker.ID,
+		CoffeeShopID: &coffeeShop.ID,
+		CategoryID:   &category.ID,
+		StatusID:     &ideaStatus.ID,
+	}
+	err = suite.DB.Create(idea).Error
+	suite.Require().NoError(err)
+
+	return adminToken, workerToken, worker, coffeeShop, category, idea
+}
+
+func (suite *BanIntegrationTestSuite) banUser(token string, shopID string, body dto.BanUserRequest) int {
+	req := TestRequest{
+		method:      http.MethodPost,
+		path:        fmt.Sprintf("/api/v1/coffee-shops/%s/bans", shopID),
+		body:        body,
+		contentType: "application/json",
+		token:       token,
+	}
+	return suite.MakeRequest(req).Code
+}
+
+func (suite *BanIntegrationTestSuite) TestBanUser() {
+	adminToken, workerToken, worker, coffeeShop, _, _ := suite.createBanPrerequisites()
+	reason := "spam"
+
+	suite.Run("Fail - Worker cannot ban", func() {
+		code := suite.banUser(workerToken, coffeeShop.ID.String(), dto.BanUserRequest{UserID: worker.ID})
+		suite.Equal(http.StatusForbidden, code)
+	})
+
+	suite.Run("Fail - Expiry in the past", func() {
+		expiresAt := time.Now().Add(-time.Hour)
+		code := suite.banUser(adminToken, coffeeShop.ID.String(), dto.BanUserRequest{UserID: worker.ID, ExpiresAt: &expiresAt})
+		suite.Equal(http.StatusBadRequest, code)
+	})
+
+	suite.Run("Success - Admin bans worker", func() {
+		req := TestRequest{
+			method:      http.MethodPost,
+			path:        fmt.Sprintf("/api/v1/coffee-shops/%s/bans", coffeeShop.ID),
+			body:        dto.BanUserRequest{UserID: worker.ID, Reason: &reason},
+			contentType: "application/json",
+			token:       adminToken,
+		}
+		w := suite.MakeRequest(req)
+		suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+
+		var resp dto.BannedUserResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal(worker.ID, *resp.UserID)
+		suite.Equal(reason, *resp.Reason)
+	})
+
+	suite.Run("Fail - Already banned", func() {
+		code := suite.banUser(adminToken, coffeeShop.ID.String(), dto.BanUserRequest{UserID: worker.ID})
+		suite.Equal(http.StatusConflict, code)
+	})
+
+	suite.Run("Success - List bans", func() {
+		req := TestRequest{
+			method: http.MethodGet,
+			path:   fmt.Sprintf("/api/v1/coffee-shops/%s/bans", coffeeShop.ID),
+			token:  adminToken,
+		}
+		w := suite.MakeRequest(req)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var resp pagination.Page[dto.BannedUserResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Require().Len(resp.Items, 1)
+		suite.Equal("ban-worker", resp.Items[0].UserName)
+	})
+
+	suite.Run("Success - Unban", func() {
+		req := TestRequest{
+			method: http.MethodDelete,
+			path:   fmt.Sprintf("/api/v1/coffee-shops/%s/bans/%s", coffeeShop.ID, worker.ID),
+			token:  adminToken,
+		}
+		w := suite.MakeRequest(req)
+		suite.Equal(http.StatusNoContent, w.Code)
+
+		w = suite.MakeRequest(req)
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+func (suite *BanIntegrationTestSuite) TestBannedUserIsRestricted() {
+	adminToken, workerToken, worker, coffeeShop, category, idea := suite.createBanPrerequisites()
+	suite.Require().Equal(http.StatusCreated, suite.banUser(adminToken, coffeeShop.ID.String(), dto.BanUserRequest{UserID: worker.ID}))
+
+	suite.Run("Fail - Create idea", func() {
+		req := TestRequest{
+			method: http.MethodPost,
+			path:   "/api/v1/ideas",
+			formData: map[string]string{
+				"title":          "Idea after ban",
+				"description":    "Should not be created.",
+				"category_id":    category.ID.String(),
+				"coffee_shop_id": coffeeShop.ID.String(),
+			},
+			contentType: "multipart/form-data",
+			token:       workerToken,
+		}
+		w := suite.MakeRequest(req)
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+
+	suite.Run("Fail - Comment", func() {
+		req := TestRequest{
+			method:      http.MethodPost,
+			path:        fmt.Sprintf("/api/v1/ideas/%s/comments", idea.ID),
+			body:        dto.CreateCommentRequest{Text: "Still here", AuthorName: "Banned"},
+			contentType: "application/json",
+			token:       workerToken,
+		}
+		w := suite.MakeRequest(req)
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+
+	suite.Run("Fail - Like", func() {
+		req := TestRequest{
+			method: http.MethodPost,
+			path:   fmt.Sprintf("/api/v1/ideas/%s/like", idea.ID),
+			token:  workerToken,
+		}
+		w := suite.MakeRequest(req)
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+
+	suite.Run("Success - Ideas stay visible without hide_ideas", func() {
+		req := TestRequest{
+			method: http.MethodGet,
+			path:   fmt.Sprintf("/api/v1/coffee-shops/%s/ideas", coffeeShop.ID),
+		}
+		w := suite.MakeRequest(req)
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var resp pagination.Page[dto.IdeaResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Len(resp.Items, 1)
+	})
+}
+
+func (suite *BanIntegrationTestSuite) TestBanHidesIdeas() {
+	adminToken, _, worker, coffeeShop, _, _ := suite.createBanPrerequisites()
+	suite.Require().Equal(http.StatusCreated, suite.banUser(adminToken, coffeeShop.ID.String(), dto.BanUserRequest{UserID: worker.ID, HideIdeas: true}))
+
+	req := TestRequest{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/ideas", coffeeShop.ID),
+	}
+	w := suite.MakeRequest(req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var resp pagination.Page[dto.IdeaResponse]
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Empty(resp.Items)
+}
+
+func (suite *BanIntegrationTestSuite) TestExpiredBanIsIgnored() {
+	_, workerToken, worker, coffeeShop, _, idea := suite.createBanPrerequisites()
+
+	expiredAt := time.Now().Add(-time.Minute)
+	err := suite.DB.Create(&models.BannedUser{
+		UserID:       &worker.ID,
+		CoffeeShopID: &coffeeShop.ID,
+		ExpiresAt:    &expiredAt,
+	}).Error
+	suite.Require().NoError(err)
+
+	req := TestRequest{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/api/v1/ideas/%s/like", idea.ID),
+		token:  workerToken,
+	}
+	w := suite.MakeRequest(req)
+	suite.Equal(http.StatusCreated, w.Code)
+}
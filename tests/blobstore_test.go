@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalBlobStoreTestSuite struct {
+	suite.Suite
+	root  string
+	store *blobstore.Local
+	ctx   context.Context
+}
+
+func TestLocalBlobStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(LocalBlobStoreTestSuite))
+}
+
+func (suite *LocalBlobStoreTestSuite) SetupTest() {
+	suite.root = filepath.Join(suite.T().TempDir(), "uploads")
+	suite.store = blobstore.NewLocal(suite.root, "http://localhost:8080/uploads", []byte("test-signing-secret"))
+	suite.ctx = context.Background()
+	suite.Require().NoError(suite.store.Init(suite.ctx))
+}
+
+func (suite *LocalBlobStoreTestSuite) put(key string, data []byte) {
+	suite.Require().NoError(suite.store.Put(suite.ctx, key, bytes.NewReader(data), int64(len(data)), "image/jpeg"))
+}
+
+func (suite *LocalBlobStoreTestSuite) TestPutGetStatDelete() {
+	data := []byte("0123456789")
+	suite.put("a1/original.jpg", data)
+	suite.NoError(suite.store.Ping(suite.ctx))
+
+	suite.Run("Whole object", func() {
+		body, info, err := suite.store.Get(suite.ctx, "a1/original.jpg", nil)
+		suite.Require().NoError(err)
+		defer body.Close()
+		got, err := io.ReadAll(body)
+		suite.Require().NoError(err)
+		suite.Equal(data, got)
+		suite.Equal(int64(10), info.Size)
+		suite.Equal("image/jpeg", info.ContentType)
+		suite.NotEmpty(info.ETag)
+	})
+
+	suite.Run("Range", func() {
+		body, info, err := suite.store.Get(suite.ctx, "a1/original.jpg", &blobstore.Range{Offset: 2, Length: 3})
+		suite.Require().NoError(err)
+		defer body.Close()
+		got, err := io.ReadAll(body)
+		suite.Require().NoError(err)
+		suite.Equal([]byte("234"), got)
+		suite.Equal(int64(10), info.Size, "info describes the whole object")
+	})
+
+	suite.Run("Stat", func() {
+		info, err := suite.store.Stat(suite.ctx, "a1/original.jpg")
+		suite.Require().NoError(err)
+		suite.Equal("a1/original.jpg", info.Key)
+		suite.Equal(int64(10), info.Size)
+	})
+
+	suite.Run("Delete removes empty directories", func() {
+		suite.Require().NoError(suite.store.Delete(suite.ctx, "a1/original.jpg"))
+		_, err := suite.store.Stat(suite.ctx, "a1/original.jpg")
+		suite.ErrorIs(err, blobstore.ErrNotFound)
+		_, err = os.Stat(filepath.Join(suite.root, "a1"))
+		suite.True(os.IsNotExist(err))
+		suite.NoError(suite.store.Delete(suite.ctx, "a1/original.jpg"), "deleting a missing object is not an error")
+	})
+}
+
+func (suite *LocalBlobStoreTestSuite) TestRejectsInvalidKeys() {
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../secret", "a//b", "a\\b", "a/.upload-123"} {
+		suite.Run(key, func() {
+			err := suite.store.Put(suite.ctx, key, bytes.NewReader(nil), 0, "image/jpeg")
+			suite.ErrorIs(err, blobstore.ErrInvalidKey)
+			_, _, err = suite.store.Get(suite.ctx, key, nil)
+			suite.ErrorIs(err, blobstore.ErrInvalidKey)
+		})
+	}
+}
+
+func (suite *LocalBlobStoreTestSuite) TestSizeMismatchLeavesNoObject() {
+	err := suite.store.Put(suite.ctx, "a1/original.jpg", bytes.NewReader([]byte("abc")), 5, "image/jpeg")
+	suite.Error(err)
+	_, err = suite.store.Stat(suite.ctx, "a1/original.jpg")
+	suite.ErrorIs(err, blobstore.ErrNotFound)
+
+	entries, err := os.ReadDir(filepath.Join(suite.root, "a1"))
+	suite.Require().NoError(err)
+	suite.Empty(entries, "the temporary file is removed")
+}
+
+func (suite *LocalBlobStoreTestSuite) TestPresignPut() {
+	req, err := suite.store.PresignPut(suite.ctx, "a1/original.jpg", "image/jpeg", 100, time.Minute)
+	suite.Require().NoError(err)
+	suite.Equal("PUT", req.Method)
+	suite.Equal("image/jpeg", req.Headers["Content-Type"])
+	suite.Equal("100", req.Headers["Content-Length"])
+
+	u, err := url.Parse(req.URL)
+	suite.Require().NoError(err)
+	suite.Equal("/uploads/a1/original.jpg", u.Path)
+	query := u.Query()
+	now := time.Now()
+
+	suite.NoError(suite.store.VerifyPut("a1/original.jpg", "image/jpeg", 100, query, now))
+	suite.Error(suite.store.VerifyPut("a1/other.jpg", "image/jpeg", 100, query, now), "another key")
+	suite.Error(suite.store.VerifyPut("a1/original.jpg", "image/png", 100, query, now), "another content type")
+	suite.Error(suite.store.VerifyPut("a1/original.jpg", "image/jpeg", 101, query, now), "another size")
+	suite.Error(suite.store.VerifyPut("a1/original.jpg", "image/jpeg", 100, query, now.Add(2*time.Minute)), "expired")
+
+	tampered := url.Values{"expires": {"99999999999"}, "signature": query["signature"]}
+	suite.Error(suite.store.VerifyPut("a1/original.jpg", "image/jpeg", 100, tampered, now), "extended expiry")
+
+	unsigned := blobstore.NewLocal(suite.root, "", nil)
+	_, err = unsigned.PresignPut(suite.ctx, "a1/original.jpg", "image/jpeg", 100, time.Minute)
+	suite.ErrorIs(err, blobstore.ErrPresignNotSupported)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
@@ -44,7 +45,7 @@ func (suite *CategoryIntegrationTestSuite) TestCategoryCRUD() {
 		ID:      uuid.New(),
 		Name:    "Test Coffee Shop",
 		Address: "123 Test St",
-		CreatorID: admin.ID,
+		CreatorID: &admin.ID,
 	}
 	err = suite.DB.Create(&coffeeShop).Error
 	suite.Require().NoError(err)
@@ -103,21 +104,19 @@ func (suite *CategoryIntegrationTestSuite) TestCategoryCRUD() {
 	})
 
 	t.Run("Get Categories By Coffee Shop", func(t *testing.T) {
-		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/coffee-shops/%s/categories", coffeeShop.ID), nil)
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/api/v1/coffee-shops/%s/categories?include_total=true", coffeeShop.ID), nil)
 
 		rr := httptest.NewRecorder()
 		suite.Router.ServeHTTP(rr, req)
 
 		suite.Require().Equal(http.StatusOK, rr.Code)
 
-		var response struct {
-			Data  []dto.CategoryResponse `json:"data"`
-			Total int                    `json:"total"`
-		}
+		var response pagination.Page[dto.CategoryResponse]
 		err := json.Unmarshal(rr.Body.Bytes(), &response)
 		suite.Require().NoError(err)
-		suite.Require().Equal(1, response.Total)
-		suite.Require().Equal("New Category", response.Data[0].Title)
+		suite.Require().NotNil(response.Total)
+		suite.Require().Equal(int64(1), *response.Total)
+		suite.Require().Equal("New Category", response.Items[0].Title)
 	})
 
 	t.Run("Update Category", func(t *testing.T) {
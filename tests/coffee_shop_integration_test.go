@@ -8,6 +8,7 @@ import (
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
@@ -78,7 +79,7 @@ func (suite *CoffeeShopIntegrationTestSuite) TestGetAllCoffeeShops() {
 	user := suite.CreateUser("test-user", "1234567890")
 
 	// Create a coffee shop to be listed
-	newShop := &models.CoffeeShop{ID: uuid.New(), Name: "Shop 1-" + uuid.New().String(), Address: "Addr 1", CreatorID: user.ID}
+	newShop := &models.CoffeeShop{ID: uuid.New(), Name: "Shop 1-" + uuid.New().String(), Address: "Addr 1", CreatorID: &user.ID}
 	err := suite.DB.Create(newShop).Error
 	suite.Require().NoError(err)
 
@@ -107,7 +108,7 @@ func (suite *CoffeeShopIntegrationTestSuite) TestGetAllCoffeeShops() {
 func (suite *CoffeeShopIntegrationTestSuite) TestGetCoffeeShop() {
 	user := suite.CreateUser("test-user", "1234567890")
 	shopID := uuid.New()
-	suite.DB.Create(&models.CoffeeShop{ID: shopID, Name: "Shop 1", Address: "Addr 1", CreatorID: user.ID})
+	suite.DB.Create(&models.CoffeeShop{ID: shopID, Name: "Shop 1", Address: "Addr 1", CreatorID: &user.ID})
 
 	req := TestRequest{
 		method: http.MethodGet,
@@ -274,3 +275,138 @@ func (suite *CoffeeShopIntegrationTestSuite) TestDeleteCoffeeShop_Unauthorized()
 	suite.DB.Model(&models.CoffeeShop{}).Where("id = ?", coffeeShop.ID).Count(&count)
 	suite.Equal(int64(1), count)
 }
+
+func (suite *CoffeeShopIntegrationTestSuite) createLocatedShop(token, name string, lat, lon float64) dto.CoffeeShopResponse {
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/coffee-shops", token: token, contentType: "application/json",
+		body: dto.CreateCoffeeShopRequest{Name: name, Address: name + " address", Location: &dto.Location{Latitude: lat, Longitude: lon}},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var resp dto.CoffeeShopResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func (suite *CoffeeShopIntegrationTestSuite) listShops(query string) (int, pagination.Page[dto.CoffeeShopResponse]) {
+	w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/coffee-shops?" + query})
+	var resp pagination.Page[dto.CoffeeShopResponse]
+	if w.Code == http.StatusOK {
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func (suite *CoffeeShopIntegrationTestSuite) TestLocationAddressAndOpeningHours() {
+	token := suite.GetRandomAuthToken()
+	city := "Moscow"
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/coffee-shops", token: token, contentType: "application/json",
+		body: dto.CreateCoffeeShopRequest{
+			Name: "Hours Shop", Address: "1 Tverskaya St, Moscow", City: &city,
+			Location: &dto.Location{Latitude: 55.7575, Longitude: 37.6136},
+			OpeningHours: []dto.OpeningHours{
+				{Weekday: 1, Opens: "14:00", Closes: "20:00"},
+				{Weekday: 1, Opens: "08:00", Closes: "12:00"},
+				{Weekday: 5, Opens: "18:00", Closes: "02:00"},
+			},
+		},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created dto.CoffeeShopResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s", created.ID)})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var shop dto.CoffeeShopResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &shop))
+	suite.Equal(&city, shop.City)
+	suite.Equal(&dto.Location{Latitude: 55.7575, Longitude: 37.6136}, shop.Location)
+	suite.Equal([]dto.OpeningHours{
+		{Weekday: 1, Opens: "08:00", Closes: "12:00"},
+		{Weekday: 1, Opens: "14:00", Closes: "20:00"},
+		{Weekday: 5, Opens: "18:00", Closes: "02:00"},
+	}, shop.OpeningHours)
+
+	suite.Run("Update replaces the opening hours", func() {
+		w := suite.MakeRequest(TestRequest{
+			method: http.MethodPut, path: fmt.Sprintf("/api/v1/coffee-shops/%s", created.ID), token: token, contentType: "application/json",
+			body: dto.UpdateCoffeeShopRequest{OpeningHours: []dto.OpeningHours{{Weekday: 0, Opens: "10:00", Closes: "16:00"}}},
+		})
+		suite.Require().Equal(http.StatusNoContent, w.Code, w.Body.String())
+
+		var hours []models.OpeningHours
+		suite.Require().NoError(suite.DB.Find(&hours, "coffee_shop_id = ?", created.ID).Error)
+		suite.Require().Len(hours, 1)
+		suite.Equal(0, hours[0].Weekday)
+	})
+
+	suite.Run("Fail - Invalid location and hours", func() {
+		for _, body := range []dto.CreateCoffeeShopRequest{
+			{Name: "Bad", Address: "Bad", Location: &dto.Location{Latitude: 91, Longitude: 0}},
+			{Name: "Bad", Address: "Bad", OpeningHours: []dto.OpeningHours{{Weekday: 7, Opens: "08:00", Closes: "12:00"}}},
+			{Name: "Bad", Address: "Bad", OpeningHours: []dto.OpeningHours{{Weekday: 1, Opens: "8am", Closes: "12:00"}}},
+		} {
+			w := suite.MakeRequest(TestRequest{method: http.MethodPost, path: "/api/v1/coffee-shops", token: token, contentType: "application/json", body: body})
+			suite.Equal(http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+func (suite *CoffeeShopIntegrationTestSuite) TestNearbyAndNameSearch() {
+	token := suite.GetRandomAuthToken()
+	// Around the Kremlin: about 0.3 km, 1.5 km and 12 km away, plus a shop in Saint Petersburg.
+	near := suite.createLocatedShop(token, "Red Square Coffee", 55.7525, 37.6231)
+	middle := suite.createLocatedShop(token, "Arbat Coffee", 55.7494, 37.5931)
+	far := suite.createLocatedShop(token, "Khimki Roasters", 55.8570, 37.5300)
+	suite.createLocatedShop(token, "Nevsky Coffee", 59.9343, 30.3351)
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/coffee-shops", token: token, contentType: "application/json",
+		body: dto.CreateCoffeeShopRequest{Name: "Unlocated Coffee", Address: "Somewhere"},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code)
+
+	suite.Run("Nearest first within the default radius", func() {
+		code, page := suite.listShops("near=55.7520,37.6175")
+		suite.Require().Equal(http.StatusOK, code)
+		suite.Require().Len(page.Items, 2)
+		suite.Equal(near.ID, page.Items[0].ID)
+		suite.Equal(middle.ID, page.Items[1].ID)
+		suite.Require().NotNil(page.Items[0].DistanceMeters)
+		suite.InDelta(360, *page.Items[0].DistanceMeters, 50)
+		suite.Less(*page.Items[0].DistanceMeters, *page.Items[1].DistanceMeters)
+	})
+
+	suite.Run("Larger radius and paging", func() {
+		code, page := suite.listShops("near=55.7520,37.6175&radius=20000&limit=2")
+		suite.Require().Equal(http.StatusOK, code)
+		suite.Require().Len(page.Items, 2)
+		suite.Require().NotEmpty(page.NextCursor)
+
+		code, next := suite.listShops("near=55.7520,37.6175&radius=20000&limit=2&cursor=" + page.NextCursor)
+		suite.Require().Equal(http.StatusOK, code)
+		suite.Require().Len(next.Items, 1)
+		suite.Equal(far.ID, next.Items[0].ID)
+	})
+
+	suite.Run("Name search combined with distance", func() {
+		code, page := suite.listShops("q=coffee")
+		suite.Require().Equal(http.StatusOK, code)
+		suite.Len(page.Items, 4)
+
+		code, page = suite.listShops("q=ARBAT&near=55.7520,37.6175")
+		suite.Require().Equal(http.StatusOK, code)
+		suite.Require().Len(page.Items, 1)
+		suite.Equal(middle.ID, page.Items[0].ID)
+
+		code, page = suite.listShops("q=100%25")
+		suite.Require().Equal(http.StatusOK, code)
+		suite.Empty(page.Items, "wildcards in the query are matched literally")
+	})
+
+	suite.Run("Fail - Invalid nearby parameters", func() {
+		for _, query := range []string{"near=55.75", "near=abc,37.6", "near=95,37.6", "near=55.75,37.6&radius=1000000", "radius=1000"} {
+			code, _ := suite.listShops(query)
+			suite.Equal(http.StatusBadRequest, code, query)
+		}
+	})
+}
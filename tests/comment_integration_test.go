@@ -8,6 +8,7 @@ import (
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -37,7 +38,7 @@ func (suite *CommentIntegrationTestSuite) createCommentPrerequisites() (string,
 	coffeeShop := &models.CoffeeShop{
 		Name:      "Comment Test Shop",
 		Address:   "456 Comment St",
-		CreatorID: worker.ID,
+		CreatorID: &worker.ID,
 	}
 	err := suite.DB.Create(coffeeShop).Error
 	suite.Require().NoError(err)
@@ -170,38 +171,56 @@ func (suite *CommentIntegrationTestSuite) TestGetComments() {
 	outsider := suite.CreateUser("outsider2", "444444444")
 	outsiderToken := suite.RegisterUserAndGetToken(outsider)
 
+	var nextCursor string
+
 	suite.Run("Worker can get comments with pagination", func() {
-		// Page 1, Limit 10 (should get newest 10: Comment 15 to Comment 6)
+		// Limit 10 (should get newest 10: Comment 15 to Comment 6)
 		req := TestRequest{
 			method: http.MethodGet,
-			path:   fmt.Sprintf("/api/v1/ideas/%s/comments?page=1&limit=10", idea.ID),
+			path:   fmt.Sprintf("/api/v1/ideas/%s/comments?limit=10&include_total=true", idea.ID),
 			token:  token,
 		}
 		w := suite.MakeRequest(req)
 		suite.Equal(http.StatusOK, w.Code)
 
-		var resp []dto.CommentResponse
+		var resp pagination.Page[dto.CommentResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.Len(resp, 10)
-		suite.Equal("Comment 15", resp[0].Text) // Check ordering (newest first)
+		suite.Len(resp.Items, 10)
+		suite.Equal("Comment 15", resp.Items[0].Text) // Check ordering (newest first)
+		suite.Require().NotNil(resp.Total)
+		suite.Equal(int64(15), *resp.Total)
+		suite.NotEmpty(resp.NextCursor)
+		nextCursor = resp.NextCursor
 	})
 
 	suite.Run("Worker can get second page", func() {
-		// Page 2, Limit 10 (should get remaining 5: Comment 5 to Comment 1)
+		// Next page, Limit 10 (should get remaining 5: Comment 5 to Comment 1)
 		req := TestRequest{
 			method: http.MethodGet,
-			path:   fmt.Sprintf("/api/v1/ideas/%s/comments?page=2&limit=10", idea.ID),
+			path:   fmt.Sprintf("/api/v1/ideas/%s/comments?limit=10&cursor=%s", idea.ID, nextCursor),
 			token:  token,
 		}
 		w := suite.MakeRequest(req)
 		suite.Equal(http.StatusOK, w.Code)
 
-		var resp []dto.CommentResponse
+		var resp pagination.Page[dto.CommentResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.Len(resp, 5)
-		suite.Equal("Comment 5", resp[0].Text)
+		suite.Len(resp.Items, 5)
+		suite.Equal("Comment 5", resp.Items[0].Text)
+		suite.Empty(resp.NextCursor)
+		suite.Nil(resp.Total)
+	})
+
+	suite.Run("Invalid cursor", func() {
+		req := TestRequest{
+			method: http.MethodGet,
+			path:   fmt.Sprintf("/api/v1/ideas/%s/comments?cursor=not-a-cursor", idea.ID),
+			token:  token,
+		}
+		w := suite.MakeRequest(req)
+		suite.Equal(http.StatusBadRequest, w.Code)
 	})
 
 	suite.Run("Outsider cannot get comments", func() {
@@ -260,11 +279,11 @@ func (suite *CommentIntegrationTestSuite) TestDeleteComment() {
 			token:  token,
 		}
 		checkW := suite.MakeRequest(checkReq)
-		var listResp []dto.CommentResponse
+		var listResp pagination.Page[dto.CommentResponse]
 		json.Unmarshal(checkW.Body.Bytes(), &listResp)
 		
 		found := false
-		for _, c := range listResp {
+		for _, c := range listResp.Items {
 			if c.ID == commentResp.ID {
 				found = true
 				break
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+}
+
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
+
+func (suite *ConfigTestSuite) validConfig() *config.Config {
+	return &config.Config{
+		Server: config.ServerConfig{
+			Host: "0.0.0.0", Port: 8080,
+			ReadHeaderTimeout: 5 * time.Second, ReadTimeout: 30 * time.Second, WriteTimeout: time.Minute,
+			IdleTimeout: 2 * time.Minute, ShutdownTimeout: 20 * time.Second, DrainDelay: 5 * time.Second,
+		},
+		DB:      config.DBConfig{Host: "db", Port: 5432, User: "app", Password: "k2p9Vq7sXw4LmZ8r", Name: "ideas_db"},
+		ImageDB: config.ImageDBConfig{AccessKeyID: "app", SecretAccessKey: "h6Tn3Jw9QeR2yU5p"},
+		Storage: config.StorageConfig{Driver: config.StorageMinIO, LocalDir: "data/uploads"},
+		App:     config.AppConfig{Env: "production", Version: "1.0.0"},
+		AuthConfig: config.AuthConfig{
+			OTPConfig: config.OTPConfig{
+				ExpiresAtTimer:        5 * time.Minute,
+				AttemptsLeft:          3,
+				ResetResendCountTimer: time.Minute,
+				SoftAttemptsCount:     5,
+				HardAttemptsCount:     10,
+				SubSoftAttemptsTimer:  10 * time.Minute,
+				SubHardAttemptsTimer:  30 * time.Minute,
+				PostHardAttemptsCount: time.Hour,
+			},
+			JWTConfig: config.JWTConfig{
+				Secret:            "c1e0b7f4a9d24e6f8b3a5c7d9e1f2a4b",
+				RefreshTokenTimer: 168 * time.Hour,
+				JWTTokenTimer:     15 * time.Minute,
+			},
+			OTPSenderConfig: config.OTPSenderConfig{
+				Provider: "sms", MaxAttempts: 3, RetryDelay: time.Second, Timeout: 10 * time.Second,
+				SMSGatewayURL: "https://sms.example.com/send", SMSGatewayAPIKey: "sms-api-key",
+			},
+		},
+		Image: config.ImageConfig{
+			MaxBytes: 10 << 20, MaxDimension: 6000, ThumbnailSize: 320, MediumSize: 1280, JPEGQuality: 85,
+			UploadURLExpiry: 15 * time.Minute, UploadTTL: time.Hour, UploadCleanupInterval: 10 * time.Minute,
+			GCInterval: time.Hour, GCGracePeriod: 24 * time.Hour,
+		},
+		Reward:     config.RewardConfig{ExpirySweepInterval: 10 * time.Minute},
+		Invitation: config.InvitationConfig{TTL: 72 * time.Hour, MaxTTL: 720 * time.Hour},
+	}
+}
+
+func (suite *ConfigTestSuite) TestValidate() {
+	suite.NoError(suite.validConfig().Validate())
+
+	tests := []struct {
+		name   string
+		mutate func(cfg *config.Config)
+		errMsg string
+	}{
+		{"Empty JWT secret", func(cfg *config.Config) { cfg.AuthConfig.JWTConfig.Secret = "" }, "AUTH_JWTCONFIG_SECRET or AUTH_JWTCONFIG_SIGNINGKEYFILE must be set"},
+		{"Verification keys without a signing key", func(cfg *config.Config) {
+			cfg.AuthConfig.JWTConfig.VerificationKeyFiles = []string{"old.pem"}
+		}, "AUTH_JWTCONFIG_VERIFICATIONKEYFILES requires AUTH_JWTCONFIG_SIGNINGKEYFILE"},
+		{"Hard-coded JWT secret", func(cfg *config.Config) { cfg.AuthConfig.JWTConfig.Secret = "1234567890" }, "AUTH_JWTCONFIG_SECRET is too weak"},
+		{"Example JWT secret", func(cfg *config.Config) {
+			cfg.AuthConfig.JWTConfig.Secret = "change-me-local-development-secret"
+		}, "AUTH_JWTCONFIG_SECRET is too weak"},
+		{"Default database password", func(cfg *config.Config) { cfg.DB.Password = "postgres" }, "DB_PASSWORD is too weak"},
+		{"File OTP sender in production", func(cfg *config.Config) { cfg.AuthConfig.OTPSenderConfig.Provider = "file" }, `AUTH_OTPSENDER_PROVIDER must be "sms" or "telegram" in production, got "file"`},
+		{"Memory OTP sender in production", func(cfg *config.Config) { cfg.AuthConfig.OTPSenderConfig.Provider = "memory" }, `AUTH_OTPSENDER_PROVIDER must be "sms" or "telegram" in production, got "memory"`},
+		{"SMS sender without an API key", func(cfg *config.Config) { cfg.AuthConfig.OTPSenderConfig.SMSGatewayAPIKey = "" }, "AUTH_OTPSENDER_SMSGATEWAYURL and AUTH_OTPSENDER_SMSGATEWAYAPIKEY must be set"},
+		{"Telegram sender without a token", func(cfg *config.Config) { cfg.AuthConfig.OTPSenderConfig.Provider = "telegram" }, "AUTH_OTPSENDER_TELEGRAMTOKEN must be set"},
+		{"Zero OTP timer", func(cfg *config.Config) { cfg.AuthConfig.OTPConfig.ExpiresAtTimer = 0 }, "AUTH_OTPCONFIG_EXPIRESATTIMER must be positive"},
+		{"Refresh shorter than access token", func(cfg *config.Config) {
+			cfg.AuthConfig.JWTConfig.RefreshTokenTimer = time.Minute
+		}, "must be longer than AUTH_JWTCONFIG_JWTTOKENTIMER"},
+		{"Zero sweep interval", func(cfg *config.Config) { cfg.Reward.ExpirySweepInterval = 0 }, "REWARD_EXPIRYSWEEPINTERVAL must be positive"},
+		{"Unknown storage driver", func(cfg *config.Config) { cfg.Storage.Driver = "ftp" }, `STORAGE_DRIVER must be "minio" or "local"`},
+		{"MinIO driver without credentials", func(cfg *config.Config) { cfg.ImageDB.AccessKeyID = "" }, "MINIO_ACCESS_KEY_ID and MINIO_SECRET_ACCESS_KEY must be set"},
+		{"Local presigned uploads without a secret", func(cfg *config.Config) {
+			cfg.Storage.Driver = config.StorageLocal
+			cfg.Storage.LocalPublicURL = "https://api.example.com/api/v1/storage"
+		}, "STORAGE_LOCALPUBLICURL requires STORAGE_LOCALSIGNINGSECRET"},
+		{"Thumbnail larger than medium", func(cfg *config.Config) { cfg.Image.ThumbnailSize = 2000 }, "IMAGE_THUMBNAILSIZE (2000) must be positive and not larger than IMAGE_MEDIUMSIZE"},
+		{"Zero GC grace period", func(cfg *config.Config) { cfg.Image.GCGracePeriod = 0 }, "IMAGE_GCGRACEPERIOD must be positive"},
+		{"Upload TTL shorter than URL expiry", func(cfg *config.Config) { cfg.Image.UploadTTL = time.Minute }, "IMAGE_UPLOADTTL (1m0s) must not be less than IMAGE_UPLOADURLEXPIRY (15m0s)"},
+		{"Zero shutdown timeout", func(cfg *config.Config) { cfg.Server.ShutdownTimeout = 0 }, "SERVER_SHUTDOWNTIMEOUT must be positive"},
+		{"Negative drain delay", func(cfg *config.Config) { cfg.Server.DrainDelay = -time.Second }, "SERVER_DRAINDELAY must not be negative"},
+		{"Invalid port", func(cfg *config.Config) { cfg.Server.Port = 70000 }, "SERVER_PORT must be between 1 and 65535"},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			cfg := suite.validConfig()
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			suite.Require().Error(err)
+			suite.Contains(err.Error(), tt.errMsg)
+		})
+	}
+
+	suite.Run("Local driver needs no MinIO credentials", func() {
+		cfg := suite.validConfig()
+		cfg.Storage.Driver = config.StorageLocal
+		cfg.ImageDB = config.ImageDBConfig{}
+		suite.NoError(cfg.Validate())
+	})
+
+	suite.Run("Secret is not needed with a signing key", func() {
+		cfg := suite.validConfig()
+		cfg.AuthConfig.JWTConfig.Secret = ""
+		cfg.AuthConfig.JWTConfig.SigningKeyFile = "jwt.pem"
+		suite.NoError(cfg.Validate())
+	})
+
+	suite.Run("Weak secrets and the file OTP sender are allowed outside production", func() {
+		cfg := suite.validConfig()
+		cfg.App.Env = "development"
+		cfg.AuthConfig.JWTConfig.Secret = "1234567890"
+		cfg.DB.Password = "postgres"
+		cfg.AuthConfig.OTPSenderConfig.Provider = "file"
+		suite.NoError(cfg.Validate())
+	})
+
+	suite.Run("Durations are checked outside production", func() {
+		cfg := suite.validConfig()
+		cfg.App.Env = "development"
+		cfg.AuthConfig.OTPConfig.SubHardAttemptsTimer = 0
+		suite.Error(cfg.Validate())
+	})
+}
+
+func (suite *ConfigTestSuite) TestRedacted() {
+	cfg := suite.validConfig()
+	cfg.AuthConfig.OTPSenderConfig.TelegramToken = ""
+
+	dump := fmt.Sprintf("%+v", cfg.Redacted())
+	for _, secret := range []string{cfg.DB.Password, cfg.ImageDB.SecretAccessKey, cfg.AuthConfig.JWTConfig.Secret} {
+		suite.NotContains(dump, secret)
+	}
+	suite.Contains(dump, "[REDACTED]")
+	suite.Equal("", cfg.Redacted().AuthConfig.OTPSenderConfig.TelegramToken)
+	suite.Equal("c1e0b7f4a9d24e6f8b3a5c7d9e1f2a4b", cfg.AuthConfig.JWTConfig.Secret, "the original config is not modified")
+	suite.Equal("0.0.0.0:8080", cfg.Server.Address())
+	suite.Equal(":8080", config.ServerConfig{Port: 8080}.Address(), "an empty host listens on all interfaces")
+}
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthIntegrationTestSuite struct {
+	BaseTestSuite
+}
+
+func (suite *HealthIntegrationTestSuite) SetupSuite() {
+	suite.BaseTestSuite.SetupSuite()
+}
+
+func (suite *HealthIntegrationTestSuite) TearDownTest() {
+	suite.StorageErr = nil
+	suite.BaseTestSuite.TearDownTest()
+}
+
+func TestHealthIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthIntegrationTestSuite))
+}
+
+func (suite *HealthIntegrationTestSuite) probe(path string) (int, dto.ProbeResponse) {
+	w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: path})
+	var resp dto.ProbeResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+func (suite *HealthIntegrationTestSuite) TestProbes() {
+	suite.Run("Liveness needs no auth", func() {
+		code, resp := suite.probe("/livez")
+		suite.Equal(http.StatusOK, code)
+		suite.Equal("ok", resp.Status)
+	})
+
+	suite.Run("Ready when dependencies are reachable", func() {
+		code, resp := suite.probe("/readyz")
+		suite.Equal(http.StatusOK, code)
+		suite.Equal(map[string]string{"postgres": "ok", "storage": "ok"}, resp.Checks)
+	})
+
+	suite.Run("Not ready when a dependency fails", func() {
+		suite.StorageErr = errors.New("dial tcp: connection refused")
+		code, resp := suite.probe("/readyz")
+		suite.Equal(http.StatusServiceUnavailable, code)
+		suite.Equal("unavailable", resp.Status)
+		suite.Equal("failed", resp.Checks["storage"])
+		suite.Equal("ok", resp.Checks["postgres"])
+
+		code, _ = suite.probe("/livez")
+		suite.Equal(http.StatusOK, code, "liveness does not depend on dependencies")
+		suite.StorageErr = nil
+	})
+
+	suite.Run("Not ready while draining", func() {
+		suite.HealthHandler.Drain()
+		code, resp := suite.probe("/readyz")
+		suite.Equal(http.StatusServiceUnavailable, code)
+		suite.Equal("shutting down", resp.Status)
+	})
+}
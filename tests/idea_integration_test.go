@@ -8,6 +8,7 @@ import (
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
@@ -38,7 +39,7 @@ func (suite *IdeaIntegrationTestSuite) createIdeaPrerequisites() (string, *model
 	coffeeShop := &models.CoffeeShop{
 		Name:      "Test Coffee Shop for Ideas",
 		Address:   "123 Idea St",
-		CreatorID: user.ID,
+		CreatorID: &user.ID,
 	}
 	err := suite.DB.Create(coffeeShop).Error
 	suite.Require().NoError(err)
@@ -75,28 +76,7 @@ func (suite *IdeaIntegrationTestSuite) createTestIdea(author *models.User, cs *m
 func (suite *IdeaIntegrationTestSuite) TestCreateIdea() {
 	token, _, coffeeShop, category := suite.createIdeaPrerequisites()
 
-	// Dummy image content (minimal valid JPEG)
-	dummyImageContent := []byte{
-		0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 0x4A, 0x46, 0x49, 0x46, 0x00, 0x01,
-		0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xDB, 0x00, 0x43,
-		0x00, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
-		0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
-		0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
-		0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
-		0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01,
-		0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0xFF, 0xC0, 0x00, 0x11, 0x08, 0x00,
-		0x01, 0x00, 0x01, 0x03, 0x01, 0x22, 0x00, 0x02, 0x11, 0x01, 0x03, 0x11,
-		0x01, 0xFF, 0xC4, 0x00, 0x1F, 0x00, 0x00, 0x01, 0x05, 0x01, 0x01, 0x01,
-		0x01, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
-		0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0xFF, 0xC4,
-		0x00, 0xB5, 0x10, 0x00, 0x02, 0x01, 0x03, 0x03, 0x02, 0x04, 0x03, 0x05,
-		0x05, 0x04, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04,
-		0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x01, 0x00, 0x03, 0x01, 0x01,
-		0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00,
-		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B,
-		0xFF, 0xDA, 0x00, 0x0C, 0x03, 0x01, 0x00, 0x02, 0x11, 0x03, 0x11, 0x00,
-		0x3F, 0x00, 0xBF, 0x40, 0xFF, 0xD9,
-	}
+	imageContent := suite.JPEGImage(64, 48)
 
 	tests := []struct {
 		name           string
@@ -136,13 +116,28 @@ func (suite *IdeaIntegrationTestSuite) TestCreateIdea() {
 				"coffee_shop_id": coffeeShop.ID.String(),
 			},
 			fileField:      "image",
-			fileContent:    dummyImageContent,
+			fileContent:    imageContent,
 			fileName:       "test_image.jpg",
 			contentType:    "multipart/form-data",
 			expectedStatus: http.StatusCreated,
 			checkResponse:  true,
 			expectedTitle:  "Idea with Picture",
-			expectedImageURL: "test_image.jpg",
+			expectedImageURL: "/original.jpg",
+		},
+		{
+			name:  "Fail - File is not an image",
+			token: token,
+			formData: map[string]string{
+				"title":          "Idea with a fake picture",
+				"description":    "This idea has a text file renamed to .jpg.",
+				"category_id":    category.ID.String(),
+				"coffee_shop_id": coffeeShop.ID.String(),
+			},
+			fileField:      "image",
+			fileContent:    []byte("definitely not an image"),
+			fileName:       "fake.jpg",
+			contentType:    "multipart/form-data",
+			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:           "Fail - Unauthorized",
@@ -201,12 +196,12 @@ func (suite *IdeaIntegrationTestSuite) TestGetAllIdeas() {
 
 	suite.Equal(http.StatusOK, w.Code)
 
-	var resp []dto.IdeaResponse
+	var resp pagination.Page[dto.IdeaResponse]
 	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	suite.NoError(err)
 
 	found := false
-	for _, i := range resp {
+	for _, i := range resp.Items {
 		if i.ID == idea.ID {
 			found = true
 			suite.False(i.CreatedAt.IsZero(), "CreatedAt should not be zero")
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdeaSearchIntegrationTestSuite struct {
+	BaseTestSuite
+}
+
+func (suite *IdeaSearchIntegrationTestSuite) SetupSuite() {
+	suite.BaseTestSuite.SetupSuite()
+}
+
+func (suite *IdeaSearchIntegrationTestSuite) TearDownTest() {
+	suite.BaseTestSuite.TearDownTest()
+}
+
+func TestIdeaSearchIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(IdeaSearchIntegrationTestSuite))
+}
+
+type searchFixture struct {
+	shop      *models.CoffeeShop
+	author    *models.User
+	drinks    *models.Category
+	service   *models.Category
+	newStatus *models.IdeaStatus
+	oldStatus *models.IdeaStatus
+}
+
+func (suite *IdeaSearchIntegrationTestSuite) createSearchFixture() searchFixture {
+	author, shop := suite.CreateTestUser("search-author", "111111111", "Search Shop", "1 Search St", suite.UserRoleID)
+	other := suite.CreateUser("search-other", "222222222")
+
+	drinks := &models.Category{Title: "Drinks", CoffeeShopID: &shop.ID}
+	service := &models.Category{Title: "Service", CoffeeShopID: &shop.ID}
+	suite.Require().NoError(suite.DB.Create(drinks).Error)
+	suite.Require().NoError(suite.DB.Create(service).Error)
+
+	newStatus := &models.IdeaStatus{Title: "Новая", CoffeeShopID: &shop.ID, IsInitial: true}
+	oldStatus := &models.IdeaStatus{Title: "Старая", CoffeeShopID: &shop.ID}
+	suite.Require().NoError(suite.DB.Create(newStatus).Error)
+	suite.Require().NoError(suite.DB.Create(oldStatus).Error)
+
+	image := "ideas/latte.png"
+	ideas := []*models.Idea{
+		{Title: "Овсяный латте", Description: "Добавить в меню латте на овсяном молоке", CategoryID: &drinks.ID, StatusID: &newStatus.ID, CreatorID: &author.ID, ImageURL: &image},
+		{Title: "Pumpkin spice latte", Description: "Seasonal drink for autumn", CategoryID: &drinks.ID, StatusID: &oldStatus.ID, CreatorID: &other.ID},
+		{Title: "Быстрая касса", Description: "Отдельная касса для заказов навынос", CategoryID: &service.ID, StatusID: &newStatus.ID, CreatorID: &author.ID},
+	}
+	for i, idea := range ideas {
+		idea.CoffeeShopID = &shop.ID
+		suite.Require().NoError(suite.DB.Create(idea).Error)
+		createdAt := time.Date(2024, time.January, i+1, 12, 0, 0, 0, time.UTC)
+		suite.Require().NoError(suite.DB.Model(idea).UpdateColumn("created_at", createdAt).Error)
+	}
+
+	return searchFixture{shop: shop, author: author, drinks: drinks, service: service, newStatus: newStatus, oldStatus: oldStatus}
+}
+
+func (suite *IdeaSearchIntegrationTestSuite) search(shopID string, query url.Values) ([]dto.IdeaResponse, int) {
+	req := TestRequest{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/ideas/search?%s", shopID, query.Encode()),
+	}
+	w := suite.MakeRequest(req)
+	if w.Code != http.StatusOK {
+		return nil, w.Code
+	}
+
+	var resp []dto.IdeaResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp, w.Code
+}
+
+func titles(ideas []dto.IdeaResponse) []string {
+	res := make([]string, len(ideas))
+	for i, idea := range ideas {
+		res[i] = idea.Title
+	}
+	return res
+}
+
+func (suite *IdeaSearchIntegrationTestSuite) TestSearchIdeas() {
+	f := suite.createSearchFixture()
+	shopID := f.shop.ID.String()
+
+	tests := []struct {
+		name     string
+		query    url.Values
+		expected []string
+	}{
+		{
+			name:     "Russian morphology",
+			query:    url.Values{"q": {"латте овсяное"}},
+			expected: []string{"Овсяный латте"},
+		},
+		{
+			name:     "English stemming",
+			query:    url.Values{"q": {"drinks"}},
+			expected: []string{"Pumpkin spice latte"},
+		},
+		{
+			name:     "Websearch OR across languages",
+			query:    url.Values{"q": {"касса or pumpkin"}, "sort": {"created_at"}},
+			expected: []string{"Pumpkin spice latte", "Быстрая касса"},
+		},
+		{
+			name:     "Status filter",
+			query:    url.Values{"status_id": {f.newStatus.ID.String()}, "sort": {"created_at"}},
+			expected: []string{"Овсяный латте", "Быстрая касса"},
+		},
+		{
+			name:     "Category and creator filters",
+			query:    url.Values{"category_id": {f.drinks.ID.String()}, "creator_id": {f.author.ID.String()}},
+			expected: []string{"Овсяный латте"},
+		},
+		{
+			name:     "Date range",
+			query:    url.Values{"created_from": {"2024-01-02T00:00:00Z"}, "created_to": {"2024-01-02T23:59:59Z"}},
+			expected: []string{"Pumpkin spice latte"},
+		},
+		{
+			name:     "Without image",
+			query:    url.Values{"has_image": {"false"}, "sort": {"created_at"}},
+			expected: []string{"Pumpkin spice latte", "Быстрая касса"},
+		},
+		{
+			name:     "No match",
+			query:    url.Values{"q": {"круассан"}},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			resp, code := suite.search(shopID, tt.query)
+			suite.Require().Equal(http.StatusOK, code)
+			suite.Equal(tt.expected, titles(resp))
+		})
+	}
+}
+
+func (suite *IdeaSearchIntegrationTestSuite) TestSearchIdeasValidation() {
+	f := suite.createSearchFixture()
+	shopID := f.shop.ID.String()
+
+	tests := []struct {
+		name  string
+		query url.Values
+	}{
+		{name: "Invalid status id", query: url.Values{"status_id": {"not-a-uuid"}}},
+		{name: "Invalid date", query: url.Values{"created_from": {"yesterday"}}},
+		{name: "Invalid has_image", query: url.Values{"has_image": {"maybe"}}},
+		{name: "Inverted date range", query: url.Values{"created_from": {"2024-02-01T00:00:00Z"}, "created_to": {"2024-01-01T00:00:00Z"}}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			_, code := suite.search(shopID, tt.query)
+			suite.Equal(http.StatusBadRequest, code)
+		})
+	}
+}
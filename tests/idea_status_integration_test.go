@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ type IdeaStatusTestSuite struct {
 	BaseTestSuite
 	AdminToken string
 	AdminUser  *models.User
+	AdminShop  *models.CoffeeShop
 }
 
 func (suite *IdeaStatusTestSuite) SetupTest() {
@@ -25,76 +27,99 @@ func (suite *IdeaStatusTestSuite) SetupTest() {
 	name := "Admin"
 	phone := "1234567890"
 	suite.AdminUser = suite.CreateUser(name, phone)
-	
-	// Assign admin role in a system shop (needed for admin access)
-	// Or use existing helper to create a shop where they are admin
-	// The middleware AdminFilter checks IsAdminInAnyShop
-	// So we need to create a shop and make them admin
+
+	// Statuses are managed per coffee shop, so the admin needs a shop of their own
 	shop := &models.CoffeeShop{
 		ID:        uuid.New(),
-		CreatorID: suite.AdminUser.ID,
+		CreatorID: &suite.AdminUser.ID,
 		Name:      "Admin Shop",
 		Address:   "Admin Address",
 	}
 	suite.DB.Create(shop)
-	
+	suite.AdminShop = shop
+
 	suite.CreateWorkerForShop(suite.AdminUser, shop, suite.AdminRoleID)
-	
+
 	suite.AdminToken = suite.GetAuthToken(phone, "123456", name)
-	
-	// Create "Создана" status for default assignment tests if it doesn't exist
-	createdStatus := &models.IdeaStatus{
-		Title: "Создана",
-	}
-	suite.DB.Create(createdStatus)
 }
 
-func (suite *IdeaStatusTestSuite) TestCreateUpdateDeleteStatus() {
-	// 1. Create Status
-	statusTitle := fmt.Sprintf("NewStatus_%d", time.Now().UnixNano())
-	createReq := dto.CreateIdeaStatusRequest{
-		Title: statusTitle,
-	}
-	
+func (suite *IdeaStatusTestSuite) createStatus(title string, isInitial, isTerminal bool) uuid.UUID {
 	req := TestRequest{
 		method: http.MethodPost,
-		path:   "/api/v1/admin/statuses",
-		body:   createReq,
-		token:  suite.AdminToken,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/statuses", suite.AdminShop.ID),
+		body: dto.CreateIdeaStatusRequest{
+			Title:      title,
+			IsInitial:  isInitial,
+			IsTerminal: isTerminal,
+		},
+		token: suite.AdminToken,
 	}
 	w := suite.MakeRequest(req)
-	suite.Require().Equal(http.StatusCreated, w.Code)
-	
-	var createResp map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &createResp)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+
+	var createResp map[string]string
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &createResp))
+	id, err := uuid.Parse(createResp["id"])
 	suite.Require().NoError(err)
-	statusIDStr := createResp["id"].(string)
-	suite.Require().NotEmpty(statusIDStr)
+	return id
+}
+
+func (suite *IdeaStatusTestSuite) createTransition(from, to uuid.UUID) *httptest.ResponseRecorder {
+	req := TestRequest{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/status-transitions", suite.AdminShop.ID),
+		body: dto.CreateIdeaStatusTransitionRequest{
+			FromStatusID: from,
+			ToStatusID:   to,
+		},
+		token: suite.AdminToken,
+	}
+	return suite.MakeRequest(req)
+}
+
+func (suite *IdeaStatusTestSuite) TestCreateUpdateDeleteStatus() {
+	// 1. Create Status. The first status of a shop becomes initial.
+	statusTitle := fmt.Sprintf("NewStatus_%d", time.Now().UnixNano())
+	initialID := suite.createStatus(statusTitle, false, false)
+	statusIDStr := initialID.String()
 
 	// 2. Get Status
-	req = TestRequest{
+	req := TestRequest{
 		method: http.MethodGet,
 		path:   "/api/v1/statuses/" + statusIDStr,
 		token:  "",
 	}
-	w = suite.MakeRequest(req)
+	w := suite.MakeRequest(req)
 	suite.Require().Equal(http.StatusOK, w.Code)
-	
+
 	var getResp dto.IdeaStatusResponse
-	err = json.Unmarshal(w.Body.Bytes(), &getResp)
+	err := json.Unmarshal(w.Body.Bytes(), &getResp)
 	suite.Require().NoError(err)
 	suite.Equal(statusTitle, getResp.Title)
 	suite.Equal(statusIDStr, getResp.ID.String())
+	suite.True(getResp.IsInitial)
+	suite.Equal(suite.AdminShop.ID, *getResp.CoffeeShopID)
+
+	// Duplicate title in the same shop is rejected
+	req = TestRequest{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/statuses", suite.AdminShop.ID),
+		body:   dto.CreateIdeaStatusRequest{Title: statusTitle},
+		token:  suite.AdminToken,
+	}
+	w = suite.MakeRequest(req)
+	suite.Equal(http.StatusConflict, w.Code)
 
 	// 3. Update Status
 	updatedTitle := statusTitle + "_updated"
 	updateReq := dto.UpdateIdeaStatusRequest{
-		Title: updatedTitle,
+		Title:     updatedTitle,
+		IsInitial: true,
 	}
-	
+
 	req = TestRequest{
 		method: http.MethodPut,
-		path:   "/api/v1/admin/statuses/" + statusIDStr,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/statuses/%s", suite.AdminShop.ID, statusIDStr),
 		body:   updateReq,
 		token:  suite.AdminToken,
 	}
@@ -113,13 +138,18 @@ func (suite *IdeaStatusTestSuite) TestCreateUpdateDeleteStatus() {
 	suite.Require().NoError(err)
 	suite.Equal(updatedTitle, getResp.Title)
 
-	// 4. Delete Status
+	// The initial status cannot be deleted
 	req = TestRequest{
 		method: http.MethodDelete,
-		path:   "/api/v1/admin/statuses/" + statusIDStr,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/statuses/%s", suite.AdminShop.ID, statusIDStr),
 		token:  suite.AdminToken,
 	}
 	w = suite.MakeRequest(req)
+	suite.Require().Equal(http.StatusBadRequest, w.Code)
+
+	// 4. Delete Status after another one became initial
+	suite.createStatus("Replacement", true, false)
+	w = suite.MakeRequest(req)
 	suite.Require().Equal(http.StatusOK, w.Code)
 
 	// Verify Deletion
@@ -132,13 +162,30 @@ func (suite *IdeaStatusTestSuite) TestCreateUpdateDeleteStatus() {
 	suite.Require().Equal(http.StatusNotFound, w.Code)
 }
 
+func (suite *IdeaStatusTestSuite) TestNonAdminCannotManageStatuses() {
+	userName := "Worker"
+	userPhone := "9876543210"
+	user := suite.CreateUser(userName, userPhone)
+	suite.CreateWorkerForShop(user, suite.AdminShop, suite.UserRoleID)
+	userToken := suite.GetAuthToken(userPhone, "123456", userName)
+
+	req := TestRequest{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/statuses", suite.AdminShop.ID),
+		body:   dto.CreateIdeaStatusRequest{Title: "Sneaky"},
+		token:  userToken,
+	}
+	w := suite.MakeRequest(req)
+	suite.Equal(http.StatusForbidden, w.Code)
+}
+
 func (suite *IdeaStatusTestSuite) TestDefaultStatusAssignment() {
 	// User creates an idea
 	userName := "User"
 	userPhone := "9876543210"
 	_, shop := suite.CreateTestUser(userName, userPhone, "User Shop", "Address", suite.UserRoleID)
 	userToken := suite.GetAuthToken(userPhone, "123456", userName)
-	
+
 	desc := "Desc"
 	cat := &models.Category{
 		CoffeeShopID: &shop.ID,
@@ -153,7 +200,7 @@ func (suite *IdeaStatusTestSuite) TestDefaultStatusAssignment() {
 		"title":          "Idea with Default Status",
 		"description":    "Testing default status",
 	}
-	
+
 	req := TestRequest{
 		method:      http.MethodPost,
 		path:        "/api/v1/ideas",
@@ -163,68 +210,206 @@ func (suite *IdeaStatusTestSuite) TestDefaultStatusAssignment() {
 	}
 	w := suite.MakeRequest(req)
 	suite.Require().Equal(http.StatusCreated, w.Code) // Idea handler returns 201 Created
-	
+
 	var ideaResp dto.IdeaResponse
 	err := json.Unmarshal(w.Body.Bytes(), &ideaResp)
 	suite.Require().NoError(err)
-	
-	// Verify StatusID is not nil and corresponds to "Создана"
+
+	// The shop had no workflow, so it got the default one starting at "Создана"
 	suite.Require().NotNil(ideaResp.StatusID)
-	
+	suite.Equal("Создана", ideaResp.StatusName)
+
 	var status models.IdeaStatus
-	suite.DB.First(&status, "title = ?", "Создана")
+	suite.DB.First(&status, "coffee_shop_id = ? AND is_initial = true", shop.ID)
 	suite.Equal(status.ID, *ideaResp.StatusID)
+
+	req = TestRequest{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/api/v1/coffee-shops/%s/statuses", shop.ID),
+	}
+	w = suite.MakeRequest(req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var statuses []dto.IdeaStatusResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &statuses))
+	suite.Len(statuses, 4)
+}
+
+func (suite *IdeaStatusTestSuite) TestConfiguredInitialStatusAssignment() {
+	suite.createStatus("Draft", false, false)
+	reviewID := suite.createStatus("Review", true, false)
+
+	desc := "Desc"
+	cat := &models.Category{
+		CoffeeShopID: &suite.AdminShop.ID,
+		Title:        "Test Category",
+		Description:  &desc,
+	}
+	suite.DB.Create(cat)
+
+	req := TestRequest{
+		method: http.MethodPost,
+		path:   "/api/v1/ideas",
+		formData: map[string]string{
+			"coffee_shop_id": suite.AdminShop.ID.String(),
+			"category_id":    cat.ID.String(),
+			"title":          "Idea with Configured Status",
+			"description":    "Testing configured status",
+		},
+		contentType: "multipart/form-data",
+		token:       suite.AdminToken,
+	}
+	w := suite.MakeRequest(req)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+
+	var ideaResp dto.IdeaResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &ideaResp))
+	suite.Require().NotNil(ideaResp.StatusID)
+	suite.Equal(reviewID, *ideaResp.StatusID)
 }
 
 func (suite *IdeaStatusTestSuite) TestUpdateIdeaStatus() {
 	// Create Statuses
-	status1 := &models.IdeaStatus{Title: "В работе"}
-	status2 := &models.IdeaStatus{Title: "Done"}
-	suite.DB.Create(status1)
-	suite.DB.Create(status2)
-
-	// User creates idea
-	userName := "Creator"
-	userPhone := "5555555555"
-	user, shop := suite.CreateTestUser(userName, userPhone, "Creator Shop", "Addr", suite.UserRoleID)
-	userToken := suite.GetAuthToken(userPhone, "123456", userName)
-	
+	createdID := suite.createStatus("Создана", true, false)
+	inWorkID := suite.createStatus("В работе", false, false)
+	doneID := suite.createStatus("Done", false, true)
+
+	w := suite.createTransition(createdID, inWorkID)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	w = suite.createTransition(inWorkID, doneID)
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+
+	suite.Run("Fail - Duplicate transition", func() {
+		w := suite.createTransition(createdID, inWorkID)
+		suite.Equal(http.StatusConflict, w.Code)
+	})
+
+	suite.Run("Fail - Transition out of terminal status", func() {
+		w := suite.createTransition(doneID, createdID)
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
 	desc := "D"
 	cat := &models.Category{
-		CoffeeShopID: &shop.ID,
+		CoffeeShopID: &suite.AdminShop.ID,
 		Title:        "Cat",
 		Description:  &desc,
 	}
 	suite.DB.Create(cat)
 
-	// Create idea (will have "Создана" status)
 	idea := &models.Idea{
-		CreatorID:    &user.ID,
-		CoffeeShopID: &shop.ID,
+		CreatorID:    &suite.AdminUser.ID,
+		CoffeeShopID: &suite.AdminShop.ID,
 		CategoryID:   &cat.ID,
+		StatusID:     &createdID,
 		Title:        "My Idea",
 		Description:  "Desc",
 	}
 	suite.DB.Create(idea)
 
-	// Update status to "В работе"
-	updateReq := dto.UpdateIdeaRequest{
-		StatusID: &status1.ID,
+	updateStatus := func(statusID uuid.UUID) int {
+		req := TestRequest{
+			method: http.MethodPut,
+			path:   "/api/v1/ideas/" + idea.ID.String(),
+			body:   dto.UpdateIdeaRequest{StatusID: &statusID},
+			token:  suite.AdminToken,
+		}
+		return suite.MakeRequest(req).Code
 	}
-	
+
+	suite.Run("Fail - Transition not allowed", func() {
+		suite.Equal(http.StatusBadRequest, updateStatus(doneID))
+	})
+
+	suite.Run("Fail - Status of another shop", func() {
+		otherStatus := &models.IdeaStatus{Title: "Foreign"}
+		suite.DB.Create(otherStatus)
+		suite.Equal(http.StatusBadRequest, updateStatus(otherStatus.ID))
+	})
+
+	suite.Run("Success - Allowed transitions", func() {
+		suite.Require().Equal(http.StatusNoContent, updateStatus(inWorkID))
+		suite.Require().Equal(http.StatusNoContent, updateStatus(doneID))
+
+		var updatedIdea models.Idea
+		suite.DB.First(&updatedIdea, "id = ?", idea.ID)
+		suite.Equal(doneID, *updatedIdea.StatusID)
+	})
+
+	suite.Run("Fail - Idea in terminal status", func() {
+		suite.Equal(http.StatusBadRequest, updateStatus(inWorkID))
+	})
+}
+
+func (suite *IdeaStatusTestSuite) TestStatusHistory() {
+	desc := "Desc"
+	cat := &models.Category{
+		CoffeeShopID: &suite.AdminShop.ID,
+		Title:        "History Category",
+		Description:  &desc,
+	}
+	suite.DB.Create(cat)
+
 	req := TestRequest{
-		method: http.MethodPut,
-		path:   "/api/v1/ideas/" + idea.ID.String(),
-		body:   updateReq,
-		token:  userToken,
+		method: http.MethodPost,
+		path:   "/api/v1/ideas",
+		formData: map[string]string{
+			"coffee_shop_id": suite.AdminShop.ID.String(),
+			"category_id":    cat.ID.String(),
+			"title":          "Idea with History",
+			"description":    "Testing status history",
+		},
+		contentType: "multipart/form-data",
+		token:       suite.AdminToken,
 	}
 	w := suite.MakeRequest(req)
-	suite.Require().Equal(http.StatusNoContent, w.Code) // Expect 204
-	
-	// Verify update
-	var updatedIdea models.Idea
-	suite.DB.First(&updatedIdea, "id = ?", idea.ID)
-	suite.Equal(status1.ID, *updatedIdea.StatusID)
+	suite.Require().Equal(http.StatusCreated, w.Code)
+
+	var ideaResp dto.IdeaResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &ideaResp))
+
+	var inWork models.IdeaStatus
+	suite.Require().NoError(suite.DB.First(&inWork, "coffee_shop_id = ? AND title = ?", suite.AdminShop.ID, "В работе").Error)
+
+	note := "Начинаем на следующей неделе"
+	req = TestRequest{
+		method: http.MethodPut,
+		path:   "/api/v1/ideas/" + ideaResp.ID.String(),
+		body:   dto.UpdateIdeaRequest{StatusID: &inWork.ID, StatusNote: &note},
+		token:  suite.AdminToken,
+	}
+	w = suite.MakeRequest(req)
+	suite.Require().Equal(http.StatusNoContent, w.Code, w.Body.String())
+
+	req = TestRequest{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/api/v1/ideas/%s/history", ideaResp.ID),
+	}
+	w = suite.MakeRequest(req)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	var history []dto.IdeaStatusHistoryResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &history))
+	suite.Require().Len(history, 2)
+
+	suite.Nil(history[0].FromStatusID)
+	suite.Equal("Создана", history[0].ToStatusName)
+
+	suite.Equal(ideaResp.StatusID, history[1].FromStatusID)
+	suite.Equal(inWork.ID, *history[1].ToStatusID)
+	suite.Equal("В работе", history[1].ToStatusName)
+	suite.Equal(suite.AdminUser.ID, *history[1].ActorID)
+	suite.Equal("Admin", history[1].ActorName)
+	suite.Require().NotNil(history[1].Note)
+	suite.Equal(note, *history[1].Note)
+
+	suite.Run("Fail - Unknown idea", func() {
+		req := TestRequest{
+			method: http.MethodGet,
+			path:   fmt.Sprintf("/api/v1/ideas/%s/history", uuid.New()),
+		}
+		w := suite.MakeRequest(req)
+		suite.Equal(http.StatusNotFound, w.Code)
+	})
 }
 
 func TestIdeaStatusTestSuite(t *testing.T) {
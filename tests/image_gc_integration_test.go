@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/imageproc"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImageGCIntegrationTestSuite struct {
+	BaseTestSuite
+	token      string
+	coffeeShop *models.CoffeeShop
+	category   *models.Category
+}
+
+func TestImageGCIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ImageGCIntegrationTestSuite))
+}
+
+func (suite *ImageGCIntegrationTestSuite) SetupTest() {
+	user := suite.CreateUser("gc-user", "888888888")
+	suite.token = suite.RegisterUserAndGetToken(user)
+
+	suite.coffeeShop = &models.CoffeeShop{Name: "GC Coffee Shop", Address: "1 GC St", CreatorID: &user.ID}
+	suite.Require().NoError(suite.DB.Create(suite.coffeeShop).Error)
+	suite.category = &models.Category{Title: "GC Category", CoffeeShopID: &suite.coffeeShop.ID}
+	suite.Require().NoError(suite.DB.Create(suite.category).Error)
+}
+
+// postIdea creates an idea with an image in the coffee shop and returns the response.
+func (suite *ImageGCIntegrationTestSuite) postIdea(coffeeShopID uuid.UUID) (int, dto.IdeaResponse) {
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost,
+		path:   "/api/v1/ideas",
+		token:  suite.token,
+		formData: map[string]string{
+			"title":          "Idea with Picture",
+			"description":    "This idea has a picture.",
+			"category_id":    suite.category.ID.String(),
+			"coffee_shop_id": coffeeShopID.String(),
+		},
+		fileField:   "image",
+		fileContent: suite.JPEGImage(64, 48),
+		fileName:    "photo.jpg",
+		contentType: "multipart/form-data",
+	})
+	var resp dto.IdeaResponse
+	if w.Code == http.StatusCreated {
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Require().NotNil(resp.ImageURL)
+	}
+	return w.Code, resp
+}
+
+// ageImages moves the images stored so far past the grace period.
+func (suite *ImageGCIntegrationTestSuite) ageImages() {
+	past := time.Now().Add(-suite.cfg.Image.GCGracePeriod - time.Hour)
+	suite.Require().NoError(suite.DB.Exec("UPDATE stored_image SET created_at = ?", past).Error)
+}
+
+func (suite *ImageGCIntegrationTestSuite) imageURLs(report *dto.ImageGCReport) []string {
+	urls := make([]string, 0, len(report.Images))
+	for _, image := range report.Images {
+		urls = append(urls, image.ImageURL)
+	}
+	return urls
+}
+
+// assertFiles checks whether the original and the variants of the image are stored.
+func (suite *ImageGCIntegrationTestSuite) assertFiles(imageURL string, stored bool) {
+	for _, url := range []*string{&imageURL, usecase.ImageVariantURL(&imageURL, imageproc.Medium), usecase.ImageVariantURL(&imageURL, imageproc.Thumbnail)} {
+		_, err := suite.ImageUsecase.StatImage(suite.Ctx, *url)
+		if stored {
+			suite.NoError(err, *url)
+		} else {
+			suite.Error(err, *url)
+		}
+	}
+}
+
+func (suite *ImageGCIntegrationTestSuite) TestCollectsUnreferencedImages() {
+	_, kept := suite.postIdea(suite.coffeeShop.ID)
+	_, deleted := suite.postIdea(suite.coffeeShop.ID)
+	_, replaced := suite.postIdea(suite.coffeeShop.ID)
+	status, _ := suite.postIdea(uuid.New())
+	suite.Require().NotEqual(http.StatusCreated, status, "creating an idea in an unknown coffee shop fails after the upload")
+
+	w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/ideas/%s", deleted.ID), token: suite.token})
+	suite.Require().Equal(http.StatusNoContent, w.Code)
+	w = suite.MakeRequest(TestRequest{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("/api/v1/ideas/%s", replaced.ID),
+		token:  suite.token,
+		body:   dto.UpdateIdeaRequest{ImageURL: kept.ImageURL},
+	})
+	suite.Require().Equal(http.StatusNoContent, w.Code)
+
+	var tracked []models.StoredImage
+	suite.Require().NoError(suite.DB.Find(&tracked).Error)
+	suite.Require().Len(tracked, 4, "every stored image is tracked")
+
+	suite.Run("Recent images are kept", func() {
+		report, err := suite.ImageUsecase.CollectGarbage(suite.Ctx, false)
+		suite.Require().NoError(err)
+		suite.Empty(report.Images)
+	})
+
+	suite.ageImages()
+
+	suite.Run("Dry run only reports", func() {
+		report, err := suite.ImageUsecase.CollectGarbage(suite.Ctx, true)
+		suite.Require().NoError(err)
+		suite.True(report.DryRun)
+		suite.Len(report.Images, 3, "the deleted, the replaced and the failed idea's images")
+		suite.Contains(suite.imageURLs(report), *deleted.ImageURL)
+		suite.Contains(suite.imageURLs(report), *replaced.ImageURL)
+		suite.NotContains(suite.imageURLs(report), *kept.ImageURL)
+		suite.Positive(report.Bytes)
+		suite.assertFiles(*deleted.ImageURL, true)
+	})
+
+	suite.Run("Unreferenced images are deleted", func() {
+		report, err := suite.ImageUsecase.CollectGarbage(suite.Ctx, false)
+		suite.Require().NoError(err)
+		suite.False(report.DryRun)
+		suite.Len(report.Images, 3)
+		suite.Zero(report.Failed)
+
+		suite.assertFiles(*deleted.ImageURL, false)
+		suite.assertFiles(*replaced.ImageURL, false)
+		suite.assertFiles(*kept.ImageURL, true)
+
+		var remaining []models.StoredImage
+		suite.Require().NoError(suite.DB.Find(&remaining).Error)
+		suite.Require().Len(remaining, 1)
+		suite.Equal(*kept.ImageURL, remaining[0].ImageURL)
+	})
+
+	suite.Run("Collected images can not be attached", func() {
+		for _, imageURL := range []string{*deleted.ImageURL, suite.cfg.ImageDB.BucketName + "/unknown/original.jpg"} {
+			w := suite.MakeRequest(TestRequest{
+				method: http.MethodPut,
+				path:   fmt.Sprintf("/api/v1/ideas/%s", kept.ID),
+				token:  suite.token,
+				body:   dto.UpdateIdeaRequest{ImageURL: &imageURL},
+			})
+			suite.Equal(http.StatusBadRequest, w.Code, imageURL)
+		}
+	})
+
+	suite.Run("Nothing is left to collect", func() {
+		report, err := suite.ImageUsecase.CollectGarbage(suite.Ctx, false)
+		suite.Require().NoError(err)
+		suite.Empty(report.Images)
+	})
+}
+
+func (suite *ImageGCIntegrationTestSuite) TestKeepsImagesOfPendingUploads() {
+	data := suite.JPEGImage(64, 48)
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost,
+		path:   "/api/v1/uploads",
+		token:  suite.token,
+		body:   dto.CreateUploadRequest{ContentType: "image/jpeg", Size: int64(len(data))},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var upload dto.UploadResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &upload))
+
+	stored, err := suite.ImageUploadRepo.GetByID(suite.Ctx, upload.UploadID)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.Store.Put(suite.Ctx, stored.ObjectKey, bytes.NewReader(data), int64(len(data)), "image/jpeg"))
+	imageURL, err := suite.ImageUsecase.FinalizeUpload(suite.Ctx, stored.UserID, upload.UploadID)
+	suite.Require().NoError(err)
+
+	suite.ageImages()
+	report, err := suite.ImageUsecase.CollectGarbage(suite.Ctx, false)
+	suite.Require().NoError(err)
+	suite.Empty(report.Images, "the finalized upload still refers to the image")
+	suite.assertFiles(imageURL, true)
+
+	suite.Require().NoError(suite.DB.Delete(&models.ImageUpload{}, "id = ?", upload.UploadID).Error)
+	report, err = suite.ImageUsecase.CollectGarbage(suite.Ctx, false)
+	suite.Require().NoError(err)
+	suite.Equal([]string{imageURL}, suite.imageURLs(report), "collected once the upload is gone and no idea uses it")
+	suite.assertFiles(imageURL, false)
+}
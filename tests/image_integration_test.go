@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/jpeg"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImageIntegrationTestSuite struct {
+	BaseTestSuite
+}
+
+func TestImageIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(ImageIntegrationTestSuite))
+}
+
+// createIdeaWithImage creates an idea with an uploaded 1600x400 JPEG and returns it.
+func (suite *ImageIntegrationTestSuite) createIdeaWithImage() dto.IdeaResponse {
+	user := suite.CreateUser("image-uploader", "222222222")
+	token := suite.RegisterUserAndGetToken(user)
+
+	coffeeShop := &models.CoffeeShop{Name: "Image Coffee Shop", Address: "1 Image St", CreatorID: &user.ID}
+	suite.Require().NoError(suite.DB.Create(coffeeShop).Error)
+	category := &models.Category{Title: "Image Category", CoffeeShopID: &coffeeShop.ID}
+	suite.Require().NoError(suite.DB.Create(category).Error)
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost,
+		path:   "/api/v1/ideas",
+		token:  token,
+		formData: map[string]string{
+			"title":          "Idea with Picture",
+			"description":    "This idea has a picture.",
+			"category_id":    category.ID.String(),
+			"coffee_shop_id": coffeeShop.ID.String(),
+		},
+		fileField:   "image",
+		fileContent: suite.JPEGImage(1600, 400),
+		fileName:    "photo.jpg",
+		contentType: "multipart/form-data",
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+
+	var resp dto.IdeaResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Require().NotNil(resp.ImageURL)
+	suite.Require().NotNil(resp.ImageMediumURL)
+	suite.Require().NotNil(resp.ImageThumbnailURL)
+	return resp
+}
+
+func (suite *ImageIntegrationTestSuite) TestGetImage() {
+	idea := suite.createIdeaWithImage()
+
+	tests := []struct {
+		name          string
+		imageURL      string
+		expectedWidth int
+	}{
+		{"Original", *idea.ImageURL, 1600},
+		{"Medium", *idea.ImageMediumURL, suite.cfg.Image.MediumSize},
+		{"Thumbnail", *idea.ImageThumbnailURL, suite.cfg.Image.ThumbnailSize},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/images/" + tt.imageURL})
+			suite.Require().Equal(http.StatusOK, w.Code)
+			suite.Equal("image/jpeg", w.Header().Get("Content-Type"))
+
+			decoded, err := jpeg.DecodeConfig(bytes.NewReader(w.Body.Bytes()))
+			suite.Require().NoError(err)
+			suite.Equal(tt.expectedWidth, decoded.Width)
+		})
+	}
+}
+
+func (suite *ImageIntegrationTestSuite) TestGetImageNotFound() {
+	for _, path := range []string{
+		"/api/v1/images/" + suite.cfg.ImageDB.BucketName + "/00000000-0000-0000-0000-000000000000/original.jpg",
+		"/api/v1/images/" + suite.cfg.ImageDB.BucketName + "/../main_test.go",
+		"/api/v1/images/other-bucket/original.jpg",
+	} {
+		suite.Run(path, func() {
+			w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: path})
+			suite.Equal(http.StatusNotFound, w.Code)
+		})
+	}
+}
+
+func (suite *ImageIntegrationTestSuite) TestConditionalRequests() {
+	idea := suite.createIdeaWithImage()
+	path := "/api/v1/images/" + *idea.ImageURL
+
+	w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: path})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	lastModified := w.Header().Get("Last-Modified")
+	suite.Regexp(`^"[^"]+"$`, etag, "a strong, quoted ETag")
+	suite.NotEmpty(lastModified)
+	suite.Equal("public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+	suite.Equal("bytes", w.Header().Get("Accept-Ranges"))
+
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{"Matching ETag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"Matching weak ETag in a list", map[string]string{"If-None-Match": `"other", W/` + etag}, http.StatusNotModified},
+		{"Wildcard", map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+		{"Other ETag", map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		{"Not modified since", map[string]string{"If-Modified-Since": lastModified}, http.StatusNotModified},
+		{"Modified since", map[string]string{"If-Modified-Since": "Mon, 02 Jan 2006 15:04:05 GMT"}, http.StatusOK},
+		{"If-None-Match wins over If-Modified-Since", map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: path, headers: tt.headers})
+			suite.Equal(tt.expectedStatus, w.Code)
+			suite.Equal(etag, w.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				suite.Empty(w.Body.Bytes())
+			} else {
+				suite.NotEmpty(w.Body.Bytes())
+			}
+		})
+	}
+
+	suite.Run("HEAD", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodHead, path: path})
+		suite.Equal(http.StatusOK, w.Code)
+		suite.Equal(etag, w.Header().Get("ETag"))
+		suite.NotEmpty(w.Header().Get("Content-Length"))
+		suite.Empty(w.Body.Bytes())
+	})
+}
+
+func (suite *ImageIntegrationTestSuite) TestRangeRequests() {
+	idea := suite.createIdeaWithImage()
+	path := "/api/v1/images/" + *idea.ImageURL
+
+	w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: path})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	full := w.Body.Bytes()
+	size := len(full)
+	etag := w.Header().Get("ETag")
+
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+		expectedBody   []byte
+		expectedRange  string
+	}{
+		{"First bytes", map[string]string{"Range": "bytes=0-9"}, http.StatusPartialContent, full[:10], fmt.Sprintf("bytes 0-9/%d", size)},
+		{"Open-ended", map[string]string{"Range": fmt.Sprintf("bytes=%d-", size-5)}, http.StatusPartialContent, full[size-5:], fmt.Sprintf("bytes %d-%d/%d", size-5, size-1, size)},
+		{"Suffix", map[string]string{"Range": "bytes=-4"}, http.StatusPartialContent, full[size-4:], fmt.Sprintf("bytes %d-%d/%d", size-4, size-1, size)},
+		{"End past the size", map[string]string{"Range": fmt.Sprintf("bytes=10-%d", size+100)}, http.StatusPartialContent, full[10:], fmt.Sprintf("bytes 10-%d/%d", size-1, size)},
+		{"Matching If-Range", map[string]string{"Range": "bytes=0-9", "If-Range": etag}, http.StatusPartialContent, full[:10], fmt.Sprintf("bytes 0-9/%d", size)},
+		{"Stale If-Range", map[string]string{"Range": "bytes=0-9", "If-Range": `"other"`}, http.StatusOK, full, ""},
+		{"Several ranges", map[string]string{"Range": "bytes=0-1,5-6"}, http.StatusOK, full, ""},
+		{"Not satisfiable", map[string]string{"Range": fmt.Sprintf("bytes=%d-", size)}, http.StatusRequestedRangeNotSatisfiable, nil, fmt.Sprintf("bytes */%d", size)},
+		{"Malformed", map[string]string{"Range": "bytes=9-1"}, http.StatusRequestedRangeNotSatisfiable, nil, fmt.Sprintf("bytes */%d", size)},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: path, headers: tt.headers})
+			suite.Require().Equal(tt.expectedStatus, w.Code)
+			suite.Equal(tt.expectedRange, w.Header().Get("Content-Range"))
+			if tt.expectedBody != nil {
+				suite.Equal(tt.expectedBody, w.Body.Bytes())
+				suite.Equal(strconv.Itoa(len(tt.expectedBody)), w.Header().Get("Content-Length"))
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/imageproc"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImageProcTestSuite struct {
+	suite.Suite
+	cfg config.ImageConfig
+}
+
+func TestImageProcTestSuite(t *testing.T) {
+	suite.Run(t, new(ImageProcTestSuite))
+}
+
+func (suite *ImageProcTestSuite) SetupTest() {
+	suite.cfg = config.ImageConfig{MaxBytes: 1 << 20, MaxDimension: 3000, ThumbnailSize: 32, MediumSize: 128, JPEGQuality: 85}
+}
+
+// halves returns an opaque image whose left half is red and right half is blue.
+func (suite *ImageProcTestSuite) halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func (suite *ImageProcTestSuite) encodeJPEG(img image.Image) []byte {
+	var buf bytes.Buffer
+	suite.Require().NoError(jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// withExif inserts an APP1 segment with the orientation tag and a GPS latitude reference,
+// as written by phone cameras, right after the start of the JPEG.
+func (suite *ImageProcTestSuite) withExif(data []byte, orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("II")
+	binary.Write(&tiff, binary.LittleEndian, uint16(42))
+	binary.Write(&tiff, binary.LittleEndian, uint32(8))
+	binary.Write(&tiff, binary.LittleEndian, uint16(2))
+	for _, entry := range [][4]uint32{
+		{0x0112, 3, 1, uint32(orientation)}, // Orientation, SHORT
+		{0x0001, 2, 2, 'N'},                 // GPSLatitudeRef, ASCII
+	} {
+		binary.Write(&tiff, binary.LittleEndian, uint16(entry[0]))
+		binary.Write(&tiff, binary.LittleEndian, uint16(entry[1]))
+		binary.Write(&tiff, binary.LittleEndian, entry[2])
+		binary.Write(&tiff, binary.LittleEndian, entry[3])
+	}
+	binary.Write(&tiff, binary.LittleEndian, uint32(0))
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func (suite *ImageProcTestSuite) variant(result *imageproc.Result, name string) imageproc.Variant {
+	for _, v := range result.Variants {
+		if v.Name == name {
+			return v
+		}
+	}
+	suite.FailNow("variant not found", name)
+	return imageproc.Variant{}
+}
+
+func (suite *ImageProcTestSuite) TestStripsMetadataAndAppliesOrientation() {
+	photo := suite.withExif(suite.encodeJPEG(suite.halves(64, 32)), 6)
+	suite.Require().Contains(string(photo), "Exif")
+
+	result, err := imageproc.Process(bytes.NewReader(photo), &suite.cfg)
+	suite.Require().NoError(err)
+	suite.Equal("image/jpeg", result.ContentType)
+	suite.Equal(".jpg", result.Ext)
+
+	original := suite.variant(result, imageproc.Original)
+	suite.NotContains(string(original.Data), "Exif", "metadata is removed")
+	suite.Equal([2]int{32, 64}, [2]int{original.Width, original.Height}, "rotated by 90 degrees")
+
+	decoded, err := jpeg.Decode(bytes.NewReader(original.Data))
+	suite.Require().NoError(err)
+	top, _, _, _ := decoded.At(16, 8).RGBA()
+	bottom, _, _, _ := decoded.At(16, 56).RGBA()
+	suite.Greater(top>>8, uint32(200), "the left half is on top after a clockwise rotation")
+	suite.Less(bottom>>8, uint32(60))
+}
+
+func (suite *ImageProcTestSuite) TestVariants() {
+	suite.Run("Large image is scaled down", func() {
+		result, err := imageproc.Process(bytes.NewReader(suite.encodeJPEG(suite.halves(400, 200))), &suite.cfg)
+		suite.Require().NoError(err)
+		suite.Require().Len(result.Variants, 3)
+		suite.Equal(imageproc.Original, result.Variants[0].Name)
+
+		medium := suite.variant(result, imageproc.Medium)
+		suite.Equal([2]int{128, 64}, [2]int{medium.Width, medium.Height})
+		thumbnail := suite.variant(result, imageproc.Thumbnail)
+		suite.Equal([2]int{32, 16}, [2]int{thumbnail.Width, thumbnail.Height})
+		decoded, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail.Data))
+		suite.Require().NoError(err)
+		suite.Equal(32, decoded.Width)
+	})
+
+	suite.Run("Small image is not upscaled", func() {
+		result, err := imageproc.Process(bytes.NewReader(suite.encodeJPEG(suite.halves(20, 10))), &suite.cfg)
+		suite.Require().NoError(err)
+		for _, v := range result.Variants {
+			suite.Equal([2]int{20, 10}, [2]int{v.Width, v.Height}, v.Name)
+		}
+	})
+
+	suite.Run("PNG with transparency stays PNG", func() {
+		img := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+		img.Set(5, 5, color.NRGBA{G: 255, A: 128})
+		var buf bytes.Buffer
+		suite.Require().NoError(png.Encode(&buf, img))
+
+		result, err := imageproc.Process(&buf, &suite.cfg)
+		suite.Require().NoError(err)
+		suite.Equal("image/png", result.ContentType)
+		suite.Equal(".png", result.Ext)
+		_, err = png.DecodeConfig(bytes.NewReader(suite.variant(result, imageproc.Thumbnail).Data))
+		suite.NoError(err)
+	})
+}
+
+func (suite *ImageProcTestSuite) TestRejectsInvalidFiles() {
+	photo := suite.encodeJPEG(suite.halves(64, 32))
+
+	tests := []struct {
+		name   string
+		data   []byte
+		mutate func(cfg *config.ImageConfig)
+		err    error
+	}{
+		{"Text renamed to .jpg", []byte("definitely not an image"), nil, imageproc.ErrUnsupportedFormat},
+		{"HTML", []byte("<html><script>alert(1)</script></html>"), nil, imageproc.ErrUnsupportedFormat},
+		{"File too large", photo, func(cfg *config.ImageConfig) { cfg.MaxBytes = int64(len(photo) - 1) }, imageproc.ErrTooLarge},
+		{"Too many pixels", photo, func(cfg *config.ImageConfig) { cfg.MaxDimension = 50 }, imageproc.ErrTooManyPixels},
+		{"Truncated file", photo[:len(photo)/2], nil, imageproc.ErrCorrupt},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			cfg := suite.cfg
+			if tt.mutate != nil {
+				tt.mutate(&cfg)
+			}
+			_, err := imageproc.Process(bytes.NewReader(tt.data), &cfg)
+			suite.ErrorIs(err, tt.err)
+			suite.ErrorIs(err, imageproc.ErrInvalidImage)
+		})
+	}
+}
+
+func (suite *ImageProcTestSuite) TestImageVariantURL() {
+	original := "images/0b6a4c1e-8f0d-4c39-9a57-3f2f2d1c9e10/original.jpg"
+	suite.Equal("images/0b6a4c1e-8f0d-4c39-9a57-3f2f2d1c9e10/thumbnail.jpg", *usecase.ImageVariantURL(&original, imageproc.Thumbnail))
+
+	legacy := "images/0b6a4c1e-8f0d-4c39-9a57-3f2f2d1c9e10.jpg"
+	suite.Nil(usecase.ImageVariantURL(&legacy, imageproc.Thumbnail), "images uploaded before variants have none")
+	suite.Nil(usecase.ImageVariantURL(nil, imageproc.Medium))
+}
@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/jwtkeys"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type JWTKeysTestSuite struct {
+	suite.Suite
+	rsaKey *rsa.PrivateKey
+	edKey  ed25519.PrivateKey
+}
+
+func TestJWTKeysTestSuite(t *testing.T) {
+	suite.Run(t, new(JWTKeysTestSuite))
+}
+
+func (suite *JWTKeysTestSuite) SetupSuite() {
+	var err error
+	suite.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	suite.Require().NoError(err)
+	_, suite.edKey, err = ed25519.GenerateKey(nil)
+	suite.Require().NoError(err)
+}
+
+func (suite *JWTKeysTestSuite) claims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{Subject: "user", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}
+}
+
+func (suite *JWTKeysTestSuite) writePEM(dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	suite.Require().NoError(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+func (suite *JWTKeysTestSuite) TestSignAndParse() {
+	for alg, key := range map[string]crypto.Signer{"RS256": suite.rsaKey, "EdDSA": suite.edKey} {
+		suite.Run(alg, func() {
+			keys, err := jwtkeys.New(key)
+			suite.Require().NoError(err)
+			token, err := keys.Sign(suite.claims())
+			suite.Require().NoError(err)
+
+			var claims jwt.RegisteredClaims
+			parsed, err := keys.Parse(token, &claims)
+			suite.Require().NoError(err)
+			suite.Equal(alg, parsed.Method.Alg())
+			suite.Equal(keys.SigningKeyID(), parsed.Header["kid"])
+			suite.Equal("user", claims.Subject)
+
+			jwks := keys.PublicKeys()
+			suite.Require().Len(jwks, 1)
+			suite.Equal(keys.SigningKeyID(), jwks[0].Kid)
+			suite.Equal(alg, jwks[0].Alg)
+			suite.Equal("sig", jwks[0].Use)
+		})
+	}
+}
+
+func (suite *JWTKeysTestSuite) TestRotation() {
+	previous, err := jwtkeys.New(suite.rsaKey)
+	suite.Require().NoError(err)
+	oldToken, err := previous.Sign(suite.claims())
+	suite.Require().NoError(err)
+
+	rotated, err := jwtkeys.New(suite.edKey, suite.rsaKey.Public())
+	suite.Require().NoError(err)
+	suite.NotEqual(previous.SigningKeyID(), rotated.SigningKeyID())
+	suite.Len(rotated.PublicKeys(), 2)
+	suite.Equal(rotated.SigningKeyID(), rotated.PublicKeys()[0].Kid, "the signing key is published first")
+
+	suite.Run("Tokens of the previous key stay valid", func() {
+		_, err := rotated.Parse(oldToken, &jwt.RegisteredClaims{})
+		suite.NoError(err)
+	})
+
+	suite.Run("Fail - Previous key removed", func() {
+		current, err := jwtkeys.New(suite.edKey)
+		suite.Require().NoError(err)
+		_, err = current.Parse(oldToken, &jwt.RegisteredClaims{})
+		suite.Error(err)
+	})
+}
+
+func (suite *JWTKeysTestSuite) TestRejectsForgedTokens() {
+	keys, err := jwtkeys.New(suite.rsaKey)
+	suite.Require().NoError(err)
+
+	suite.Run("Fail - HMAC signed with the public key", func() {
+		publicDER, err := x509.MarshalPKIXPublicKey(suite.rsaKey.Public())
+		suite.Require().NoError(err)
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, suite.claims())
+		token.Header["kid"] = keys.SigningKeyID()
+		forged, err := token.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+		suite.Require().NoError(err)
+		_, err = keys.Parse(forged, &jwt.RegisteredClaims{})
+		suite.Error(err)
+	})
+
+	suite.Run("Fail - Unsigned token", func() {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, suite.claims())
+		token.Header["kid"] = keys.SigningKeyID()
+		forged, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		suite.Require().NoError(err)
+		_, err = keys.Parse(forged, &jwt.RegisteredClaims{})
+		suite.Error(err)
+	})
+
+	suite.Run("Fail - Unknown key", func() {
+		other, err := jwtkeys.New(suite.edKey)
+		suite.Require().NoError(err)
+		token, err := other.Sign(suite.claims())
+		suite.Require().NoError(err)
+		_, err = keys.Parse(token, &jwt.RegisteredClaims{})
+		suite.Error(err)
+	})
+
+	suite.Run("Fail - Short RSA key", func() {
+		short, err := rsa.GenerateKey(rand.Reader, 1024)
+		suite.Require().NoError(err)
+		_, err = jwtkeys.New(short)
+		suite.Error(err)
+	})
+}
+
+func (suite *JWTKeysTestSuite) TestLoad() {
+	dir := suite.T().TempDir()
+	edDER, err := x509.MarshalPKCS8PrivateKey(suite.edKey)
+	suite.Require().NoError(err)
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(suite.rsaKey.Public())
+	suite.Require().NoError(err)
+
+	suite.Run("Signing and verification key files", func() {
+		keys, err := jwtkeys.Load(&config.JWTConfig{
+			SigningKeyFile:       suite.writePEM(dir, "current.pem", "PRIVATE KEY", edDER),
+			VerificationKeyFiles: []string{suite.writePEM(dir, "previous.pub", "PUBLIC KEY", rsaPublicDER)},
+		})
+		suite.Require().NoError(err)
+		suite.Len(keys.PublicKeys(), 2)
+
+		expected, err := jwtkeys.New(suite.edKey)
+		suite.Require().NoError(err)
+		suite.Equal(expected.SigningKeyID(), keys.SigningKeyID(), "key ids do not depend on how the key was loaded")
+	})
+
+	suite.Run("PKCS1 RSA signing key", func() {
+		keys, err := jwtkeys.Load(&config.JWTConfig{
+			SigningKeyFile: suite.writePEM(dir, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(suite.rsaKey)),
+		})
+		suite.Require().NoError(err)
+		suite.Equal("RS256", keys.PublicKeys()[0].Alg)
+	})
+
+	suite.Run("Shared secret without key files", func() {
+		keys, err := jwtkeys.Load(&config.JWTConfig{Secret: "local-secret"})
+		suite.Require().NoError(err)
+		suite.Empty(keys.PublicKeys())
+		token, err := keys.Sign(suite.claims())
+		suite.Require().NoError(err)
+		_, err = keys.Parse(token, &jwt.RegisteredClaims{})
+		suite.NoError(err)
+	})
+
+	suite.Run("Fail - Missing file", func() {
+		_, err := jwtkeys.Load(&config.JWTConfig{SigningKeyFile: filepath.Join(dir, "missing.pem")})
+		suite.Error(err)
+	})
+}
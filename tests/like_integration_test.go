@@ -8,6 +8,7 @@ import (
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -36,7 +37,7 @@ func (suite *LikeIntegrationTestSuite) createLikePrerequisites() (string, *model
 	coffeeShop := &models.CoffeeShop{
 		Name:      "Test Coffee Shop for Likes",
 		Address:   "123 Like St",
-		CreatorID: user.ID,
+		CreatorID: &user.ID,
 	}
 	err := suite.DB.Create(coffeeShop).Error
 	suite.Require().NoError(err)
@@ -147,4 +148,67 @@ func (suite *LikeIntegrationTestSuite) TestGetIdeaWithLikes() {
 	err = json.Unmarshal(w.Body.Bytes(), &resp)
 	suite.NoError(err)
 	suite.Equal(1, resp.Likes)
+	suite.True(resp.LikedByMe)
+
+	suite.Run("Anonymous viewer", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/ideas/%s", idea.ID)})
+		suite.Require().Equal(http.StatusOK, w.Code)
+
+		var resp dto.IdeaResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal(1, resp.Likes)
+		suite.False(resp.LikedByMe)
+	})
+}
+
+func (suite *LikeIntegrationTestSuite) TestListIdeasWithLikes() {
+	token, user, idea := suite.createLikePrerequisites()
+
+	popular := &models.Idea{
+		Title:        "Popular idea",
+		Description:  "Liked by everyone.",
+		CreatorID:    &user.ID,
+		CoffeeShopID: idea.CoffeeShopID,
+		CategoryID:   idea.CategoryID,
+		StatusID:     idea.StatusID,
+	}
+	suite.Require().NoError(suite.DB.Create(popular).Error)
+
+	other := suite.CreateUser("like-other", "333333333")
+	suite.Require().NoError(suite.DB.Create(&models.IdeaLike{UserID: &user.ID, IdeaID: &popular.ID}).Error)
+	suite.Require().NoError(suite.DB.Create(&models.IdeaLike{UserID: &other.ID, IdeaID: &popular.ID}).Error)
+	suite.Require().NoError(suite.DB.Create(&models.IdeaLike{UserID: &other.ID, IdeaID: &idea.ID}).Error)
+
+	listIdeas := func(path, token string) []dto.IdeaResponse {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: path, token: token})
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+		var resp pagination.Page[dto.IdeaResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Items
+	}
+
+	suite.Run("Sort by likes with liked_by_me", func() {
+		resp := listIdeas(fmt.Sprintf("/api/v1/coffee-shops/%s/ideas?sort=-likes", idea.CoffeeShopID), token)
+		suite.Require().Len(resp, 2)
+		suite.Equal(popular.ID, resp[0].ID)
+		suite.Equal(2, resp[0].Likes)
+		suite.True(resp[0].LikedByMe)
+		suite.Equal(idea.ID, resp[1].ID)
+		suite.Equal(1, resp[1].Likes)
+		suite.False(resp[1].LikedByMe)
+	})
+
+	suite.Run("Ascending likes", func() {
+		resp := listIdeas(fmt.Sprintf("/api/v1/coffee-shops/%s/ideas?sort=likes", idea.CoffeeShopID), token)
+		suite.Require().Len(resp, 2)
+		suite.Equal(idea.ID, resp[0].ID)
+	})
+
+	suite.Run("Anonymous search", func() {
+		resp := listIdeas(fmt.Sprintf("/api/v1/coffee-shops/%s/ideas/search?sort=-likes", idea.CoffeeShopID), "")
+		suite.Require().Len(resp, 2)
+		suite.Equal(popular.ID, resp[0].ID)
+		suite.False(resp[0].LikedByMe)
+	})
 }
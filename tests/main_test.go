@@ -3,8 +3,11 @@ package tests
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
+	"image"
+	"image/jpeg"
 	"log/slog"
 	"math/rand"
 	"net/http"
@@ -14,42 +17,23 @@ import (
 	"time"
 
 	"github.com/GeorgiiMalishev/ideas-platform/config"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/db"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/handlers"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/jwtkeys"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/otpsender"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/repository"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/router"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/minio/minio-go/v7"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
-// MockImageUsecase is a mock implementation of ImageUsecase for testing.
-type MockImageUsecase struct{}
-
-func (m *MockImageUsecase) UploadImage(ctx context.Context, file *multipart.FileHeader) (string, error) {
-	// Simulate a successful upload by returning a predictable URL
-	return fmt.Sprintf("http://mock-minio/testbucket/%s", file.Filename), nil
-}
-
-func (m *MockImageUsecase) CreateBucket(ctx context.Context) error {
-	// Simulate successful bucket creation
-	return nil
-}
-
-func (m *MockImageUsecase) GetImage(ctx context.Context, objectName string) (*minio.Object, minio.ObjectInfo, error) {
-	// Mock implementation.
-	// Note: Returning nil for *minio.Object will cause a panic if the handler tries to use it.
-	// Since we are not testing the image download endpoint in this suite, this is acceptable for now.
-	// Ideally, we should mock the MinIO client or use a real MinIO instance for integration tests.
-	return nil, minio.ObjectInfo{}, fmt.Errorf("GetImage not implemented in mock")
-}
-
 // BaseTestSuite is a base suite for integration tests
 type BaseTestSuite struct {
 	suite.Suite
@@ -67,9 +51,27 @@ type BaseTestSuite struct {
 	CategoryRepo         repository.CategoryRepository
 	CommentRepo          repository.CommentRepository
 	IdeaStatusRepo       repository.IdeaStatusRepository // Added IdeaStatusRepo
+	BannedUserRepo       repository.BannedUserRepository
+	InvitationRepo       repository.WorkerInvitationRepository
+	OwnershipRepo        repository.OwnershipTransferRepository
+	OrganizationRepo     repository.OrganizationRepository
+	ImageUploadRepo      repository.ImageUploadRepository
+	StoredImageRepo      repository.StoredImageRepository
 	ImageUsecase         usecase.ImageUsecase
+	// Store keeps uploaded images in a temporary directory of the suite.
+	Store blobstore.BlobStore
+	OTPSender            *otpsender.MemorySender
+	JWTKeys              *jwtkeys.KeySet
+	HealthHandler        *handlers.HealthHandler
+	// StorageErr makes the storage readiness check fail when set.
+	StorageErr error
+	PreviousJWTKey       ed25519.PrivateKey
+	RoleRepo             repository.RoleRepository
 	UserRoleID           uuid.UUID
 	AdminRoleID          uuid.UUID
+	OwnerRoleID          uuid.UUID
+	ModeratorRoleID      uuid.UUID
+	BaristaRoleID        uuid.UUID
 	Ctx                  context.Context
 }
 
@@ -78,12 +80,14 @@ type TestRequest struct {
 	method      string
 	path        string
 	body        interface{} // For JSON bodies
+	rawBody     []byte      // For bodies sent as is, such as uploaded files
 	formData    map[string]string // For form data fields
 	fileField   string          // The field name for the file (e.g., "image")
 	fileContent []byte          // The content of the file
 	fileName    string          // The file name
 	contentType string
 	token       string
+	headers     map[string]string
 }
 
 // SetupSuite sets up the test suite
@@ -101,11 +105,12 @@ func (suite *BaseTestSuite) SetupSuite() {
 	suite.cfg.DB.User = "postgres"
 	suite.cfg.DB.Password = "postgres"
 
-	// Configure ImageDB for tests
-	suite.cfg.ImageDB.AccessKeyID = "minioadmin"
-	suite.cfg.ImageDB.SecretAccessKey = "minioadmin"
-	suite.cfg.ImageDB.BucketName = "test-bucket" // A dedicated bucket for tests
-	suite.cfg.ImageDB.Endpoint = "localhost:9000" // Assuming MinIO is accessible for tests
+	// Images are kept in a temporary directory, so tests do not need MinIO
+	suite.cfg.Storage.Driver = config.StorageLocal
+	suite.cfg.Storage.LocalDir = suite.T().TempDir()
+	suite.cfg.Storage.LocalPublicURL = "http://localhost:8080/api/v1/storage"
+	suite.cfg.Storage.LocalSigningSecret = "test-storage-signing-secret"
+	suite.cfg.ImageDB.BucketName = "test-bucket" // Prefix of image URLs
 
 	// Configure App version for tests
 	suite.cfg.App.Version = "test"
@@ -119,17 +124,9 @@ func (suite *BaseTestSuite) SetupSuite() {
 	}
 	suite.DB = database
 
-	// Using AutoMigrate for tests to ensure schema is up-to-date
-	err = suite.DB.AutoMigrate(
-		&models.User{}, &models.BannedUser{}, &models.Role{},
-		&models.CoffeeShop{}, &models.WorkerCoffeeShop{}, &models.Category{},
-		&models.Idea{}, &models.IdeaLike{}, &models.IdeaComment{},
-		&models.Reward{}, &models.RewardType{}, &models.OTP{},
-		&models.UserRefreshToken{},
-		&models.IdeaStatus{}, // Added IdeaStatus
-	)
-	if err != nil {
-		suite.T().Fatalf("failed to auto-migrate database: %v", err)
+	// The schema is built by the same migrations as in production
+	if err := db.RunMigrations(suite.cfg); err != nil {
+		suite.T().Fatalf("failed to migrate database: %v", err)
 	}
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
@@ -146,6 +143,18 @@ func (suite *BaseTestSuite) SetupSuite() {
 	suite.DB.FirstOrCreate(&userRole, "name = ?", "user")
 	suite.UserRoleID = userRole.ID
 
+	ownerRole := models.Role{Name: models.RoleOwner}
+	suite.DB.FirstOrCreate(&ownerRole, "name = ?", models.RoleOwner)
+	suite.OwnerRoleID = ownerRole.ID
+
+	moderatorRole := models.Role{Name: models.RoleModerator}
+	suite.DB.FirstOrCreate(&moderatorRole, "name = ?", models.RoleModerator)
+	suite.ModeratorRoleID = moderatorRole.ID
+
+	baristaRole := models.Role{Name: models.RoleBarista}
+	suite.DB.FirstOrCreate(&baristaRole, "name = ?", models.RoleBarista)
+	suite.BaristaRoleID = baristaRole.ID
+
 	// Repositories
 	suite.AuthRepo = repository.NewAuthRepository(suite.DB)
 	suite.UserRepo = repository.NewUserRepository(suite.DB)
@@ -158,21 +167,42 @@ func (suite *BaseTestSuite) SetupSuite() {
 	suite.CategoryRepo = repository.NewCategoryRepository(suite.DB)
 	suite.CommentRepo = repository.NewCommentRepository(suite.DB)
 	suite.IdeaStatusRepo = repository.NewIdeaStatusRepository(suite.DB) // Added IdeaStatusRepo
+	suite.BannedUserRepo = repository.NewBannedUserRepository(suite.DB)
+	suite.RoleRepo = repository.NewRoleRepository(suite.DB)
+	suite.InvitationRepo = repository.NewWorkerInvitationRepository(suite.DB)
+	suite.OwnershipRepo = repository.NewOwnershipTransferRepository(suite.DB)
+	suite.OrganizationRepo = repository.NewOrganizationRepository(suite.DB)
 
 	// Usecases
-	suite.ImageUsecase = &MockImageUsecase{} // Initialize mock
-	authUsecase := usecase.NewAuthUsecase(suite.AuthRepo, suite.CoffeeShopRepo, suite.WorkerCoffeeShopRepo, suite.DB, "test-secret", &suite.cfg.AuthConfig, logger)
-	userUsecase := usecase.NewUserUsecase(suite.UserRepo, suite.WorkerCoffeeShopRepo, logger)
-	csUscase := usecase.NewCoffeeShopUsecase(suite.CoffeeShopRepo, suite.WorkerCoffeeShopRepo, suite.AdminRoleID, logger)
-	ideaStatusUsecase := usecase.NewIdeaStatusUsecase(suite.IdeaStatusRepo, logger) // Added IdeaStatusUsecase
-	ideaUsecase := usecase.NewIdeaUsecase(suite.IdeaRepo, suite.WorkerCoffeeShopRepo, suite.LikeRepo, suite.IdeaStatusRepo, logger) // Updated NewIdeaUsecase
-	rewardUsecase := usecase.NewRewardUsecase(suite.RewardRepo, suite.IdeaRepo, logger)
-	rewardTypeUsecase := usecase.NewRewardTypeUsecase(suite.RewardTypeRepo, suite.CoffeeShopRepo, suite.WorkerCoffeeShopRepo, logger)
-	workerCoffeeShopUsecase := usecase.NewWorkerCoffeeShopUsecase(suite.WorkerCoffeeShopRepo, suite.CoffeeShopRepo, suite.UserRepo, logger)
-	likeUsecase := usecase.NewLikeUsecase(suite.LikeRepo, logger)
-	accessControlUsecase := usecase.NewAccessControlUsecase(suite.WorkerCoffeeShopRepo, logger)
+	localStore := blobstore.NewLocal(suite.cfg.Storage.LocalDir, suite.cfg.Storage.LocalPublicURL, []byte(suite.cfg.Storage.LocalSigningSecret))
+	suite.Store = localStore
+	suite.ImageUploadRepo = repository.NewImageUploadRepository(suite.DB)
+	suite.StoredImageRepo = repository.NewStoredImageRepository(suite.DB)
+	suite.ImageUsecase = usecase.NewImageUsecase(suite.Store, suite.ImageUploadRepo, suite.StoredImageRepo, suite.cfg.ImageDB.BucketName, &suite.cfg.Image, logger)
+	suite.OTPSender = otpsender.NewMemorySender()
+	// Tokens are signed with a fresh key while a previous key stays accepted, as after a key rotation.
+	_, signingKey, err := ed25519.GenerateKey(nil)
+	suite.Require().NoError(err)
+	_, suite.PreviousJWTKey, err = ed25519.GenerateKey(nil)
+	suite.Require().NoError(err)
+	suite.JWTKeys, err = jwtkeys.New(signingKey, suite.PreviousJWTKey.Public())
+	suite.Require().NoError(err)
+	authUsecase := usecase.NewAuthUsecase(suite.AuthRepo, suite.CoffeeShopRepo, suite.WorkerCoffeeShopRepo, suite.DB, suite.JWTKeys, &suite.cfg.AuthConfig, otpsender.WithRetry(suite.OTPSender, 2, 0, logger), logger)
+	accessControlUsecase := usecase.NewAccessControlUsecase(suite.WorkerCoffeeShopRepo, suite.OrganizationRepo, logger)
+	userUsecase := usecase.NewUserUsecase(suite.UserRepo, suite.WorkerCoffeeShopRepo, accessControlUsecase, logger)
+	csUscase := usecase.NewCoffeeShopUsecase(suite.CoffeeShopRepo, suite.WorkerCoffeeShopRepo, accessControlUsecase, suite.OwnerRoleID, logger)
+	ideaStatusUsecase := usecase.NewIdeaStatusUsecase(suite.IdeaStatusRepo, accessControlUsecase, logger) // Added IdeaStatusUsecase
+	ideaUsecase := usecase.NewIdeaUsecase(suite.IdeaRepo, accessControlUsecase, suite.LikeRepo, suite.IdeaStatusRepo, suite.BannedUserRepo, suite.ImageUsecase, logger) // Updated NewIdeaUsecase
+	rewardUsecase := usecase.NewRewardUsecase(suite.RewardRepo, suite.RewardTypeRepo, suite.IdeaRepo, accessControlUsecase, logger)
+	rewardTypeUsecase := usecase.NewRewardTypeUsecase(suite.RewardTypeRepo, suite.CoffeeShopRepo, accessControlUsecase, logger)
+	workerCoffeeShopUsecase := usecase.NewWorkerCoffeeShopUsecase(suite.WorkerCoffeeShopRepo, suite.CoffeeShopRepo, suite.UserRepo, suite.RoleRepo, accessControlUsecase, logger)
+	likeUsecase := usecase.NewLikeUsecase(suite.LikeRepo, suite.IdeaRepo, suite.BannedUserRepo, logger)
 	categoryUsecase := usecase.NewCategoryUsecase(suite.CategoryRepo, accessControlUsecase)
-	commentUsecase := usecase.NewCommentUsecase(suite.CommentRepo, suite.IdeaRepo, suite.WorkerCoffeeShopRepo, logger)
+	commentUsecase := usecase.NewCommentUsecase(suite.CommentRepo, suite.IdeaRepo, accessControlUsecase, suite.BannedUserRepo, logger)
+	invitationUsecase := usecase.NewWorkerInvitationUsecase(suite.InvitationRepo, suite.WorkerCoffeeShopRepo, suite.UserRepo, suite.RoleRepo, accessControlUsecase, &suite.cfg.Invitation, logger)
+	ownershipUsecase := usecase.NewOwnershipTransferUsecase(suite.OwnershipRepo, suite.WorkerCoffeeShopRepo, accessControlUsecase, logger)
+	organizationUsecase := usecase.NewOrganizationUsecase(suite.OrganizationRepo, suite.UserRepo, suite.RoleRepo, accessControlUsecase, suite.OwnerRoleID, logger)
+	banUsecase := usecase.NewBanUsecase(suite.BannedUserRepo, suite.WorkerCoffeeShopRepo, suite.UserRepo, accessControlUsecase, logger)
 
 	// Handlers
 	authHandler := handlers.NewAuthHandler(authUsecase, logger)
@@ -186,10 +216,24 @@ func (suite *BaseTestSuite) SetupSuite() {
 	categoryHandler := handlers.NewCategoryHandler(categoryUsecase, logger)
 	commentHandler := handlers.NewCommentHandler(commentUsecase, logger)
 	ideaStatusHandler := handlers.NewIdeaStatusHandler(ideaStatusUsecase, logger) // Added IdeaStatusHandler
-	imageHandler := handlers.NewImageHandler(suite.ImageUsecase, suite.cfg, logger)
+	imageHandler := handlers.NewImageHandler(suite.ImageUsecase, logger)
+	storageHandler := handlers.NewStorageHandler(localStore, logger)
+	banHandler := handlers.NewBanHandler(banUsecase, logger)
+	invitationHandler := handlers.NewWorkerInvitationHandler(invitationUsecase, logger)
+	ownershipHandler := handlers.NewOwnershipTransferHandler(ownershipUsecase, logger)
+	organizationHandler := handlers.NewOrganizationHandler(organizationUsecase, logger)
+	suite.HealthHandler = handlers.NewHealthHandler(logger,
+		handlers.ReadinessCheck{Name: "postgres", Check: func(ctx context.Context) error { return db.Ping(ctx, suite.DB) }},
+		handlers.ReadinessCheck{Name: "storage", Check: func(ctx context.Context) error {
+			if suite.StorageErr != nil {
+				return suite.StorageErr
+			}
+			return suite.Store.Ping(ctx)
+		}},
+	)
 
 	// Router
-	appRouter := router.NewRouter(suite.cfg, userHandler, csHandler, authHandler, ideaHandler, rewardHandler, rewardTypeHandler, workerCoffeeShopHandler, likeHandler, categoryHandler, commentHandler, ideaStatusHandler, suite.WorkerCoffeeShopRepo, imageHandler, authUsecase, logger)
+	appRouter := router.NewRouter(suite.cfg, userHandler, csHandler, authHandler, ideaHandler, rewardHandler, rewardTypeHandler, workerCoffeeShopHandler, likeHandler, categoryHandler, commentHandler, ideaStatusHandler, suite.WorkerCoffeeShopRepo, imageHandler, storageHandler, banHandler, invitationHandler, ownershipHandler, organizationHandler, suite.HealthHandler, authUsecase, logger)
 	suite.Router = appRouter.SetupRouter()
 }
 
@@ -206,15 +250,27 @@ func (suite *BaseTestSuite) TearDownSuite() {
 // TearDownTest cleans up the database after each test
 func (suite *BaseTestSuite) TearDownTest() {
 	// The order is important to avoid foreign key violations
+	suite.DB.Exec("DELETE FROM rotated_refresh_token")
 	suite.DB.Exec("DELETE FROM user_refresh_tokens")
+	suite.DB.Exec("DELETE FROM user_session")
+	suite.DB.Exec("DELETE FROM image_upload")
+	suite.DB.Exec("DELETE FROM stored_image")
 	suite.DB.Exec("DELETE FROM idea_like")
 	suite.DB.Exec("DELETE FROM idea_comment")
+	suite.DB.Exec("DELETE FROM idea_status_history")
+	suite.DB.Exec("DELETE FROM banned_user")
 	suite.DB.Exec("DELETE FROM reward")
 	suite.DB.Exec("DELETE FROM idea")
 	suite.DB.Exec("DELETE FROM reward_type")
 	suite.DB.Exec("DELETE FROM category")
+	suite.DB.Exec("DELETE FROM status_transition")
+	suite.DB.Exec("DELETE FROM worker_invitation")
+	suite.DB.Exec("DELETE FROM ownership_transfer")
 	suite.DB.Exec("DELETE FROM worker_coffee_shop")
+	suite.DB.Exec("DELETE FROM coffee_shop_opening_hours")
 	suite.DB.Exec("DELETE FROM coffee_shop")
+	suite.DB.Exec("DELETE FROM organization_member")
+	suite.DB.Exec("DELETE FROM organization")
 	suite.DB.Exec("DELETE FROM otps")
 	suite.DB.Exec("DELETE FROM users")
 	suite.DB.Exec("DELETE FROM status") // Added DELETE status
@@ -252,7 +308,9 @@ func (suite *BaseTestSuite) MakeRequest(req TestRequest) *httptest.ResponseRecor
 
 	} else {
 		var bodyReader *bytes.Buffer
-		if req.body != nil {
+		if req.rawBody != nil {
+			bodyReader = bytes.NewBuffer(req.rawBody)
+		} else if req.body != nil {
 			bodyBytes, err := json.Marshal(req.body)
 			suite.Require().NoError(err)
 			bodyReader = bytes.NewBuffer(bodyBytes)
@@ -269,6 +327,9 @@ func (suite *BaseTestSuite) MakeRequest(req TestRequest) *httptest.ResponseRecor
 	if req.token != "" {
 		httpReq.Header.Set("Authorization", "Bearer "+req.token)
 	}
+	for key, value := range req.headers {
+		httpReq.Header.Set(key, value)
+	}
 
 	suite.Router.ServeHTTP(w, httpReq)
 	return w
@@ -353,6 +414,17 @@ func (suite *BaseTestSuite) RegisterUserAndGetToken(user *models.User) string {
 	return suite.GetAuthToken(*user.Phone, otpCode, *user.Name)
 }
 
+// JPEGImage returns a gray JPEG image of the given size.
+func (suite *BaseTestSuite) JPEGImage(width, height int) []byte {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 128
+	}
+	var buf bytes.Buffer
+	suite.Require().NoError(jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
 // CreateUser is a helper to create a user
 func (suite *BaseTestSuite) CreateUser(name, phone string) *models.User {
 	user := &models.User{
@@ -389,7 +461,7 @@ func (suite *BaseTestSuite) CreateTestUser(userName, userPhone, coffeeShopName,
 	// 2. Create the coffee shop, owned by the user
 	cs := &models.CoffeeShop{
 		ID:        uuid.New(),
-		CreatorID: user.ID,
+		CreatorID: &user.ID,
 		Name:      coffeeShopName,
 		Address:   coffeeShopAddress,
 		CreatedAt: time.Now(),
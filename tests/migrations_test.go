@@ -0,0 +1,376 @@
+package tests
+
+import (
+	"io/fs"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/db"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+// The models as they were when the schema was created by AutoMigrate, before versioned
+// migrations were introduced. Databases of that release have to be upgradable.
+type baselineUser struct {
+	ID           uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name         *string   `gorm:"size:100"`
+	Login        *string   `gorm:"unique;size:50"`
+	PasswordHash *string
+	Phone        *string   `gorm:"unique;size:15"`
+	IsDeleted    bool      `gorm:"default:false"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+func (baselineUser) TableName() string { return "users" }
+
+type baselineBannedUser struct {
+	ID           uuid.UUID          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID       *uuid.UUID         `gorm:"type:uuid"`
+	User         baselineUser       `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	CoffeeShopID *uuid.UUID         `gorm:"type:uuid"`
+	CoffeeShop   baselineCoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	CreatedAt    time.Time          `gorm:"autoCreateTime"`
+}
+
+func (baselineBannedUser) TableName() string { return "banned_user" }
+
+type baselineRole struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Name      string    `gorm:"not null;unique;size:50"`
+	IsDeleted bool      `gorm:"default:false"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (baselineRole) TableName() string { return "role" }
+
+type baselineCoffeeShop struct {
+	ID             uuid.UUID    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CreatorID      uuid.UUID    `gorm:"type:uuid;not null"`
+	Creator        baselineUser `gorm:"foreignKey:CreatorID;references:ID;constraint:OnDelete:CASCADE"`
+	Name           string       `gorm:"not null;size:100"`
+	Address        string       `gorm:"not null;size:255"`
+	Contacts       *string      `gorm:"size:100"`
+	WelcomeMessage *string
+	Rules          *string
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+}
+
+func (baselineCoffeeShop) TableName() string { return "coffee_shop" }
+
+type baselineWorkerCoffeeShop struct {
+	ID           uuid.UUID          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	WorkerID     *uuid.UUID         `gorm:"type:uuid"`
+	Worker       baselineUser       `gorm:"foreignKey:WorkerID;references:ID;constraint:OnDelete:CASCADE"`
+	CoffeeShopID *uuid.UUID         `gorm:"type:uuid"`
+	CoffeeShop   baselineCoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	RoleID       *uuid.UUID         `gorm:"type:uuid"`
+	Role         baselineRole       `gorm:"foreignKey:RoleID;references:ID;constraint:OnDelete:CASCADE"`
+	IsDeleted    bool               `gorm:"default:false"`
+	CreatedAt    time.Time          `gorm:"autoCreateTime"`
+}
+
+func (baselineWorkerCoffeeShop) TableName() string { return "worker_coffee_shop" }
+
+type baselineCategory struct {
+	ID           uuid.UUID          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID *uuid.UUID         `gorm:"type:uuid"`
+	CoffeeShop   baselineCoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:CASCADE"`
+	Title        string             `gorm:"not null;size:50"`
+	Description  *string
+	IsDeleted    bool      `gorm:"default:false"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+func (baselineCategory) TableName() string { return "category" }
+
+type baselineIdeaStatus struct {
+	ID        uuid.UUID `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	Title     string    `gorm:"not null;unique;size:50"`
+	IsDeleted bool      `gorm:"default:false"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (baselineIdeaStatus) TableName() string { return "status" }
+
+type baselineIdea struct {
+	ID           uuid.UUID          `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CreatorID    *uuid.UUID         `gorm:"type:uuid"`
+	Creator      baselineUser       `gorm:"foreignKey:CreatorID;references:ID;constraint:OnDelete:SET NULL"`
+	CoffeeShopID *uuid.UUID         `gorm:"type:uuid"`
+	CoffeeShop   baselineCoffeeShop `gorm:"foreignKey:CoffeeShopID;references:ID;constraint:OnDelete:SET NULL"`
+	CategoryID   *uuid.UUID         `gorm:"type:uuid"`
+	Category     baselineCategory   `gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:SET NULL"`
+	StatusID     *uuid.UUID         `gorm:"type:uuid"`
+	Status       baselineIdeaStatus `gorm:"foreignKey:StatusID;references:ID;constraint:OnDelete:SET NULL"`
+	Title        string             `gorm:"not null;size:150"`
+	Description  string             `gorm:"not null"`
+	ImageURL     *string            `gorm:"size:255"`
+	IsDeleted    bool               `gorm:"default:false"`
+	UpdatedAt    time.Time          `gorm:"autoUpdateTime"`
+	CreatedAt    time.Time          `gorm:"autoCreateTime"`
+}
+
+func (baselineIdea) TableName() string { return "idea" }
+
+type baselineIdeaLike struct {
+	ID        uuid.UUID    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID    *uuid.UUID   `gorm:"type:uuid;uniqueIndex:idx_user_idea"`
+	User      baselineUser `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	IdeaID    *uuid.UUID   `gorm:"type:uuid;uniqueIndex:idx_user_idea"`
+	Idea      baselineIdea `gorm:"foreignKey:IdeaID;references:ID;constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time    `gorm:"autoCreateTime"`
+}
+
+func (baselineIdeaLike) TableName() string { return "idea_like" }
+
+type baselineIdeaComment struct {
+	ID         uuid.UUID    `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CreatorID  *uuid.UUID   `gorm:"type:uuid"`
+	Creator    baselineUser `gorm:"foreignKey:CreatorID;references:ID;constraint:OnDelete:CASCADE"`
+	IdeaID     *uuid.UUID   `gorm:"type:uuid"`
+	Idea       baselineIdea `gorm:"foreignKey:IdeaID;references:ID;constraint:OnDelete:CASCADE"`
+	Text       string       `gorm:"not null"`
+	AuthorName string       `gorm:"not null;size:100"`
+	IsDeleted  bool         `gorm:"default:false"`
+	UpdatedAt  time.Time    `gorm:"autoUpdateTime"`
+	CreatedAt  time.Time    `gorm:"autoCreateTime"`
+}
+
+func (baselineIdeaComment) TableName() string { return "idea_comment" }
+
+type baselineRewardType struct {
+	ID           uuid.UUID           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	CoffeeShopID *uuid.UUID          `gorm:"type:uuid"`
+	CoffeeShop   *baselineCoffeeShop `gorm:"foreignKey:CoffeeShopID"`
+	Description  string              `gorm:"not null"`
+	CreatedAt    time.Time           `gorm:"autoCreateTime"`
+}
+
+func (baselineRewardType) TableName() string { return "reward_type" }
+
+type baselineReward struct {
+	ID           uuid.UUID           `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
+	ReceiverID   *uuid.UUID          `gorm:"type:uuid"`
+	Receiver     *baselineUser       `gorm:"foreignKey:ReceiverID"`
+	CoffeeShopID *uuid.UUID          `gorm:"type:uuid"`
+	CoffeeShop   *baselineCoffeeShop `gorm:"foreignKey:CoffeeShopID"`
+	IdeaID       *uuid.UUID          `gorm:"type:uuid"`
+	Idea         *baselineIdea       `gorm:"foreignKey:IdeaID"`
+	RewardTypeID *uuid.UUID          `gorm:"type:uuid"`
+	RewardType   *baselineRewardType `gorm:"foreignKey:RewardTypeID"`
+	IsActivated  bool                `gorm:"default:false"`
+	GivenAt      *time.Time
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+func (baselineReward) TableName() string { return "reward" }
+
+type baselineOTP struct {
+	ID            uint64    `gorm:"primaryKey"`
+	Phone         string    `gorm:"column:phone;not null;size:20"`
+	CodeHash      string    `gorm:"column:code_hash;not null;size:255"`
+	ExpiresAt     time.Time `gorm:"column:expires_at;not null"`
+	Verified      bool      `gorm:"column:verified;default:false"`
+	AttemptsLeft  int       `gorm:"column:attempts_left;default:3"`
+	CreatedAt     time.Time `gorm:"column:created_at;autoCreateTime"`
+	ResendCount   int       `gorm:"column:resend_count;default:0"`
+	NextAllowedAt time.Time `gorm:"column:next_allowed_at"`
+}
+
+func (baselineOTP) TableName() string { return "otps" }
+
+type baselineUserRefreshToken struct {
+	UserID       uuid.UUID     `gorm:"not null;type:uuid;index"`
+	User         *baselineUser `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	RefreshToken string        `gorm:"primaryKey;type:text"`
+	ExpiresAt    time.Time     `gorm:"not null"`
+}
+
+func (baselineUserRefreshToken) TableName() string { return "user_refresh_tokens" }
+
+type MigrationsTestSuite struct {
+	BaseTestSuite
+}
+
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
+
+func (suite *MigrationsTestSuite) TestEveryMigrationCanBeRolledBack() {
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(ups)
+	for _, up := range ups {
+		_, err := fs.Stat(migrations.FS, strings.TrimSuffix(up, ".up.sql")+".down.sql")
+		suite.NoError(err, "%s has no down migration", up)
+	}
+
+	latest, err := db.LatestSchemaVersion()
+	suite.Require().NoError(err)
+	suite.Len(ups, int(latest), "migration versions are numbered without gaps")
+}
+
+func (suite *MigrationsTestSuite) TestSetupChecksSchemaVersion() {
+	latest, err := db.LatestSchemaVersion()
+	suite.Require().NoError(err)
+
+	suite.Run("Up to date", func() {
+		ownerRoleID, err := db.Setup(suite.Ctx, suite.DB, slog.New(slog.DiscardHandler))
+		suite.Require().NoError(err)
+		suite.Equal(suite.OwnerRoleID, ownerRoleID)
+	})
+
+	m, err := db.NewMigrate(suite.cfg)
+	suite.Require().NoError(err)
+	defer m.Close()
+
+	suite.Run("Fail - Outdated", func() {
+		suite.Require().NoError(m.Steps(-1))
+		defer func() { suite.Require().NoError(m.Up()) }()
+
+		version, dirty, err := db.SchemaVersion(suite.Ctx, suite.DB)
+		suite.Require().NoError(err)
+		suite.False(dirty)
+		suite.Equal(latest-1, version)
+
+		_, err = db.Setup(suite.Ctx, suite.DB, slog.New(slog.DiscardHandler))
+		suite.ErrorIs(err, db.ErrSchemaOutdated)
+	})
+
+	suite.Run("Fail - Dirty", func() {
+		suite.Require().NoError(m.Force(int(latest)))
+		suite.Require().NoError(suite.DB.Exec("UPDATE schema_migrations SET dirty = true").Error)
+		defer func() { suite.Require().NoError(m.Force(int(latest))) }()
+
+		_, err := db.Setup(suite.Ctx, suite.DB, slog.New(slog.DiscardHandler))
+		suite.ErrorIs(err, db.ErrSchemaDirty)
+	})
+}
+
+func (suite *MigrationsTestSuite) TestDownAndUpRecreatesSchema() {
+	m, err := db.NewMigrate(suite.cfg)
+	suite.Require().NoError(err)
+	defer m.Close()
+
+	suite.Require().NoError(m.Down())
+	_, _, err = m.Version()
+	suite.ErrorIs(err, migrate.ErrNilVersion)
+	suite.False(suite.DB.Migrator().HasTable(&models.User{}))
+	_, err = db.Setup(suite.Ctx, suite.DB, slog.New(slog.DiscardHandler))
+	suite.ErrorIs(err, db.ErrSchemaNotInitialized)
+
+	suite.Require().NoError(m.Up())
+	for _, table := range []any{
+		&models.User{}, &models.CoffeeShop{}, &models.Idea{}, &models.Reward{},
+		&models.UserSession{}, &models.UserRefreshToken{}, &models.RotatedRefreshToken{},
+	} {
+		suite.True(suite.DB.Migrator().HasTable(table))
+	}
+
+	var roles []string
+	suite.Require().NoError(suite.DB.Model(&models.Role{}).Order("name").Pluck("name", &roles).Error)
+	suite.ElementsMatch(models.RoleNames(), roles, "the migrations seed the worker roles")
+
+	suite.Run("Checks reject invalid rows", func() {
+		shop := models.CoffeeShop{Name: "Shop", Address: "Street 1"}
+		suite.Require().NoError(suite.DB.Create(&shop).Error)
+		err := suite.DB.Create(&models.OpeningHours{CoffeeShopID: shop.ID, Weekday: 7, Opens: "08:00", Closes: "20:00"}).Error
+		suite.Error(err)
+		latitude := 91.0
+		suite.Error(suite.DB.Model(&shop).Update("latitude", latitude).Error, "latitude without longitude")
+	})
+
+	suite.restoreRoles()
+}
+
+func (suite *MigrationsTestSuite) TestUpgradesBaselineSchema() {
+	m, err := db.NewMigrate(suite.cfg)
+	suite.Require().NoError(err)
+	suite.Require().NoError(m.Down())
+	m.Close()
+	defer suite.recreateSchema()
+
+	// A database as the release before versioned migrations left it.
+	suite.Require().NoError(suite.DB.Exec("DROP TABLE schema_migrations").Error)
+	suite.Require().NoError(suite.DB.AutoMigrate(
+		&baselineUser{}, &baselineBannedUser{}, &baselineRole{}, &baselineCoffeeShop{}, &baselineWorkerCoffeeShop{},
+		&baselineCategory{}, &baselineIdea{}, &baselineIdeaLike{}, &baselineIdeaComment{}, &baselineIdeaStatus{},
+		&baselineReward{}, &baselineRewardType{}, &baselineOTP{}, &baselineUserRefreshToken{},
+	))
+	suite.Require().False(suite.DB.Migrator().HasColumn(&models.CoffeeShop{}, "organization_id"))
+
+	phone := "9990001122"
+	creator := baselineUser{Phone: &phone}
+	suite.Require().NoError(suite.DB.Create(&creator).Error)
+	shop := baselineCoffeeShop{CreatorID: creator.ID, Name: "Old Shop", Address: "Old St 1"}
+	suite.Require().NoError(suite.DB.Create(&shop).Error)
+	adminRole := baselineRole{Name: models.RoleAdmin}
+	suite.Require().NoError(suite.DB.Create(&adminRole).Error)
+	creatorWorker := baselineWorkerCoffeeShop{WorkerID: &creator.ID, CoffeeShopID: &shop.ID, RoleID: &adminRole.ID}
+	suite.Require().NoError(suite.DB.Create(&creatorWorker).Error)
+	rewardType := baselineRewardType{CoffeeShopID: &shop.ID, Description: "Free coffee"}
+	suite.Require().NoError(suite.DB.Create(&rewardType).Error)
+
+	suite.Require().NoError(db.RunMigrations(suite.cfg))
+	_, err = db.Setup(suite.Ctx, suite.DB, slog.New(slog.DiscardHandler))
+	suite.Require().NoError(err)
+
+	for table, columns := range map[any][]string{
+		&models.CoffeeShop{}:       {"organization_id", "latitude", "longitude", "postal_code"},
+		&models.BannedUser{}:       {"banned_by_id", "reason", "expires_at", "hide_ideas"},
+		&models.Category{}:         {"organization_id"},
+		&models.IdeaStatus{}:       {"coffee_shop_id", "is_initial", "is_terminal"},
+		&models.RewardType{}:       {"organization_id", "title", "validity_days", "total_stock", "monthly_budget"},
+		&models.Reward{}:           {"expires_at", "is_expired", "redemption_code", "activated_by_id"},
+		&models.UserRefreshToken{}: {"session_id"},
+	} {
+		for _, column := range columns {
+			suite.True(suite.DB.Migrator().HasColumn(table, column), "%T has no column %s", table, column)
+		}
+	}
+
+	var upgraded models.CoffeeShop
+	suite.Require().NoError(suite.DB.First(&upgraded, "id = ?", shop.ID).Error)
+	suite.Equal("Old Shop", upgraded.Name, "existing rows are kept")
+
+	var creatorRole string
+	suite.Require().NoError(suite.DB.Table("worker_coffee_shop").Joins("JOIN role ON role.id = worker_coffee_shop.role_id").
+		Where("worker_coffee_shop.id = ?", creatorWorker.ID).Select("role.name").Scan(&creatorRole).Error)
+	suite.Equal(models.RoleOwner, creatorRole, "the creator of the shop becomes its owner")
+
+	suite.Require().NoError(suite.DB.Delete(&models.User{}, "id = ?", creator.ID).Error)
+	suite.Require().NoError(suite.DB.First(&upgraded, "id = ?", shop.ID).Error, "the shop outlives its creator")
+	suite.Nil(upgraded.CreatorID)
+}
+
+// recreateSchema rebuilds the schema from the migrations for the tests that follow.
+func (suite *MigrationsTestSuite) recreateSchema() {
+	m, err := db.NewMigrate(suite.cfg)
+	suite.Require().NoError(err)
+	defer m.Close()
+	suite.Require().NoError(m.Down())
+	suite.Require().NoError(m.Up())
+	suite.restoreRoles()
+}
+
+// restoreRoles recreates the roles with the IDs the base suite created, the rest of the
+// suite relies on them.
+func (suite *MigrationsTestSuite) restoreRoles() {
+	suite.Require().NoError(suite.DB.Exec("DELETE FROM role").Error)
+	for name, id := range map[string]any{
+		models.RoleOwner: suite.OwnerRoleID, models.RoleAdmin: suite.AdminRoleID, models.RoleModerator: suite.ModeratorRoleID,
+		models.RoleBarista: suite.BaristaRoleID, "user": suite.UserRoleID,
+	} {
+		suite.Require().NoError(suite.DB.Exec("INSERT INTO role (id, name) VALUES (?, ?)", id, name).Error)
+	}
+}
@@ -0,0 +1,261 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrganizationIntegrationTestSuite struct {
+	BaseTestSuite
+}
+
+func (suite *OrganizationIntegrationTestSuite) SetupSuite() {
+	suite.BaseTestSuite.SetupSuite()
+}
+
+func (suite *OrganizationIntegrationTestSuite) TearDownTest() {
+	suite.BaseTestSuite.TearDownTest()
+}
+
+func TestOrganizationIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationIntegrationTestSuite))
+}
+
+// createChain creates an organization owned by a user who also owns two coffee shops attached to it.
+func (suite *OrganizationIntegrationTestSuite) createChain() (string, dto.OrganizationResponse, *models.CoffeeShop, *models.CoffeeShop) {
+	owner, first := suite.CreateTestUser("chain-owner", "111111111", "Chain Downtown", "1 Chain St", suite.OwnerRoleID)
+	ownerToken := suite.RegisterUserAndGetToken(owner)
+	second := &models.CoffeeShop{
+		ID:        uuid.New(),
+		CreatorID: &owner.ID,
+		Name:      "Chain Uptown",
+		Address:   "2 Chain St",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	suite.Require().NoError(suite.DB.Create(second).Error)
+	suite.CreateWorkerForShop(owner, second, suite.OwnerRoleID)
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/organizations", token: ownerToken,
+		body: dto.CreateOrganizationRequest{Name: "Chain"}, contentType: "application/json",
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var org dto.OrganizationResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &org))
+
+	for _, shop := range []*models.CoffeeShop{first, second} {
+		w = suite.MakeRequest(TestRequest{
+			method: http.MethodPost, path: fmt.Sprintf("/api/v1/organizations/%s/coffee-shops", org.ID), token: ownerToken,
+			body: dto.AttachCoffeeShopRequest{CoffeeShopID: shop.ID}, contentType: "application/json",
+		})
+		suite.Require().Equal(http.StatusNoContent, w.Code, w.Body.String())
+	}
+	return ownerToken, org, first, second
+}
+
+func (suite *OrganizationIntegrationTestSuite) addMember(token string, orgID uuid.UUID, body dto.AddOrganizationMemberRequest) int {
+	return suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: fmt.Sprintf("/api/v1/organizations/%s/members", orgID), token: token,
+		body: body, contentType: "application/json",
+	}).Code
+}
+
+func (suite *OrganizationIntegrationTestSuite) createIdea(creator *models.User, shop *models.CoffeeShop, title string) *models.Idea {
+	var ideaStatus models.IdeaStatus
+	suite.DB.FirstOrCreate(&ideaStatus, "title = ?", "new")
+	idea := &models.Idea{
+		Title:        title,
+		Description:  "An idea for the whole chain.",
+		CreatorID:    &creator.ID,
+		CoffeeShopID: &shop.ID,
+		StatusID:     &ideaStatus.ID,
+	}
+	suite.Require().NoError(suite.DB.Create(idea).Error)
+	return idea
+}
+
+func (suite *OrganizationIntegrationTestSuite) TestOrganizationAdminInheritsShopRights() {
+	ownerToken, org, first, second := suite.createChain()
+	admin := suite.CreateUser("chain-admin", "222222222")
+	adminToken := suite.RegisterUserAndGetToken(admin)
+
+	suite.Run("Fail - Not a member yet", func() {
+		w := suite.MakeRequest(TestRequest{
+			method: http.MethodPost, path: fmt.Sprintf("/api/v1/coffee-shops/%s/categories", first.ID), token: adminToken,
+			body: dto.CreateCategory{Title: "Desserts"}, contentType: "application/json",
+		})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+
+	suite.Require().Equal(http.StatusCreated, suite.addMember(ownerToken, org.ID, dto.AddOrganizationMemberRequest{UserID: admin.ID}))
+	suite.Equal(http.StatusConflict, suite.addMember(ownerToken, org.ID, dto.AddOrganizationMemberRequest{UserID: admin.ID}))
+	suite.Equal(http.StatusBadRequest, suite.addMember(ownerToken, org.ID, dto.AddOrganizationMemberRequest{UserID: uuid.New(), Role: models.RoleOwner}))
+	suite.Equal(http.StatusNotFound, suite.addMember(ownerToken, org.ID, dto.AddOrganizationMemberRequest{UserID: uuid.New()}))
+
+	suite.Run("Admin manages every shop of the organization", func() {
+		for _, shop := range []*models.CoffeeShop{first, second} {
+			w := suite.MakeRequest(TestRequest{
+				method: http.MethodPost, path: fmt.Sprintf("/api/v1/coffee-shops/%s/categories", shop.ID), token: adminToken,
+				body: dto.CreateCategory{Title: "Seasonal " + shop.Name}, contentType: "application/json",
+			})
+			suite.Equal(http.StatusCreated, w.Code, w.Body.String())
+		}
+	})
+
+	suite.Run("Admin cannot delete a shop", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/coffee-shops/%s", first.ID), token: adminToken})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+
+	suite.Run("Removed admin loses the rights", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/organizations/%s/members/%s", org.ID, admin.ID), token: ownerToken})
+		suite.Require().Equal(http.StatusNoContent, w.Code, w.Body.String())
+		w = suite.MakeRequest(TestRequest{
+			method: http.MethodPost, path: fmt.Sprintf("/api/v1/coffee-shops/%s/categories", first.ID), token: adminToken,
+			body: dto.CreateCategory{Title: "Too late"}, contentType: "application/json",
+		})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+}
+
+func (suite *OrganizationIntegrationTestSuite) TestSharedCategoriesAndRewardTypes() {
+	ownerToken, org, first, second := suite.createChain()
+	customer := suite.CreateUser("chain-customer", "333333333")
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: fmt.Sprintf("/api/v1/organizations/%s/categories", org.ID), token: ownerToken,
+		body: dto.CreateCategory{Title: "Chain Drinks"}, contentType: "application/json",
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var created map[string]uuid.UUID
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+	categoryID := created["id"]
+
+	suite.Run("Organization categories are listed in every shop", func() {
+		for _, shop := range []*models.CoffeeShop{first, second} {
+			w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s/categories", shop.ID)})
+			suite.Require().Equal(http.StatusOK, w.Code)
+			var resp pagination.Page[dto.CategoryResponse]
+			suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+			suite.Require().Len(resp.Items, 1)
+			suite.Equal(categoryID, resp.Items[0].ID)
+			suite.Equal(&org.ID, resp.Items[0].OrganizationID)
+		}
+	})
+
+	w = suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/admin/rewards/type", token: ownerToken, contentType: "application/json",
+		body: dto.CreateRewardTypeRequest{OrganizationID: &org.ID, Title: "Free coffee", Description: "In any shop of the chain"},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var rewardType dto.RewardTypeResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &rewardType))
+	suite.Equal(&org.ID, rewardType.OrganizationID)
+
+	suite.Run("Organization reward type can be given in a child shop", func() {
+		idea := suite.createIdea(customer, second, "Oat milk everywhere")
+		w := suite.MakeRequest(TestRequest{
+			method: http.MethodPost, path: "/api/v1/admin/rewards", token: ownerToken, contentType: "application/json",
+			body: dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rewardType.ID},
+		})
+		suite.Equal(http.StatusCreated, w.Code, w.Body.String())
+	})
+
+	suite.Run("Fail - Organization reward type in a standalone shop", func() {
+		other, standalone := suite.CreateTestUser("standalone-owner", "444444444", "Standalone", "3 Other St", suite.OwnerRoleID)
+		otherToken := suite.RegisterUserAndGetToken(other)
+		idea := suite.createIdea(customer, standalone, "Not for the chain")
+		w := suite.MakeRequest(TestRequest{
+			method: http.MethodPost, path: "/api/v1/admin/rewards", token: otherToken, contentType: "application/json",
+			body: dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rewardType.ID},
+		})
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (suite *OrganizationIntegrationTestSuite) TestCrossShopIdeasAndStats() {
+	ownerToken, org, first, second := suite.createChain()
+	customer := suite.CreateUser("chain-customer", "333333333")
+	stranger := suite.CreateUser("chain-stranger", "555555555")
+	strangerToken := suite.RegisterUserAndGetToken(stranger)
+
+	suite.createIdea(customer, first, "Longer hours downtown")
+	suite.createIdea(customer, first, "More seats downtown")
+	suite.createIdea(customer, second, "Music uptown")
+	_, standalone := suite.CreateTestUser("standalone-owner", "444444444", "Standalone", "3 Other St", suite.OwnerRoleID)
+	suite.createIdea(customer, standalone, "Outside the chain")
+
+	suite.Run("Ideas of all shops of the organization", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/organizations/%s/ideas?include_total=true", org.ID)})
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+		var resp pagination.Page[dto.IdeaResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Len(resp.Items, 3)
+		for _, idea := range resp.Items {
+			suite.Contains([]uuid.UUID{first.ID, second.ID}, *idea.CoffeeShopID)
+		}
+	})
+
+	suite.Run("Stats per shop and in total", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/organizations/%s/stats", org.ID), token: ownerToken})
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+		var resp dto.OrganizationStatsResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.EqualValues(3, resp.Total.Ideas)
+		suite.Require().Len(resp.CoffeeShops, 2)
+		suite.Equal(first.ID, resp.CoffeeShops[0].CoffeeShopID)
+		suite.EqualValues(2, resp.CoffeeShops[0].Ideas)
+		suite.EqualValues(1, resp.CoffeeShops[1].Ideas)
+	})
+
+	suite.Run("Fail - Stats for a non-member", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/organizations/%s/stats", org.ID), token: strangerToken})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+}
+
+func (suite *OrganizationIntegrationTestSuite) TestAttachAndDetachCoffeeShops() {
+	ownerToken, org, first, _ := suite.createChain()
+	other, otherShop := suite.CreateTestUser("other-owner", "222222222", "Other Shop", "9 Other St", suite.OwnerRoleID)
+	otherToken := suite.RegisterUserAndGetToken(other)
+
+	attach := func(token string, orgID, shopID uuid.UUID) int {
+		return suite.MakeRequest(TestRequest{
+			method: http.MethodPost, path: fmt.Sprintf("/api/v1/organizations/%s/coffee-shops", orgID), token: token,
+			body: dto.AttachCoffeeShopRequest{CoffeeShopID: shopID}, contentType: "application/json",
+		}).Code
+	}
+
+	suite.Equal(http.StatusForbidden, attach(ownerToken, org.ID, otherShop.ID), "only an owner of the shop can attach it")
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/organizations", token: otherToken,
+		body: dto.CreateOrganizationRequest{Name: "Other Chain"}, contentType: "application/json",
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var otherOrg dto.OrganizationResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &otherOrg))
+	suite.CreateWorkerForShop(other, first, suite.OwnerRoleID)
+	suite.Equal(http.StatusConflict, attach(otherToken, otherOrg.ID, first.ID), "a shop belongs to one organization")
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/organizations/%s/coffee-shops/%s", org.ID, first.ID), token: ownerToken})
+	suite.Require().Equal(http.StatusNoContent, w.Code, w.Body.String())
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/organizations/%s/coffee-shops", org.ID)})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var shops pagination.Page[dto.CoffeeShopResponse]
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &shops))
+	suite.Require().Len(shops.Items, 1)
+	suite.NotEqual(first.ID, shops.Items[0].ID)
+	suite.Equal(&org.ID, shops.Items[0].OrganizationID)
+}
@@ -0,0 +1,216 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	apperrors "github.com/GeorgiiMalishev/ideas-platform/internal/app_errors"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type OwnershipTransferIntegrationTestSuite struct {
+	BaseTestSuite
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) SetupSuite() {
+	suite.BaseTestSuite.SetupSuite()
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) TearDownTest() {
+	suite.BaseTestSuite.TearDownTest()
+}
+
+func TestOwnershipTransferIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(OwnershipTransferIntegrationTestSuite))
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) createTransfer(token string, shopID uuid.UUID, body dto.CreateOwnershipTransferRequest) (int, dto.OwnershipTransferResponse) {
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: fmt.Sprintf("/api/v1/coffee-shops/%s/ownership-transfers", shopID), token: token,
+		body: body, contentType: "application/json",
+	})
+	var resp dto.OwnershipTransferResponse
+	if w.Code == http.StatusCreated {
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) post(token, path string) int {
+	return suite.MakeRequest(TestRequest{method: http.MethodPost, path: path, token: token}).Code
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) roleOf(userID, shopID uuid.UUID) string {
+	relation, err := suite.WorkerCoffeeShopRepo.GetByUserIDAndShopID(suite.Ctx, userID, shopID)
+	suite.Require().NoError(err)
+	return relation.Role.Name
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) TestTransferOwnership() {
+	owner, shop := suite.CreateTestUser("transfer-owner", "111111111", "Transfer Shop", "1 Transfer St", suite.OwnerRoleID)
+	ownerToken := suite.RegisterUserAndGetToken(owner)
+	admin := suite.CreateUser("transfer-admin", "222222222")
+	suite.CreateWorkerForShop(admin, shop, suite.AdminRoleID)
+	adminToken := suite.RegisterUserAndGetToken(admin)
+	barista := suite.CreateUser("transfer-barista", "333333333")
+	suite.CreateWorkerForShop(barista, shop, suite.BaristaRoleID)
+
+	suite.Run("Fail - Only admins can receive ownership", func() {
+		code, _ := suite.createTransfer(ownerToken, shop.ID, dto.CreateOwnershipTransferRequest{ToUserID: barista.ID})
+		suite.Equal(http.StatusBadRequest, code)
+		code, _ = suite.createTransfer(ownerToken, shop.ID, dto.CreateOwnershipTransferRequest{ToUserID: owner.ID})
+		suite.Equal(http.StatusBadRequest, code)
+	})
+
+	suite.Run("Fail - An admin cannot offer ownership", func() {
+		code, _ := suite.createTransfer(adminToken, shop.ID, dto.CreateOwnershipTransferRequest{ToUserID: admin.ID})
+		suite.Equal(http.StatusForbidden, code)
+	})
+
+	code, transfer := suite.createTransfer(ownerToken, shop.ID, dto.CreateOwnershipTransferRequest{ToUserID: admin.ID})
+	suite.Require().Equal(http.StatusCreated, code)
+	suite.Equal(models.TransferPending, transfer.Status)
+	suite.Equal(models.RoleOwner, suite.roleOf(owner.ID, shop.ID), "nothing changes before the recipient accepts")
+
+	w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me/ownership-transfers", token: adminToken})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var mine pagination.Page[dto.OwnershipTransferResponse]
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &mine))
+	suite.Require().Len(mine.Items, 1)
+	suite.Equal(shop.Name, mine.Items[0].CoffeeShopName)
+
+	suite.Equal(http.StatusNotFound, suite.post(ownerToken, fmt.Sprintf("/api/v1/ownership-transfers/%s/accept", transfer.ID)), "only the recipient can accept")
+	suite.Equal(http.StatusOK, suite.post(adminToken, fmt.Sprintf("/api/v1/ownership-transfers/%s/accept", transfer.ID)))
+	suite.Equal(http.StatusConflict, suite.post(adminToken, fmt.Sprintf("/api/v1/ownership-transfers/%s/accept", transfer.ID)))
+
+	suite.Equal(models.RoleOwner, suite.roleOf(admin.ID, shop.ID))
+	suite.Equal(models.RoleAdmin, suite.roleOf(owner.ID, shop.ID))
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/coffee-shops/%s", shop.ID), token: ownerToken})
+	suite.Equal(http.StatusForbidden, w.Code, "the former owner can no longer delete the shop")
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) TestCoOwnersAndStepDown() {
+	owner, shop := suite.CreateTestUser("co-owner", "111111111", "Co Shop", "1 Co St", suite.OwnerRoleID)
+	ownerToken := suite.RegisterUserAndGetToken(owner)
+	admin := suite.CreateUser("co-admin", "222222222")
+	suite.CreateWorkerForShop(admin, shop, suite.AdminRoleID)
+	adminToken := suite.RegisterUserAndGetToken(admin)
+
+	ownerRelation, err := suite.WorkerCoffeeShopRepo.GetByUserIDAndShopID(suite.Ctx, owner.ID, shop.ID)
+	suite.Require().NoError(err)
+	removeOwner := func() int {
+		return suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/admin/worker-coffee-shops/%s", ownerRelation.ID), token: ownerToken}).Code
+	}
+
+	suite.Equal(http.StatusConflict, removeOwner(), "the last owner cannot leave")
+	w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/users/%s", owner.ID), token: ownerToken})
+	suite.Equal(http.StatusConflict, w.Code, "the last owner cannot delete their account")
+
+	code, transfer := suite.createTransfer(ownerToken, shop.ID, dto.CreateOwnershipTransferRequest{ToUserID: admin.ID, KeepOwnership: true})
+	suite.Require().Equal(http.StatusCreated, code)
+	suite.Require().Equal(http.StatusOK, suite.post(adminToken, fmt.Sprintf("/api/v1/ownership-transfers/%s/accept", transfer.ID)))
+	suite.Equal(models.RoleOwner, suite.roleOf(owner.ID, shop.ID))
+	suite.Equal(models.RoleOwner, suite.roleOf(admin.ID, shop.ID))
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/admin/worker-coffee-shops/%s", ownerRelation.ID), token: adminToken})
+	suite.Equal(http.StatusForbidden, w.Code, "a co-owner cannot remove another owner")
+
+	suite.Equal(http.StatusNoContent, removeOwner(), "an owner can leave while a co-owner remains")
+	suite.Equal(int64(1), suite.countOwners(shop.ID))
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) TestCoOwnersCannotStepDownAtOnce() {
+	owner, shop := suite.CreateTestUser("racing-owner", "111111111", "Race Shop", "1 Race St", suite.OwnerRoleID)
+	coOwner := suite.CreateUser("racing-co-owner", "222222222")
+	suite.CreateWorkerForShop(coOwner, shop, suite.OwnerRoleID)
+
+	var relations []*models.WorkerCoffeeShop
+	for _, user := range []*models.User{owner, coOwner} {
+		relation, err := suite.WorkerCoffeeShopRepo.GetByUserIDAndShopID(suite.Ctx, user.ID, shop.ID)
+		suite.Require().NoError(err)
+		relations = append(relations, relation)
+	}
+	adminRole, err := suite.RoleRepo.GetRoleByName(suite.Ctx, models.RoleAdmin)
+	suite.Require().NoError(err)
+	relations[1].RoleID = &adminRole.ID
+	relations[1].Role = *adminRole
+
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs[0] = suite.WorkerCoffeeShopRepo.DeleteOwner(suite.Ctx, relations[0])
+	}()
+	go func() {
+		defer wg.Done()
+		errs[1] = suite.WorkerCoffeeShopRepo.UpdateOwner(suite.Ctx, relations[1])
+	}()
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			var errConflict *apperrors.ErrConflict
+			suite.ErrorAs(err, &errConflict)
+			failed++
+		}
+	}
+	suite.Equal(1, failed, "only one of the owners steps down")
+	suite.Equal(int64(1), suite.countOwners(shop.ID))
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) TestDeclineAndCancel() {
+	owner, shop := suite.CreateTestUser("answer-owner", "111111111", "Answer Shop", "1 Answer St", suite.OwnerRoleID)
+	ownerToken := suite.RegisterUserAndGetToken(owner)
+	admin := suite.CreateUser("answer-admin", "222222222")
+	suite.CreateWorkerForShop(admin, shop, suite.AdminRoleID)
+	adminToken := suite.RegisterUserAndGetToken(admin)
+
+	_, declined := suite.createTransfer(ownerToken, shop.ID, dto.CreateOwnershipTransferRequest{ToUserID: admin.ID})
+	suite.Equal(http.StatusOK, suite.post(adminToken, fmt.Sprintf("/api/v1/ownership-transfers/%s/decline", declined.ID)))
+	suite.Equal(http.StatusConflict, suite.post(adminToken, fmt.Sprintf("/api/v1/ownership-transfers/%s/accept", declined.ID)))
+
+	_, cancelled := suite.createTransfer(ownerToken, shop.ID, dto.CreateOwnershipTransferRequest{ToUserID: admin.ID})
+	w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/coffee-shops/%s/ownership-transfers/%s", shop.ID, cancelled.ID), token: ownerToken})
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+	suite.Equal(http.StatusConflict, suite.post(adminToken, fmt.Sprintf("/api/v1/ownership-transfers/%s/accept", cancelled.ID)))
+
+	suite.Equal(models.RoleAdmin, suite.roleOf(admin.ID, shop.ID))
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s/ownership-transfers", shop.ID), token: ownerToken})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var all pagination.Page[dto.OwnershipTransferResponse]
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &all))
+	statuses := map[uuid.UUID]string{}
+	for _, t := range all.Items {
+		statuses[t.ID] = t.Status
+	}
+	suite.Equal(models.TransferDeclined, statuses[declined.ID])
+	suite.Equal(models.TransferCancelled, statuses[cancelled.ID])
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) TestShopSurvivesCreatorRemoval() {
+	creator, shop := suite.CreateTestUser("founder", "111111111", "Founder Shop", "1 Founder St", suite.OwnerRoleID)
+
+	suite.Require().NoError(suite.DB.Exec("DELETE FROM worker_coffee_shop WHERE worker_id = ?", creator.ID).Error)
+	suite.Require().NoError(suite.DB.Delete(&models.User{}, "id = ?", creator.ID).Error)
+
+	var remaining models.CoffeeShop
+	suite.Require().NoError(suite.DB.First(&remaining, "id = ?", shop.ID).Error)
+	suite.Nil(remaining.CreatorID)
+}
+
+func (suite *OwnershipTransferIntegrationTestSuite) countOwners(shopID uuid.UUID) int64 {
+	count, err := suite.WorkerCoffeeShopRepo.CountByRole(suite.Ctx, shopID, models.RoleOwner)
+	suite.Require().NoError(err)
+	return count
+}
@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
@@ -38,7 +41,7 @@ func (suite *RewardIntegrationTestSuite) createAdminTestPrerequisites() (adminTo
 	coffeeShop = models.CoffeeShop{
 		Name:      "Admin's Coffee Shop",
 		Address:   "1 Admin St",
-		CreatorID: admin.ID,
+		CreatorID: &admin.ID,
 	}
 	err := suite.DB.Create(&coffeeShop).Error
 	suite.Require().NoError(err)
@@ -216,11 +219,11 @@ func (suite *RewardIntegrationTestSuite) TestListRewards_Public() {
 		req := TestRequest{method: http.MethodGet, path: "/api/v1/users/me/rewards", token: authorToken}
 		w := suite.MakeRequest(req)
 		suite.Equal(http.StatusOK, w.Code)
-		var resp []dto.RewardResponse
+		var resp pagination.Page[dto.RewardResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.Len(resp, 1)
-		suite.Equal(*idea.CreatorID, *resp[0].ReceiverID)
+		suite.Len(resp.Items, 1)
+		suite.Equal(*idea.CreatorID, *resp.Items[0].ReceiverID)
 	})
 
 	// --- GetRewardsForCoffeeShop (Authenticated User) ---
@@ -228,9 +231,256 @@ func (suite *RewardIntegrationTestSuite) TestListRewards_Public() {
 		req := TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s/rewards", coffeeShop.ID), token: adminToken}
 		w := suite.MakeRequest(req)
 		suite.Equal(http.StatusOK, w.Code)
-		var resp []dto.RewardResponse
+		var resp pagination.Page[dto.RewardResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.Len(resp, 1)
+		suite.Len(resp.Items, 1)
 	})
 }
+
+func (suite *RewardIntegrationTestSuite) TestRedeemReward() {
+	adminToken, coffeeShop, idea, rewardType, authorToken := suite.createAdminTestPrerequisites()
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/admin/rewards", token: adminToken, contentType: "application/json",
+		body: dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rewardType.ID},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code)
+
+	// A barista of the issuing shop and a worker of another shop
+	barista := suite.CreateUser("barista", "3333333333")
+	baristaToken := suite.RegisterUserAndGetToken(barista)
+	suite.Require().NoError(suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &barista.ID, CoffeeShopID: &coffeeShop.ID, RoleID: &suite.BaristaRoleID}).Error)
+
+	otherWorker := suite.CreateUser("other-worker", "4444444444")
+	otherWorkerToken := suite.RegisterUserAndGetToken(otherWorker)
+	otherShop := models.CoffeeShop{Name: "Other Shop", Address: "2 Other St", CreatorID: &otherWorker.ID}
+	suite.Require().NoError(suite.DB.Create(&otherShop).Error)
+	suite.Require().NoError(suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &otherWorker.ID, CoffeeShopID: &otherShop.ID, RoleID: &suite.AdminRoleID}).Error)
+
+	getMyReward := func() dto.RewardResponse {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me/rewards", token: authorToken})
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var resp pagination.Page[dto.RewardResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Require().Len(resp.Items, 1)
+		return resp.Items[0]
+	}
+
+	reward := getMyReward()
+	suite.Require().NotNil(reward.RedemptionCode)
+	suite.Require().NotNil(reward.RedemptionCodeExpiresAt)
+	code := *reward.RedemptionCode
+	suite.Equal(code, *getMyReward().RedemptionCode, "a fresh code should be reused")
+
+	suite.Run("Code is not exposed to the shop", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s/rewards", coffeeShop.ID), token: adminToken})
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var resp pagination.Page[dto.RewardResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Require().Len(resp.Items, 1)
+		suite.Nil(resp.Items[0].RedemptionCode)
+	})
+
+	tests := []struct {
+		name           string
+		token          string
+		body           dto.RedeemRewardRequest
+		expectedStatus int
+	}{
+		{
+			name:           "Fail - No token",
+			body:           dto.RedeemRewardRequest{Code: code, CoffeeShopID: coffeeShop.ID},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Fail - Receiver is not a worker",
+			token:          authorToken,
+			body:           dto.RedeemRewardRequest{Code: code, CoffeeShopID: coffeeShop.ID},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Fail - Code from another shop",
+			token:          otherWorkerToken,
+			body:           dto.RedeemRewardRequest{Code: code, CoffeeShopID: otherShop.ID},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Fail - Unknown code",
+			token:          baristaToken,
+			body:           dto.RedeemRewardRequest{Code: "AAAAAAAA", CoffeeShopID: coffeeShop.ID},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Success - Barista redeems the reward",
+			token:          baristaToken,
+			body:           dto.RedeemRewardRequest{Code: code, CoffeeShopID: coffeeShop.ID},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Fail - Double redemption",
+			token:          adminToken,
+			body:           dto.RedeemRewardRequest{Code: code, CoffeeShopID: coffeeShop.ID},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			req := TestRequest{method: http.MethodPost, path: "/api/v1/rewards/redeem", token: tt.token, body: tt.body, contentType: "application/json"}
+			w := suite.MakeRequest(req)
+			suite.Equal(tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+
+	reward = getMyReward()
+	suite.True(reward.IsActivated)
+	suite.NotNil(reward.ActivatedAt)
+	suite.Nil(reward.RedemptionCode)
+}
+
+func (suite *RewardIntegrationTestSuite) TestRedeemReward_ExpiredCode() {
+	adminToken, coffeeShop, idea, rewardType, authorToken := suite.createAdminTestPrerequisites()
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/admin/rewards", token: adminToken, contentType: "application/json",
+		body: dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rewardType.ID},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var created dto.RewardResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &created))
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me/rewards", token: authorToken})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var page pagination.Page[dto.RewardResponse]
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &page))
+	suite.Require().Len(page.Items, 1)
+	code := *page.Items[0].RedemptionCode
+
+	suite.Require().NoError(suite.DB.Model(&models.Reward{}).Where("id = ?", created.ID).
+		UpdateColumn("redemption_code_expires_at", time.Now().Add(-time.Minute)).Error)
+
+	w = suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/rewards/redeem", token: adminToken, contentType: "application/json",
+		body: dto.RedeemRewardRequest{Code: code, CoffeeShopID: coffeeShop.ID},
+	})
+	suite.Equal(http.StatusConflict, w.Code)
+}
+
+func (suite *RewardIntegrationTestSuite) TestRewardsAreScopedToShop() {
+	adminToken, coffeeShop, idea, rewardType, authorToken := suite.createAdminTestPrerequisites()
+
+	// An admin of a competing shop with its own reward type
+	rival := suite.CreateUser("rival-admin", "5555555555")
+	rivalToken := suite.RegisterUserAndGetToken(rival)
+	rivalShop := models.CoffeeShop{Name: "Rival Shop", Address: "3 Rival St", CreatorID: &rival.ID}
+	suite.Require().NoError(suite.DB.Create(&rivalShop).Error)
+	suite.Require().NoError(suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &rival.ID, CoffeeShopID: &rivalShop.ID, RoleID: &suite.AdminRoleID}).Error)
+	rivalRewardType := models.RewardType{CoffeeShopID: &rivalShop.ID, Description: "Rival coffee"}
+	suite.Require().NoError(suite.DB.Create(&rivalRewardType).Error)
+
+	giveReward := func(token string, body dto.GiveRewardRequest) *httptest.ResponseRecorder {
+		return suite.MakeRequest(TestRequest{method: http.MethodPost, path: "/api/v1/admin/rewards", token: token, body: body, contentType: "application/json"})
+	}
+
+	suite.Run("Fail - Admin of another shop cannot reward the idea", func() {
+		w := giveReward(rivalToken, dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rivalRewardType.ID})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+
+	suite.Run("Fail - Reward type of another shop", func() {
+		w := giveReward(adminToken, dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rivalRewardType.ID})
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+
+	w := giveReward(adminToken, dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rewardType.ID})
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var reward dto.RewardResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &reward))
+
+	suite.Run("Fail - Admin of another shop cannot list rewards", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s/rewards", coffeeShop.ID), token: rivalToken})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+
+	suite.Run("Fail - Non-worker cannot list rewards", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s/rewards", coffeeShop.ID), token: authorToken})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+
+	suite.Run("Fail - Admin of another shop cannot revoke the reward", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/admin/rewards/%s", reward.ID), token: rivalToken})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+}
+
+func (suite *RewardIntegrationTestSuite) TestGiveReward_Limits() {
+	adminToken, _, idea, _, _ := suite.createAdminTestPrerequisites()
+
+	giveReward := func(rewardTypeID uuid.UUID) *httptest.ResponseRecorder {
+		return suite.MakeRequest(TestRequest{
+			method: http.MethodPost, path: "/api/v1/admin/rewards", token: adminToken, contentType: "application/json",
+			body: dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rewardTypeID},
+		})
+	}
+
+	suite.Run("Total stock", func() {
+		stock := 1
+		rewardType := models.RewardType{CoffeeShopID: idea.CoffeeShopID, Title: "Limited", Description: "One only", TotalStock: &stock}
+		suite.Require().NoError(suite.DB.Create(&rewardType).Error)
+
+		suite.Equal(http.StatusCreated, giveReward(rewardType.ID).Code)
+		suite.Equal(http.StatusConflict, giveReward(rewardType.ID).Code)
+	})
+
+	suite.Run("Monthly budget", func() {
+		budget := 1
+		rewardType := models.RewardType{CoffeeShopID: idea.CoffeeShopID, Title: "Monthly", Description: "One per month", MonthlyBudget: &budget}
+		suite.Require().NoError(suite.DB.Create(&rewardType).Error)
+
+		w := giveReward(rewardType.ID)
+		suite.Require().Equal(http.StatusCreated, w.Code)
+		suite.Equal(http.StatusConflict, giveReward(rewardType.ID).Code)
+
+		// A reward given last month does not count against this month's budget
+		var reward dto.RewardResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &reward))
+		suite.Require().NoError(suite.DB.Model(&models.Reward{}).Where("id = ?", reward.ID).
+			UpdateColumn("given_at", time.Now().AddDate(0, -1, -1)).Error)
+		suite.Equal(http.StatusCreated, giveReward(rewardType.ID).Code)
+	})
+}
+
+func (suite *RewardIntegrationTestSuite) TestRewardExpiry() {
+	adminToken, coffeeShop, idea, _, authorToken := suite.createAdminTestPrerequisites()
+
+	validity := 7
+	rewardType := models.RewardType{CoffeeShopID: &coffeeShop.ID, Title: "Weekly", Description: "Valid for a week", ValidityDays: &validity}
+	suite.Require().NoError(suite.DB.Create(&rewardType).Error)
+
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: "/api/v1/admin/rewards", token: adminToken, contentType: "application/json",
+		body: dto.GiveRewardRequest{IdeaID: idea.ID, RewardTypeID: rewardType.ID},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code)
+	var reward dto.RewardResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &reward))
+	suite.Require().NotNil(reward.ExpiresAt)
+	suite.WithinDuration(reward.GivenAt.AddDate(0, 0, validity), *reward.ExpiresAt, time.Second)
+	suite.False(reward.IsExpired)
+
+	suite.Require().NoError(suite.DB.Model(&models.Reward{}).Where("id = ?", reward.ID).
+		UpdateColumn("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	expired, err := suite.RewardRepo.ExpireRewards(suite.Ctx, time.Now())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), expired)
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me/rewards", token: authorToken})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var page pagination.Page[dto.RewardResponse]
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &page))
+	suite.Require().Len(page.Items, 1)
+	suite.True(page.Items[0].IsExpired)
+	suite.Nil(page.Items[0].RedemptionCode)
+}
@@ -47,7 +47,7 @@ func (suite *RewardTypeIntegrationTestSuite) TestCreateRewardType() {
 				token := suite.RegisterUserAndGetToken(&admin)
 				shop, err := suite.CoffeeShopRepo.CreateCoffeeShop(suite.Ctx, &models.CoffeeShop{
 					Name:      "test coffee shop",
-					CreatorID: admin.ID,
+					CreatorID: &admin.ID,
 				})
 				suite.Require().NoError(err)
 
@@ -59,9 +59,46 @@ func (suite *RewardTypeIntegrationTestSuite) TestCreateRewardType() {
 				}).Error
 				suite.Require().NoError(err)
 
+				stock := 100
 				req := dto.CreateRewardTypeRequest{
 					CoffeeShopID: shop.ID,
+					Title:        "Free latte",
 					Description:  "test reward type",
+					TotalStock:   &stock,
+				}
+				return token, req
+			},
+		},
+		{
+			name:           "create reward type with non-positive limit",
+			expectedStatus: http.StatusBadRequest,
+			needCheckResp:  false,
+			setup: func() (string, dto.CreateRewardTypeRequest) {
+				adminName := "limit admin name"
+				adminPhone := "7778"
+				admin := models.User{
+					Name:  &adminName,
+					Phone: &adminPhone,
+				}
+				token := suite.RegisterUserAndGetToken(&admin)
+				shop, err := suite.CoffeeShopRepo.CreateCoffeeShop(suite.Ctx, &models.CoffeeShop{
+					Name:      "test coffee shop",
+					CreatorID: &admin.ID,
+				})
+				suite.Require().NoError(err)
+
+				err = suite.DB.Create(&models.WorkerCoffeeShop{
+					WorkerID:     &admin.ID,
+					CoffeeShopID: &shop.ID,
+					RoleID:       &suite.AdminRoleID,
+				}).Error
+				suite.Require().NoError(err)
+
+				budget := 0
+				req := dto.CreateRewardTypeRequest{
+					CoffeeShopID:  shop.ID,
+					Description:   "test reward type",
+					MonthlyBudget: &budget,
 				}
 				return token, req
 			},
@@ -80,7 +117,7 @@ func (suite *RewardTypeIntegrationTestSuite) TestCreateRewardType() {
 				token := suite.RegisterUserAndGetToken(&user)
 				shop, err := suite.CoffeeShopRepo.CreateCoffeeShop(suite.Ctx, &models.CoffeeShop{
 					Name:      "test coffee shop",
-					CreatorID: user.ID,
+					CreatorID: &user.ID,
 				})
 				suite.Require().NoError(err)
 				req := dto.CreateRewardTypeRequest{
@@ -110,6 +147,9 @@ func (suite *RewardTypeIntegrationTestSuite) TestCreateRewardType() {
 				suite.Require().NoError(err)
 				suite.Equal(resp.CoffeeShopID, r.CoffeeShopID)
 				suite.Equal(resp.Description, r.Description)
+				suite.Equal(resp.Title, r.Title)
+				suite.Equal(resp.TotalStock, r.TotalStock)
+				suite.Nil(resp.MonthlyBudget)
 
 			}
 		})
@@ -0,0 +1,265 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/blobstore"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type UploadIntegrationTestSuite struct {
+	BaseTestSuite
+}
+
+func TestUploadIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(UploadIntegrationTestSuite))
+}
+
+// createIdea creates a user with an idea and returns the token of the user and the idea.
+func (suite *UploadIntegrationTestSuite) createIdea(phone string) (string, *models.Idea) {
+	user := suite.CreateUser("uploader", phone)
+	token := suite.RegisterUserAndGetToken(user)
+
+	coffeeShop := &models.CoffeeShop{Name: "Upload Coffee Shop", Address: "1 Upload St", CreatorID: &user.ID}
+	suite.Require().NoError(suite.DB.Create(coffeeShop).Error)
+	idea := &models.Idea{Title: "Idea", Description: "Needs a picture.", CreatorID: &user.ID, CoffeeShopID: &coffeeShop.ID}
+	suite.Require().NoError(suite.DB.Create(idea).Error)
+	return token, idea
+}
+
+func (suite *UploadIntegrationTestSuite) createUpload(token, contentType string, size int) dto.UploadResponse {
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost,
+		path:   "/api/v1/uploads",
+		token:  token,
+		body:   dto.CreateUploadRequest{ContentType: contentType, Size: int64(size)},
+	})
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+
+	var resp dto.UploadResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+// sendFile sends data to the presigned URL of the upload with the given headers.
+func (suite *UploadIntegrationTestSuite) sendFile(uploadURL string, headers map[string]string, data []byte) int {
+	u, err := url.Parse(uploadURL)
+	suite.Require().NoError(err)
+	w := suite.MakeRequest(TestRequest{
+		method:  http.MethodPut,
+		path:    u.RequestURI(),
+		rawBody: data,
+		headers: headers,
+	})
+	return w.Code
+}
+
+func (suite *UploadIntegrationTestSuite) attach(token string, ideaID, uploadID uuid.UUID) int {
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPut,
+		path:   fmt.Sprintf("/api/v1/ideas/%s", ideaID),
+		token:  token,
+		body:   dto.UpdateIdeaRequest{UploadID: &uploadID},
+	})
+	return w.Code
+}
+
+func (suite *UploadIntegrationTestSuite) TestDirectUpload() {
+	token, idea := suite.createIdea("333333333")
+	data := suite.JPEGImage(1600, 400)
+
+	upload := suite.createUpload(token, "image/jpeg", len(data))
+	suite.Equal(http.MethodPut, upload.Method)
+	suite.True(strings.HasPrefix(upload.URL, suite.cfg.Storage.LocalPublicURL+"/uploads/"))
+	suite.True(upload.ExpiresAt.After(time.Now()))
+
+	suite.Equal(http.StatusBadRequest, suite.attach(token, idea.ID, upload.UploadID), "the file is not uploaded yet")
+	suite.Equal(http.StatusOK, suite.sendFile(upload.URL, upload.Headers, data))
+	suite.Equal(http.StatusNoContent, suite.attach(token, idea.ID, upload.UploadID))
+
+	w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/ideas/%s", idea.ID)})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var resp dto.IdeaResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Require().NotNil(resp.ImageURL)
+	suite.True(strings.HasSuffix(*resp.ImageURL, "/original.jpg"), *resp.ImageURL)
+	suite.NotNil(resp.ImageThumbnailURL)
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/images/" + *resp.ImageThumbnailURL})
+	suite.Equal(http.StatusOK, w.Code)
+
+	stored, err := suite.ImageUploadRepo.GetByID(suite.Ctx, upload.UploadID)
+	suite.Require().NoError(err)
+	suite.NotNil(stored.FinalizedAt)
+	_, err = suite.Store.Stat(suite.Ctx, stored.ObjectKey)
+	suite.ErrorIs(err, blobstore.ErrNotFound, "only the processed image is kept")
+
+	suite.Equal(http.StatusNoContent, suite.attach(token, idea.ID, upload.UploadID), "finalizing again is allowed")
+	var reloaded models.Idea
+	suite.Require().NoError(suite.DB.First(&reloaded, "id = ?", idea.ID).Error)
+	suite.Equal(*resp.ImageURL, *reloaded.ImageURL, "the same image is attached")
+}
+
+func (suite *UploadIntegrationTestSuite) TestPendingUploadsAreNotServed() {
+	token, _ := suite.createIdea("222333444")
+	data := suite.JPEGImage(64, 48)
+	upload := suite.createUpload(token, "image/jpeg", len(data))
+	suite.Require().Equal(http.StatusOK, suite.sendFile(upload.URL, upload.Headers, data))
+
+	stored, err := suite.ImageUploadRepo.GetByID(suite.Ctx, upload.UploadID)
+	suite.Require().NoError(err)
+	_, err = suite.Store.Stat(suite.Ctx, stored.ObjectKey)
+	suite.Require().NoError(err, "the file is stored as it was sent")
+
+	for _, path := range []string{
+		"/api/v1/images/" + stored.ObjectKey,
+		"/api/v1/images/" + suite.cfg.ImageDB.BucketName + "/" + stored.ObjectKey,
+	} {
+		suite.Run(path, func() {
+			w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: path})
+			suite.Equal(http.StatusNotFound, w.Code)
+		})
+	}
+}
+
+func (suite *UploadIntegrationTestSuite) TestCreateUploadValidation() {
+	token := suite.GetRandomAuthToken()
+
+	tests := []struct {
+		name           string
+		token          string
+		body           any
+		expectedStatus int
+	}{
+		{"Unsupported content type", token, dto.CreateUploadRequest{ContentType: "image/gif", Size: 100}, http.StatusBadRequest},
+		{"Too large", token, dto.CreateUploadRequest{ContentType: "image/jpeg", Size: suite.cfg.Image.MaxBytes + 1}, http.StatusBadRequest},
+		{"Missing size", token, map[string]any{"content_type": "image/jpeg"}, http.StatusBadRequest},
+		{"Unauthorized", "", dto.CreateUploadRequest{ContentType: "image/jpeg", Size: 100}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			w := suite.MakeRequest(TestRequest{method: http.MethodPost, path: "/api/v1/uploads", token: tt.token, body: tt.body})
+			suite.Equal(tt.expectedStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func (suite *UploadIntegrationTestSuite) TestStorageRejectsMismatchedUploads() {
+	token, _ := suite.createIdea("444444444")
+	data := suite.JPEGImage(64, 48)
+	upload := suite.createUpload(token, "image/jpeg", len(data))
+
+	suite.Run("Other content type", func() {
+		suite.Equal(http.StatusForbidden, suite.sendFile(upload.URL, map[string]string{"Content-Type": "text/html"}, data))
+	})
+	suite.Run("Other size", func() {
+		suite.Equal(http.StatusForbidden, suite.sendFile(upload.URL, upload.Headers, append(data, 0)))
+	})
+	suite.Run("Other key", func() {
+		otherKey := strings.Replace(upload.URL, "/uploads/", "/uploads/x", 1)
+		suite.Equal(http.StatusForbidden, suite.sendFile(otherKey, upload.Headers, data))
+	})
+	suite.Run("No signature", func() {
+		unsigned := strings.SplitN(upload.URL, "?", 2)[0]
+		suite.Equal(http.StatusForbidden, suite.sendFile(unsigned, upload.Headers, data))
+	})
+}
+
+func (suite *UploadIntegrationTestSuite) TestFinalizeRejectsInvalidUploads() {
+	token, idea := suite.createIdea("555555555")
+
+	suite.Run("Upload of another user", func() {
+		data := suite.JPEGImage(64, 48)
+		otherToken, _ := suite.createIdea("666666666")
+		upload := suite.createUpload(otherToken, "image/jpeg", len(data))
+		suite.Require().Equal(http.StatusOK, suite.sendFile(upload.URL, upload.Headers, data))
+		suite.Equal(http.StatusNotFound, suite.attach(token, idea.ID, upload.UploadID))
+	})
+
+	suite.Run("Unknown upload", func() {
+		suite.Equal(http.StatusNotFound, suite.attach(token, idea.ID, uuid.New()))
+	})
+
+	suite.Run("File is not an image", func() {
+		data := []byte("definitely not an image")
+		upload := suite.createUpload(token, "image/jpeg", len(data))
+		suite.Require().Equal(http.StatusOK, suite.sendFile(upload.URL, upload.Headers, data))
+		suite.Equal(http.StatusBadRequest, suite.attach(token, idea.ID, upload.UploadID))
+	})
+
+	suite.Run("Expired upload", func() {
+		data := suite.JPEGImage(64, 48)
+		upload := suite.createUpload(token, "image/jpeg", len(data))
+		suite.Require().Equal(http.StatusOK, suite.sendFile(upload.URL, upload.Headers, data))
+		suite.Require().NoError(suite.DB.Model(&models.ImageUpload{}).Where("id = ?", upload.UploadID).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+		suite.Equal(http.StatusBadRequest, suite.attach(token, idea.ID, upload.UploadID))
+	})
+
+	suite.Run("Both image_url and upload_id", func() {
+		imageURL := "test-bucket/image.jpg"
+		uploadID := uuid.New()
+		w := suite.MakeRequest(TestRequest{
+			method: http.MethodPut,
+			path:   fmt.Sprintf("/api/v1/ideas/%s", idea.ID),
+			token:  token,
+			body:   dto.UpdateIdeaRequest{ImageURL: &imageURL, UploadID: &uploadID},
+		})
+		suite.Equal(http.StatusBadRequest, w.Code)
+	})
+}
+
+func (suite *UploadIntegrationTestSuite) TestRejectedUpdateKeepsUploadPending() {
+	_, idea := suite.createIdea("888777666")
+	otherToken, _ := suite.createIdea("888777555")
+	data := suite.JPEGImage(64, 48)
+	upload := suite.createUpload(otherToken, "image/jpeg", len(data))
+	suite.Require().Equal(http.StatusOK, suite.sendFile(upload.URL, upload.Headers, data))
+
+	suite.Equal(http.StatusForbidden, suite.attach(otherToken, idea.ID, upload.UploadID), "the idea belongs to another user")
+	suite.Equal(http.StatusNotFound, suite.attach(otherToken, uuid.New(), upload.UploadID), "the idea does not exist")
+
+	stored, err := suite.ImageUploadRepo.GetByID(suite.Ctx, upload.UploadID)
+	suite.Require().NoError(err)
+	suite.Nil(stored.FinalizedAt)
+	suite.Nil(stored.ImageURL)
+	var images int64
+	suite.Require().NoError(suite.DB.Model(&models.StoredImage{}).Count(&images).Error)
+	suite.Zero(images, "no image is stored for a rejected update")
+}
+
+func (suite *UploadIntegrationTestSuite) TestCleanupUploads() {
+	token, _ := suite.createIdea("777777777")
+	data := suite.JPEGImage(64, 48)
+
+	expired := suite.createUpload(token, "image/jpeg", len(data))
+	suite.Require().Equal(http.StatusOK, suite.sendFile(expired.URL, expired.Headers, data))
+	suite.Require().NoError(suite.DB.Model(&models.ImageUpload{}).Where("id = ?", expired.UploadID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	expiredUpload, err := suite.ImageUploadRepo.GetByID(suite.Ctx, expired.UploadID)
+	suite.Require().NoError(err)
+
+	pending := suite.createUpload(token, "image/jpeg", len(data))
+	suite.Require().Equal(http.StatusOK, suite.sendFile(pending.URL, pending.Headers, data))
+
+	suite.Require().NoError(suite.ImageUsecase.CleanupUploads(suite.Ctx))
+
+	_, err = suite.ImageUploadRepo.GetByID(suite.Ctx, expired.UploadID)
+	suite.Error(err, "the expired upload is deleted")
+	_, err = suite.Store.Stat(suite.Ctx, expiredUpload.ObjectKey)
+	suite.ErrorIs(err, blobstore.ErrNotFound, "together with its file")
+
+	pendingUpload, err := suite.ImageUploadRepo.GetByID(suite.Ctx, pending.UploadID)
+	suite.Require().NoError(err, "the upload that has not expired is kept")
+	_, err = suite.Store.Stat(suite.Ctx, pendingUpload.ObjectKey)
+	suite.NoError(err)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -35,7 +36,7 @@ func (suite *UserIdeasIntegrationTestSuite) createIdeaPrerequisites() (string, *
 	coffeeShop := &models.CoffeeShop{
 		Name:      "User Ideas Shop",
 		Address:   "456 User Ideas St",
-		CreatorID: user.ID,
+		CreatorID: &user.ID,
 	}
 	err := suite.DB.Create(coffeeShop).Error
 	suite.Require().NoError(err)
@@ -84,12 +85,12 @@ func (suite *UserIdeasIntegrationTestSuite) TestGetIdeasFromUser() {
 
 		suite.Equal(http.StatusOK, w.Code)
 
-		var resp []dto.IdeaResponse
+		var resp pagination.Page[dto.IdeaResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.GreaterOrEqual(len(resp), 2)
+		suite.GreaterOrEqual(len(resp.Items), 2)
 		
-		ids := []string{resp[0].ID.String(), resp[1].ID.String()}
+		ids := []string{resp.Items[0].ID.String(), resp.Items[1].ID.String()}
 		suite.Contains(ids, idea1.ID.String())
 		suite.Contains(ids, idea2.ID.String())
 	})
@@ -98,15 +99,16 @@ func (suite *UserIdeasIntegrationTestSuite) TestGetIdeasFromUser() {
 	suite.Run("Get ideas with pagination", func() {
 		req := TestRequest{
 			method: http.MethodGet,
-			path:   "/api/v1/users/me/ideas?limit=1&page=1",
+			path:   "/api/v1/users/me/ideas?limit=1",
 			token:  token,
 		}
 		w := suite.MakeRequest(req)
 		suite.Equal(http.StatusOK, w.Code)
-		var resp []dto.IdeaResponse
+		var resp pagination.Page[dto.IdeaResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.Len(resp, 1)
+		suite.Len(resp.Items, 1)
+		suite.NotEmpty(resp.NextCursor)
 	})
 	
 	// Test with sort
@@ -8,6 +8,8 @@ import (
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/stretchr/testify/suite"
@@ -32,38 +34,63 @@ func TestRouterTestSuite(t *testing.T) {
 }
 
 func (suite *RouterTestSuite) TestGetAllUsers() {
-	    admin := suite.CreateUser("admin", "33333")
-		adminToken := suite.GetAuthToken(*admin.Phone, "333", *admin.Name)
-		// Create a coffee shop and make the user an admin
-		coffeeShop := &models.CoffeeShop{Name: "Admin's Test Shop", CreatorID: admin.ID, Address: "123 Admin Lane"}
-		suite.DB.Create(coffeeShop)
-		suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &admin.ID, CoffeeShopID: &coffeeShop.ID, RoleID: &suite.AdminRoleID})
-	
+	admin := suite.CreateUser("admin", "33333")
+	adminToken := suite.GetAuthToken(*admin.Phone, "333", *admin.Name)
+	// Create a coffee shop and make the user an admin
+	coffeeShop := &models.CoffeeShop{Name: "Admin's Test Shop", CreatorID: &admin.ID, Address: "123 Admin Lane"}
+	suite.DB.Create(coffeeShop)
+	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &admin.ID, CoffeeShopID: &coffeeShop.ID, RoleID: &suite.AdminRoleID})
+
+	author := suite.CreateUser("author", "44444")
+	suite.Require().NoError(suite.DB.Create(&models.Idea{Title: "Idea", Description: "Idea", CreatorID: &author.ID, CoffeeShopID: &coffeeShop.ID}).Error)
+	suite.CreateUser("stranger", "55555")
+
+	otherAdmin := suite.CreateUser("other admin", "66666")
+	otherAdminToken := suite.GetAuthToken(*otherAdmin.Phone, "666", *otherAdmin.Name)
+	otherShop := &models.CoffeeShop{Name: "Other Shop", CreatorID: &otherAdmin.ID, Address: "1 Other St"}
+	suite.DB.Create(otherShop)
+	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &otherAdmin.ID, CoffeeShopID: &otherShop.ID, RoleID: &suite.AdminRoleID})
+
 	userToken := suite.GetRandomAuthToken()
+	shopPath := "/api/v1/users?coffee_shop_id=" + coffeeShop.ID.String()
 
 	tests := []struct {
 		name           string
 		token          string
+		path           string
 		expectedStatus int
 		checkResponse  bool
 	}{
 		{
-			name:           "admin gets all users",
+			name:           "admin gets the users of the shop",
 			token:          adminToken,
+			path:           shopPath,
 			expectedStatus: http.StatusOK,
 			checkResponse:  true,
 		},
 		{
-			name:           "user fails to get all users",
+			name:           "admin of another shop fails to get the users of the shop",
+			token:          otherAdminToken,
+			path:           shopPath,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "user fails to get the users of the shop",
 			token:          userToken,
+			path:           shopPath,
 			expectedStatus: http.StatusForbidden,
-			checkResponse:  false,
+		},
+		{
+			name:           "coffee shop is required",
+			token:          adminToken,
+			path:           "/api/v1/users",
+			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:           "unauthorized get fails",
 			token:          "",
+			path:           shopPath,
 			expectedStatus: http.StatusUnauthorized,
-			checkResponse:  false,
 		},
 	}
 
@@ -71,18 +98,22 @@ func (suite *RouterTestSuite) TestGetAllUsers() {
 		suite.Run(tc.name, func() {
 			req := TestRequest{
 				method: "GET",
-				path:   "/api/v1/users",
+				path:   tc.path,
 				token:  tc.token,
 			}
 			w := suite.MakeRequest(req)
 			suite.Equal(tc.expectedStatus, w.Code)
 
 			if tc.checkResponse {
-				var resp []dto.UserResponse
+				var resp pagination.Page[dto.UserResponse]
 				err := json.Unmarshal(w.Body.Bytes(), &resp)
 				suite.NoError(err)
-				// 2 users created in setup + admin + random user
-				suite.GreaterOrEqual(len(resp), 2)
+				// The admin as a worker and the author of an idea, not the other users
+				var ids []uuid.UUID
+				for _, user := range resp.Items {
+					ids = append(ids, user.ID)
+				}
+				suite.ElementsMatch([]uuid.UUID{admin.ID, author.ID}, ids)
 			}
 		})
 	}
@@ -98,10 +129,29 @@ func (suite *RouterTestSuite) TestGetUser() {
 	admin := suite.CreateUser("admin", "33333")
 	adminToken := suite.GetAuthToken(*admin.Phone, "333", *admin.Name)
 	// Create a coffee shop and make the user an admin
-	coffeeShop := &models.CoffeeShop{Name: "Admin's Test Shop", CreatorID: admin.ID, Address: "123 Admin Lane"}
+	coffeeShop := &models.CoffeeShop{Name: "Admin's Test Shop", CreatorID: &admin.ID, Address: "123 Admin Lane"}
 	suite.DB.Create(coffeeShop)
 	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &admin.ID, CoffeeShopID: &coffeeShop.ID, RoleID: &suite.AdminRoleID})
+	// The target posted an idea in the shop
+	suite.Require().NoError(suite.DB.Create(&models.Idea{Title: "Idea", Description: "Idea", CreatorID: &targetUser.ID, CoffeeShopID: &coffeeShop.ID}).Error)
+
+	otherAdmin := suite.CreateUser("other admin", "66666")
+	otherAdminToken := suite.GetAuthToken(*otherAdmin.Phone, "666", *otherAdmin.Name)
+	otherShop := &models.CoffeeShop{Name: "Other Shop", CreatorID: &otherAdmin.ID, Address: "1 Other St"}
+	suite.DB.Create(otherShop)
+	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &otherAdmin.ID, CoffeeShopID: &otherShop.ID, RoleID: &suite.AdminRoleID})
+
+	barista := suite.CreateUser("barista", "77777")
+	baristaToken := suite.GetAuthToken(*barista.Phone, "777", *barista.Name)
+	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &barista.ID, CoffeeShopID: &coffeeShop.ID, RoleID: &suite.BaristaRoleID})
 
+	// The admin of the organization of the shop inherits the role in it
+	orgAdmin := suite.CreateUser("organization admin", "88888")
+	orgAdminToken := suite.GetAuthToken(*orgAdmin.Phone, "888", *orgAdmin.Name)
+	organization := &models.Organization{Name: "Admin's Organization", CreatorID: &orgAdmin.ID}
+	suite.Require().NoError(suite.DB.Create(organization).Error)
+	suite.Require().NoError(suite.DB.Model(coffeeShop).Update("organization_id", organization.ID).Error)
+	suite.Require().NoError(suite.DB.Create(&models.OrganizationMember{OrganizationID: organization.ID, UserID: orgAdmin.ID, RoleID: suite.AdminRoleID}).Error)
 
 	tests := []struct {
 		name           string
@@ -122,11 +172,27 @@ func (suite *RouterTestSuite) TestGetUser() {
 			checkResponse:  false,
 		},
 		{
-			name:           "admin gets other's profile",
+			name:           "admin gets the profile of a user of the shop",
 			token:          adminToken,
 			expectedStatus: http.StatusOK,
 			checkResponse:  true,
 		},
+		{
+			name:           "admin of the organization of the shop gets the profile",
+			token:          orgAdminToken,
+			expectedStatus: http.StatusOK,
+			checkResponse:  true,
+		},
+		{
+			name:           "admin of another shop fails to get the profile",
+			token:          otherAdminToken,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "barista of the shop fails to get the profile",
+			token:          baristaToken,
+			expectedStatus: http.StatusForbidden,
+		},
 		{
 			name:           "unauthorized get fails",
 			token:          "",
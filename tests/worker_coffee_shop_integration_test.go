@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
 	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 )
@@ -63,13 +65,13 @@ func (suite *WorkerCoffeeShopIntegrationTestSuite) createWorkerTestPrerequisites
 	shop = &models.CoffeeShop{
 		Name:      "Test Shop for Workers",
 		Address:   "123 Worker St",
-		CreatorID: creator.ID,
+		CreatorID: &creator.ID,
 	}
 	err := suite.DB.Create(shop).Error
 	suite.Require().NoError(err)
 
-	// Make creator and admin workers in the shop with admin role
-	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &creator.ID, CoffeeShopID: &shop.ID, RoleID: &suite.AdminRoleID})
+	// Make the creator the owner and the admin a worker with the admin role
+	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &creator.ID, CoffeeShopID: &shop.ID, RoleID: &suite.OwnerRoleID})
 	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &admin.ID, CoffeeShopID: &shop.ID, RoleID: &suite.AdminRoleID})
 
 	return
@@ -153,10 +155,10 @@ func (suite *WorkerCoffeeShopIntegrationTestSuite) TestListAndRemoveWorker() {
 		}
 		w := suite.MakeRequest(req)
 		suite.Equal(http.StatusOK, w.Code)
-		var resp []dto.UserResponse
+		var resp pagination.Page[dto.UserResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.Len(resp, 3) // creator + admin + new worker
+		suite.Len(resp.Items, 3) // creator + admin + new worker
 	})
 
 	suite.Run("List Workers - Fail by non-admin", func() {
@@ -203,15 +205,15 @@ func (suite *WorkerCoffeeShopIntegrationTestSuite) TestListAndRemoveWorker() {
 		}
 		w := suite.MakeRequest(req)
 		suite.Equal(http.StatusOK, w.Code)
-		var resp []dto.UserResponse
+		var resp pagination.Page[dto.UserResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.Len(resp, 2) // Only creator + admin should remain
+		suite.Len(resp.Items, 2) // Only creator + admin should remain
 
 		// Verify that the correct users are present
 		foundCreator := false
 		foundAdmin := false
-		for _, u := range resp {
+		for _, u := range resp.Items {
 			if u.ID == creator.ID {
 				foundCreator = true
 			}
@@ -230,7 +232,7 @@ func (suite *WorkerCoffeeShopIntegrationTestSuite) TestListShopsForWorker() {
 	creator, _, _, _, worker, workerToken, otherUser, otherUserToken, shop1 := suite.createWorkerTestPrerequisites()
 
 	// Create a second shop and add the worker to it as well
-	shop2 := &models.CoffeeShop{Name: "Second Shop", Address: "456 Second St", CreatorID: creator.ID}
+	shop2 := &models.CoffeeShop{Name: "Second Shop", Address: "456 Second St", CreatorID: &creator.ID}
 	suite.DB.Create(shop2)
 	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &worker.ID, CoffeeShopID: &shop1.ID})
 	suite.DB.Create(&models.WorkerCoffeeShop{WorkerID: &worker.ID, CoffeeShopID: &shop2.ID})
@@ -245,10 +247,10 @@ func (suite *WorkerCoffeeShopIntegrationTestSuite) TestListShopsForWorker() {
 		}
 		w := suite.MakeRequest(req)
 		suite.Equal(http.StatusOK, w.Code)
-		var resp []dto.CoffeeShopResponse
+		var resp pagination.Page[dto.CoffeeShopResponse]
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		suite.NoError(err)
-		suite.Len(resp, 2)
+		suite.Len(resp.Items, 2)
 	})
 
 	suite.Run("Fail - User tries to list another user's shops", func() {
@@ -271,3 +273,64 @@ func (suite *WorkerCoffeeShopIntegrationTestSuite) TestListShopsForWorker() {
 		suite.Equal(http.StatusUnauthorized, w.Code)
 	})
 }
+
+func (suite *WorkerCoffeeShopIntegrationTestSuite) TestWorkerRoles() {
+	creator, creatorToken, _, adminToken, worker, workerToken, otherUser, _, shop := suite.createWorkerTestPrerequisites()
+
+	addWorker := func(body dto.AddWorkerToShopRequest) *httptest.ResponseRecorder {
+		return suite.MakeRequest(TestRequest{method: http.MethodPost, path: "/api/v1/admin/worker-coffee-shops", token: creatorToken, body: body, contentType: "application/json"})
+	}
+	listBans := func(token string) int {
+		return suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s/bans", shop.ID), token: token}).Code
+	}
+
+	w := addWorker(dto.AddWorkerToShopRequest{WorkerID: worker.ID, CoffeeShopID: shop.ID, Role: models.RoleModerator})
+	suite.Require().Equal(http.StatusCreated, w.Code, w.Body.String())
+	var relation dto.WorkerCoffeeShopResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &relation))
+	suite.Equal(models.RoleModerator, relation.Role)
+
+	suite.Run("Fail - Owner and unknown roles cannot be assigned", func() {
+		for _, role := range []string{models.RoleOwner, "chef"} {
+			w := addWorker(dto.AddWorkerToShopRequest{WorkerID: otherUser.ID, CoffeeShopID: shop.ID, Role: role})
+			suite.Equal(http.StatusBadRequest, w.Code, role)
+		}
+	})
+
+	suite.Run("Moderator can list bans but cannot manage the shop", func() {
+		suite.Equal(http.StatusOK, listBans(workerToken))
+
+		w := suite.MakeRequest(TestRequest{
+			method: http.MethodPost, path: fmt.Sprintf("/api/v1/coffee-shops/%s/categories", shop.ID), token: workerToken,
+			body: dto.CreateCategory{Title: "Drinks"}, contentType: "application/json",
+		})
+		suite.Equal(http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	suite.Run("Admin changes the role of a worker", func() {
+		w := suite.MakeRequest(TestRequest{
+			method: http.MethodPut, path: fmt.Sprintf("/api/v1/admin/worker-coffee-shops/%s/role", relation.ID), token: adminToken,
+			body: dto.ChangeWorkerRoleRequest{Role: models.RoleBarista}, contentType: "application/json",
+		})
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+		var resp dto.WorkerCoffeeShopResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal(models.RoleBarista, resp.Role)
+
+		suite.Equal(http.StatusForbidden, listBans(workerToken), "a barista cannot see bans")
+	})
+
+	suite.Run("Fail - Owner cannot be removed or demoted", func() {
+		var owner models.WorkerCoffeeShop
+		suite.Require().NoError(suite.DB.First(&owner, "worker_id = ? AND coffee_shop_id = ?", creator.ID, shop.ID).Error)
+
+		w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/admin/worker-coffee-shops/%s", owner.ID), token: adminToken})
+		suite.Equal(http.StatusForbidden, w.Code)
+
+		w = suite.MakeRequest(TestRequest{
+			method: http.MethodPut, path: fmt.Sprintf("/api/v1/admin/worker-coffee-shops/%s/role", owner.ID), token: adminToken,
+			body: dto.ChangeWorkerRoleRequest{Role: models.RoleAdmin}, contentType: "application/json",
+		})
+		suite.Equal(http.StatusForbidden, w.Code)
+	})
+}
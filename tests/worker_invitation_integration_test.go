@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeorgiiMalishev/ideas-platform/internal/dto"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/models"
+	"github.com/GeorgiiMalishev/ideas-platform/internal/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkerInvitationIntegrationTestSuite struct {
+	BaseTestSuite
+}
+
+func (suite *WorkerInvitationIntegrationTestSuite) SetupSuite() {
+	suite.BaseTestSuite.SetupSuite()
+}
+
+func (suite *WorkerInvitationIntegrationTestSuite) TearDownTest() {
+	suite.BaseTestSuite.TearDownTest()
+}
+
+func TestWorkerInvitationIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerInvitationIntegrationTestSuite))
+}
+
+func (suite *WorkerInvitationIntegrationTestSuite) createInvitation(token string, shopID uuid.UUID, body dto.CreateInvitationRequest) (int, dto.InvitationResponse) {
+	w := suite.MakeRequest(TestRequest{
+		method: http.MethodPost, path: fmt.Sprintf("/api/v1/coffee-shops/%s/invitations", shopID), token: token,
+		body: body, contentType: "application/json",
+	})
+	var resp dto.InvitationResponse
+	if w.Code == http.StatusCreated {
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+// invitePhone returns the phone of the user as an owner would type it.
+func invitePhone(user *models.User) *string {
+	phone := "+7" + *user.Phone
+	return &phone
+}
+
+func (suite *WorkerInvitationIntegrationTestSuite) post(token, path string, body any) int {
+	req := TestRequest{method: http.MethodPost, path: path, token: token}
+	if body != nil {
+		req.body = body
+		req.contentType = "application/json"
+	}
+	return suite.MakeRequest(req).Code
+}
+
+func (suite *WorkerInvitationIntegrationTestSuite) TestPhoneInvitation() {
+	owner, shop := suite.CreateTestUser("inv-owner", "111111111", "Invitation Shop", "1 Invite St", suite.OwnerRoleID)
+	ownerToken := suite.RegisterUserAndGetToken(owner)
+	invitee := suite.CreateUser("inv-invitee", "9990002222")
+	inviteeToken := suite.RegisterUserAndGetToken(invitee)
+	stranger := suite.CreateUser("inv-stranger", "9990003333")
+	strangerToken := suite.RegisterUserAndGetToken(stranger)
+
+	code, _ := suite.createInvitation(strangerToken, shop.ID, dto.CreateInvitationRequest{Phone: invitePhone(invitee)})
+	suite.Equal(http.StatusForbidden, code, "a non-worker cannot invite")
+
+	code, _ = suite.createInvitation(ownerToken, shop.ID, dto.CreateInvitationRequest{Phone: invitePhone(invitee), Role: models.RoleOwner})
+	suite.Equal(http.StatusBadRequest, code, "ownership cannot be handed out by invitation")
+
+	code, invitation := suite.createInvitation(ownerToken, shop.ID, dto.CreateInvitationRequest{Phone: invitePhone(invitee), Role: models.RoleModerator})
+	suite.Require().Equal(http.StatusCreated, code)
+	suite.Equal(models.InvitationPending, invitation.Status)
+	suite.Empty(invitation.Token, "phone invitations have no link token")
+	suite.Equal(invitee.Phone, invitation.Phone, "the phone is stored like the phone of the user")
+
+	suite.Run("Invitee sees the invitation", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me/invitations", token: inviteeToken})
+		suite.Require().Equal(http.StatusOK, w.Code)
+		var resp pagination.Page[dto.InvitationResponse]
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Require().Len(resp.Items, 1)
+		suite.Equal(invitation.ID, resp.Items[0].ID)
+		suite.Equal(shop.Name, resp.Items[0].CoffeeShopName)
+	})
+
+	suite.Run("Fail - Invitation addressed to another phone", func() {
+		suite.Equal(http.StatusNotFound, suite.post(strangerToken, fmt.Sprintf("/api/v1/invitations/%s/accept", invitation.ID), nil))
+	})
+
+	suite.Run("Invitee accepts and becomes a worker", func() {
+		w := suite.MakeRequest(TestRequest{method: http.MethodPost, path: fmt.Sprintf("/api/v1/invitations/%s/accept", invitation.ID), token: inviteeToken})
+		suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+		var resp dto.WorkerCoffeeShopResponse
+		suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		suite.Equal(invitee.ID, resp.Worker.ID)
+		suite.Equal(shop.ID, resp.CoffeeShop.ID)
+		suite.Equal(models.RoleModerator, resp.Role)
+
+		suite.Equal(http.StatusConflict, suite.post(inviteeToken, fmt.Sprintf("/api/v1/invitations/%s/accept", invitation.ID), nil))
+	})
+
+	suite.Run("Fail - Invalid phone", func() {
+		for _, phone := range []string{"9990002222", "+1 555 0100", "+7999000222"} {
+			code, _ := suite.createInvitation(ownerToken, shop.ID, dto.CreateInvitationRequest{Phone: &phone})
+			suite.Equal(http.StatusBadRequest, code, phone)
+		}
+	})
+
+	suite.Run("Invitee declines a second invitation", func() {
+		phone := "8" + *invitee.Phone
+		code, second := suite.createInvitation(ownerToken, shop.ID, dto.CreateInvitationRequest{Phone: &phone})
+		suite.Require().Equal(http.StatusCreated, code)
+		suite.Equal(http.StatusOK, suite.post(inviteeToken, fmt.Sprintf("/api/v1/invitations/%s/decline", second.ID), nil))
+		suite.Equal(http.StatusConflict, suite.post(inviteeToken, fmt.Sprintf("/api/v1/invitations/%s/accept", second.ID), nil))
+	})
+}
+
+func (suite *WorkerInvitationIntegrationTestSuite) TestLinkInvitation() {
+	owner, shop := suite.CreateTestUser("link-owner", "111111111", "Link Shop", "1 Link St", suite.OwnerRoleID)
+	ownerToken := suite.RegisterUserAndGetToken(owner)
+	first := suite.CreateUser("link-first", "222222222")
+	firstToken := suite.RegisterUserAndGetToken(first)
+	second := suite.CreateUser("link-second", "333333333")
+	secondToken := suite.RegisterUserAndGetToken(second)
+
+	code, invitation := suite.createInvitation(ownerToken, shop.ID, dto.CreateInvitationRequest{ExpiresInHours: 1})
+	suite.Require().Equal(http.StatusCreated, code)
+	suite.Require().NotEmpty(invitation.Token)
+	suite.Equal(models.RoleBarista, invitation.Role)
+	suite.WithinDuration(time.Now().Add(time.Hour), invitation.ExpiresAt, time.Minute)
+
+	suite.Equal(http.StatusNotFound, suite.post(firstToken, "/api/v1/invitations/accept", dto.AcceptInvitationByTokenRequest{Token: "unknown"}))
+	suite.Equal(http.StatusOK, suite.post(firstToken, "/api/v1/invitations/accept", dto.AcceptInvitationByTokenRequest{Token: invitation.Token}))
+	suite.Equal(http.StatusConflict, suite.post(secondToken, "/api/v1/invitations/accept", dto.AcceptInvitationByTokenRequest{Token: invitation.Token}), "a link is single use")
+
+	code, _ = suite.createInvitation(ownerToken, shop.ID, dto.CreateInvitationRequest{ExpiresInHours: 100000})
+	suite.Equal(http.StatusBadRequest, code, "lifetime is capped")
+}
+
+func (suite *WorkerInvitationIntegrationTestSuite) TestRevokeExpireAndAudit() {
+	owner, shop := suite.CreateTestUser("audit-owner", "111111111", "Audit Shop", "1 Audit St", suite.OwnerRoleID)
+	ownerToken := suite.RegisterUserAndGetToken(owner)
+	invitee := suite.CreateUser("audit-invitee", "9990002222")
+	inviteeToken := suite.RegisterUserAndGetToken(invitee)
+
+	_, revoked := suite.createInvitation(ownerToken, shop.ID, dto.CreateInvitationRequest{Phone: invitePhone(invitee)})
+	_, expired := suite.createInvitation(ownerToken, shop.ID, dto.CreateInvitationRequest{Phone: invitePhone(invitee)})
+	suite.Require().NoError(suite.DB.Model(&models.WorkerInvitation{}).Where("id = ?", expired.ID).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	w := suite.MakeRequest(TestRequest{method: http.MethodDelete, path: fmt.Sprintf("/api/v1/coffee-shops/%s/invitations/%s", shop.ID, revoked.ID), token: ownerToken})
+	suite.Require().Equal(http.StatusOK, w.Code, w.Body.String())
+
+	suite.Equal(http.StatusConflict, suite.post(inviteeToken, fmt.Sprintf("/api/v1/invitations/%s/accept", revoked.ID), nil))
+	suite.Equal(http.StatusConflict, suite.post(inviteeToken, fmt.Sprintf("/api/v1/invitations/%s/accept", expired.ID), nil))
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodGet, path: "/api/v1/users/me/invitations", token: inviteeToken})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var mine pagination.Page[dto.InvitationResponse]
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &mine))
+	suite.Empty(mine.Items)
+
+	w = suite.MakeRequest(TestRequest{method: http.MethodGet, path: fmt.Sprintf("/api/v1/coffee-shops/%s/invitations", shop.ID), token: ownerToken})
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var all pagination.Page[dto.InvitationResponse]
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &all))
+	suite.Require().Len(all.Items, 2)
+	statuses := map[uuid.UUID]dto.InvitationResponse{}
+	for _, i := range all.Items {
+		statuses[i.ID] = i
+	}
+	suite.Equal(models.InvitationRevoked, statuses[revoked.ID].Status)
+	suite.Equal(&owner.ID, statuses[revoked.ID].RevokedByID)
+	suite.Equal(models.InvitationExpired, statuses[expired.ID].Status)
+	suite.Equal(&owner.ID, statuses[expired.ID].InvitedByID)
+}